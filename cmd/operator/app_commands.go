@@ -20,4 +20,5 @@ import (
 
 var appCmds = []cli.Command{
 	controllerCmd,
+	bucketUsageCmd,
 }
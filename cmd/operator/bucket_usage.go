@@ -0,0 +1,120 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/minio/cli"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/controller"
+)
+
+// exports the bucket usage reports of the tenants
+var bucketUsageCmd = cli.Command{
+	Name:   "bucket-usage",
+	Usage:  "Export the bucket and tier usage of MinIO Tenants as CSV or JSON",
+	Action: exportBucketUsage,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "kubeconfig",
+			Usage: "Load configuration from `KUBECONFIG`",
+		},
+		cli.StringFlag{
+			Name:  "namespace, n",
+			Usage: "Namespace of the tenants, defaults to the namespace of the current context",
+		},
+		cli.BoolFlag{
+			Name:  "all-namespaces, A",
+			Usage: "Export the tenants of all the namespaces",
+		},
+		cli.StringFlag{
+			Name:  "tenant, t",
+			Usage: "Only export the tenant with this name",
+		},
+		cli.StringFlag{
+			Name:  "format, f",
+			Value: "csv",
+			Usage: "Output format, `csv` or `json`",
+		},
+	},
+}
+
+func exportBucketUsage(ctx *cli.Context) error {
+	format := ctx.String("format")
+	if format != "csv" && format != "json" {
+		return cli.NewExitError(fmt.Sprintf("unsupported format %q, use csv or json", format), 1)
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = ctx.String("kubeconfig")
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+	cfg, err := clientConfig.ClientConfig()
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("Error building kubeconfig: %v", err), 1)
+	}
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("Error building Kubernetes clientset: %v", err), 1)
+	}
+
+	namespace := ctx.String("namespace")
+	if ctx.Bool("all-namespaces") {
+		namespace = metav1.NamespaceAll
+	} else if namespace == "" {
+		if namespace, _, err = clientConfig.Namespace(); err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+	}
+	selector := miniov2.TenantLabel
+	if tenant := ctx.String("tenant"); tenant != "" {
+		selector = fmt.Sprintf("%s=%s", miniov2.TenantLabel, tenant)
+	}
+	configMaps, err := kubeClient.CoreV1().ConfigMaps(namespace).List(context.Background(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	reports := []*controller.BucketUsageReport{}
+	for i := range configMaps.Items {
+		configMap := &configMaps.Items[i]
+		tenant := miniov2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: configMap.Labels[miniov2.TenantLabel]}}
+		// the other shards of the report are read along with the first one
+		if configMap.Name != tenant.BucketUsageConfigMapName() {
+			continue
+		}
+		report, err := controller.ReadBucketUsageReport(context.Background(), kubeClient, configMap)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("Unreadable bucket usage of tenant %s/%s: %v", configMap.Namespace, tenant.Name, err), 1)
+		}
+		reports = append(reports, report)
+	}
+
+	if format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(reports)
+	}
+	return controller.WriteBucketUsageCSV(os.Stdout, reports)
+}
@@ -176,6 +176,18 @@ const DefaultKESReplicas = 2
 // DefaultEllipticCurve specifies the default elliptic curve to be used for key generation
 var DefaultEllipticCurve = elliptic.P256()
 
+// CertificateKeyAlgorithmECDSA generates ECDSA keys for the certificates, the default
+const CertificateKeyAlgorithmECDSA = "ECDSA"
+
+// CertificateKeyAlgorithmRSA generates RSA keys for the certificates
+const CertificateKeyAlgorithmRSA = "RSA"
+
+// CertificateKeyAlgorithmEd25519 generates Ed25519 keys for the certificates
+const CertificateKeyAlgorithmEd25519 = "Ed25519"
+
+// DefaultRSAKeySize specifies the default size of the RSA keys
+const DefaultRSAKeySize = 2048
+
 // DefaultOrgName specifies the default Org name to be used in automatic certificate generation
 var DefaultOrgName = []string{"system:nodes"}
 
@@ -209,6 +221,16 @@ const monitoringIntervalEnv = "MONITORING_INTERVAL"
 // DefaultMonitoringInterval is how often we run monitoring on tenants
 const DefaultMonitoringInterval = 5
 
+const monitoringWorkersEnv = "MONITORING_WORKERS"
+
+// DefaultMonitoringWorkers is how many tenants are checked for health concurrently
+const DefaultMonitoringWorkers = 10
+
+const monitoringTimeoutEnv = "MONITORING_TIMEOUT"
+
+// DefaultMonitoringTimeout is how long a single tenant health check may take
+const DefaultMonitoringTimeout = 3 * time.Minute
+
 // PrometheusNamespace is the namespace of the prometheus
 const PrometheusNamespace = "PROMETHEUS_NAMESPACE"
 
@@ -223,3 +245,92 @@ const PrometheusAddlScrapeConfigSecret = "minio-prom-additional-scrape-config"
 
 // PrometheusAddlScrapeConfigKey is the key in secret data
 const PrometheusAddlScrapeConfigKey = "prometheus-additional.yaml"
+
+// PrometheusBearerTokenSecretKey is the key in the bearer token secret referenced by the ServiceMonitor or PodMonitor
+const PrometheusBearerTokenSecretKey = "token"
+
+const prometheusCredentialsRotationEnv = "PROMETHEUS_CREDENTIALS_ROTATION_INTERVAL"
+
+// DefaultPrometheusCredentialsRotationInterval is how often the Prometheus metrics user secret key is rotated
+const DefaultPrometheusCredentialsRotationInterval = 30 * 24 * time.Hour
+
+// PrometheusMetricsUserAccessKey is the access key of the MinIO user used to scrape metrics
+const PrometheusMetricsUserAccessKey = "minio-operator-prometheus"
+
+// PrometheusClusterMetricsPath is the v2 cluster metrics path scraped by default
+const PrometheusClusterMetricsPath = "/minio/v2/metrics/cluster"
+
+// PrometheusNodeMetricsPath is the v2 node metrics path, it reports the drive errors of the scraped server
+const PrometheusNodeMetricsPath = "/minio/v2/metrics/node"
+
+// PrometheusMetricsPolicyName is the name of the MinIO policy granting access to the metrics endpoints
+const PrometheusMetricsPolicyName = "minio-operator-prometheus"
+
+// DefaultPrometheusRulesCapacityWarningThreshold is the used capacity percentage firing the capacity warning alert
+const DefaultPrometheusRulesCapacityWarningThreshold = 75
+
+// DefaultPrometheusRulesCapacityCriticalThreshold is the used capacity percentage firing the capacity critical alert
+const DefaultPrometheusRulesCapacityCriticalThreshold = 90
+
+// DefaultPrometheusRulesHealingThresholdMinutes is how long drives can be healing before an alert is fired
+const DefaultPrometheusRulesHealingThresholdMinutes = 60
+
+// DefaultCertExpiryAlertThreshold is the number of days before expiry a certificate alert is fired
+const DefaultCertExpiryAlertThreshold = 30
+
+// DefaultCapacityWarningThreshold is the percentage of usable capacity in use above which the tenant raises CapacityWarning
+const DefaultCapacityWarningThreshold = 75
+
+// DefaultCapacityCriticalThreshold is the percentage of usable capacity in use above which the tenant raises CapacityCritical
+const DefaultCapacityCriticalThreshold = 90
+
+// DefaultCapacityWarningDaysUntilFull is the forecasted number of days until full below which the tenant raises CapacityWarning
+const DefaultCapacityWarningDaysUntilFull = 30
+
+// DefaultCapacityCriticalDaysUntilFull is the forecasted number of days until full below which the tenant raises CapacityCritical
+const DefaultCapacityCriticalDaysUntilFull = 7
+
+// DefaultStorageAutoscalingThresholdPercent is the percentage of usable pool capacity in use above which PVCs are expanded
+const DefaultStorageAutoscalingThresholdPercent = 80
+
+// DefaultStorageAutoscalingCooldownMinutes is how long to wait between two expansions of the PVCs of a pool
+const DefaultStorageAutoscalingCooldownMinutes = 60
+
+// DefaultPoolTemplateThresholdPercent is the percentage of usable tenant capacity in use above which a pool is appended
+const DefaultPoolTemplateThresholdPercent = 80
+
+// CABundleSourceLabel is set on the CA bundle ConfigMaps with the namespace of their tenant, the tenant name is set by
+// TenantLabel
+const CABundleSourceLabel = "min.io/ca-bundle-source"
+
+// PoolExpansionApprovalAnnotation approves appending pools from the pool template, either `always` or a pool name
+const PoolExpansionApprovalAnnotation = "min.io/pool-expansion-approval"
+
+// PoolExpansionApprovalAlways approves every pool appended from the pool template
+const PoolExpansionApprovalAlways = "always"
+
+// DefaultNodeFailureGracePeriodMinutes is how long a node has to be lost before the PVCs of its pods are released
+const DefaultNodeFailureGracePeriodMinutes = 30
+
+// ReplaceDriveAnnotation on a PVC or a pod of the tenant requests the replacement of its drives
+const ReplaceDriveAnnotation = "operator.min.io/replace-drive"
+
+// TenantConditionCapacityWarning is the condition raised when the tenant is running out of capacity
+const TenantConditionCapacityWarning = "CapacityWarning"
+
+// TenantConditionCapacityCritical is the condition raised when the tenant is about to run out of capacity
+const TenantConditionCapacityCritical = "CapacityCritical"
+
+// PrometheusCredentialsRotatedAtAnnotation records when the metrics user secret key was last rotated
+const PrometheusCredentialsRotatedAtAnnotation = "min.io/prometheus-credentials-rotated-at"
+
+// TenantConditionCertificatesExpiring is the condition raised when user provided certificates expire within the
+// certificate expiry alert threshold
+const TenantConditionCertificatesExpiring = "CertificatesExpiring"
+
+// CertificatesHashAnnotation is set on the KES pods mounting user provided certificates to restart them when they change
+const CertificatesHashAnnotation = "min.io/certificates-hash"
+
+// RotateRootCredentialsAnnotation on the tenant requests the rotation of its root credentials, the operator generates
+// new ones every time the value changes
+const RotateRootCredentialsAnnotation = "min.io/rotate-root-credentials"
@@ -20,7 +20,9 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -30,6 +32,7 @@ import (
 	"os"
 	"path"
 	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -55,9 +58,10 @@ import (
 
 // Webhook API constants
 const (
-	MinIOServerURL          = "MINIO_SERVER_URL"
-	MinIODomain             = "MINIO_DOMAIN"
-	MinIOBrowserRedirectURL = "MINIO_BROWSER_REDIRECT_URL"
+	MinIOServerURL            = "MINIO_SERVER_URL"
+	MinIODomain               = "MINIO_DOMAIN"
+	MinIOBrowserRedirectURL   = "MINIO_BROWSER_REDIRECT_URL"
+	MinIOStorageClassStandard = "MINIO_STORAGE_CLASS_STANDARD"
 
 	defaultPrometheusJWTExpiry = 100 * 365 * 24 * time.Hour
 )
@@ -90,10 +94,16 @@ var (
 	tenantMinIOImage        string
 	tenantKesImage          string
 	monitoringInterval      int
+	monitoringWorkersOnce   sync.Once
+	monitoringWorkers       int
+	monitoringTimeoutOnce   sync.Once
+	monitoringTimeout       time.Duration
 	prometheusNamespace     string
 	prometheusName          string
 	prometheusNamespaceOnce sync.Once
 	prometheusNameOnce      sync.Once
+	prometheusRotationOnce  sync.Once
+	prometheusRotation      time.Duration
 	// gcpAppCredentialENV to denote the GCP APP credential path
 	gcpAppCredentialENV = corev1.EnvVar{
 		Name:  "GOOGLE_APPLICATION_CREDENTIALS",
@@ -193,6 +203,11 @@ func (t *Tenant) AutoCert() bool {
 	return *t.Spec.RequestAutoCert
 }
 
+// CertManagerIssuer returns true if the certificates generated by the Operator are issued by cert-manager
+func (t *Tenant) CertManagerIssuer() bool {
+	return t.Spec.CertConfig != nil && t.Spec.CertConfig.IssuerRef != nil && t.Spec.CertConfig.IssuerRef.Name != ""
+}
+
 // VolumePathForPool returns the paths for MinIO mounts based on
 // total number of volumes on a given pool
 func (t *Tenant) VolumePathForPool(pool *Pool) string {
@@ -539,6 +554,79 @@ func (t *Tenant) HasPrometheusOperatorEnabled() bool {
 	return t.Spec.PrometheusOperator
 }
 
+// GetPrometheusOperatorMode returns the prometheus-operator integration mode, defaults to additional scrape configs
+func (t *Tenant) GetPrometheusOperatorMode() PrometheusOperatorMode {
+	if t.Spec.PrometheusOperatorMode == "" {
+		return PrometheusOperatorModeAdditionalScrapeConfigs
+	}
+	return t.Spec.PrometheusOperatorMode
+}
+
+// PrometheusScrapesEveryServer returns true when the prometheus-operator mode scrapes every MinIO server, the additional
+// scrape configs scrape a single server picked by the MinIO service
+func (t *Tenant) PrometheusScrapesEveryServer() bool {
+	return t.GetPrometheusOperatorMode() != PrometheusOperatorModeAdditionalScrapeConfigs
+}
+
+// GetPrometheusOperatorScrapeMetricsPaths returns the metrics paths to scrape, defaults to the v2 cluster metrics. The
+// v2 node metrics the drive error alerts are computed from are added when the alerting rules are enabled and every
+// server is scraped.
+func (t *Tenant) GetPrometheusOperatorScrapeMetricsPaths() []string {
+	paths := []string{PrometheusClusterMetricsPath}
+	if len(t.Spec.PrometheusOperatorScrapeMetricsPaths) > 0 {
+		paths = append([]string{}, t.Spec.PrometheusOperatorScrapeMetricsPaths...)
+	}
+	if t.HasPrometheusRulesEnabled() && t.PrometheusScrapesEveryServer() && !slices.Contains(paths, PrometheusNodeMetricsPath) {
+		paths = append(paths, PrometheusNodeMetricsPath)
+	}
+	return paths
+}
+
+// HasPrometheusRulesEnabled checks if the tenant alerting rules are generated
+func (t *Tenant) HasPrometheusRulesEnabled() bool {
+	return t.HasPrometheusOperatorEnabled() && t.Spec.PrometheusRules != nil
+}
+
+// GetCertExpiryAlertThreshold returns the number of days before expiry a certificate alert is fired
+func (t *Tenant) GetCertExpiryAlertThreshold() int32 {
+	if t.Spec.CertExpiryAlertThreshold == nil {
+		return DefaultCertExpiryAlertThreshold
+	}
+	return *t.Spec.CertExpiryAlertThreshold
+}
+
+// KESSecretsHash returns the hash of the user provided secrets mounted by the KES pods
+func (t *Tenant) KESSecretsHash() string {
+	if t.Status.Certificates.CustomCertificates == nil {
+		return ""
+	}
+	return t.Status.Certificates.CustomCertificates.KESSecretsHash
+}
+
+// GetCapacityAlertThresholds returns the percentages of usable capacity in use and the forecasted days until full at
+// which the tenant raises the CapacityWarning and CapacityCritical conditions
+func (t *Tenant) GetCapacityAlertThresholds() (warning, critical, warningDays, criticalDays int32) {
+	warning, critical = DefaultCapacityWarningThreshold, DefaultCapacityCriticalThreshold
+	warningDays, criticalDays = DefaultCapacityWarningDaysUntilFull, DefaultCapacityCriticalDaysUntilFull
+	config := t.Spec.CapacityAlerts
+	if config == nil {
+		return warning, critical, warningDays, criticalDays
+	}
+	if config.WarningThreshold != nil {
+		warning = *config.WarningThreshold
+	}
+	if config.CriticalThreshold != nil {
+		critical = *config.CriticalThreshold
+	}
+	if config.WarningDaysUntilFull != nil {
+		warningDays = *config.WarningDaysUntilFull
+	}
+	if config.CriticalDaysUntilFull != nil {
+		criticalDays = *config.CriticalDaysUntilFull
+	}
+	return warning, critical, warningDays, criticalDays
+}
+
 // GetEnvVars returns the environment variables for tenant deployment.
 func (t *Tenant) GetEnvVars() (env []corev1.EnvVar) {
 	return t.Spec.Env
@@ -824,6 +912,107 @@ func (z *Pool) Validate(zi int) error {
 		return errors.New("volume access mode must be specified")
 	}
 
+	// Make sure the storage autoscaling can expand the PVCs
+	if z.StorageAutoscaling != nil {
+		if z.StorageAutoscaling.Step.Sign() <= 0 {
+			return fmt.Errorf("pool #%d storage autoscaling step must be greater than 0", zi)
+		}
+		if z.StorageAutoscaling.MaxSize.Sign() <= 0 {
+			return fmt.Errorf("pool #%d storage autoscaling max size must be greater than 0", zi)
+		}
+	}
+
+	return nil
+}
+
+// ErasureSetWriteQuorum returns the number of drives needed to write to an erasure set, an extra drive is needed
+// when data and parity drives are even to avoid split brain
+func ErasureSetWriteQuorum(drives, parity int) int {
+	writeQuorum := drives - parity
+	if writeQuorum == parity {
+		writeQuorum++
+	}
+	return writeQuorum
+}
+
+// ErasureSetParity returns the parity of the erasure sets for the `MINIO_STORAGE_CLASS_STANDARD` configuration, such
+// as `EC:4`, or the parity MinIO defaults to when it isn't configured
+func ErasureSetParity(setDriveCount int, storageClass string) int {
+	if parity, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(storageClass), "EC:")); err == nil && parity >= 0 && parity <= setDriveCount/2 {
+		return parity
+	}
+	switch {
+	case setDriveCount <= 1:
+		return 0
+	case setDriveCount <= 3:
+		return 1
+	case setDriveCount <= 5:
+		return 2
+	case setDriveCount <= 7:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// ErasureSetDriveCount returns the number of drives of the erasure sets of the pool the way MinIO lays them out, the
+// largest size from 2 to 16 dividing the drives of the pool that is symmetric with its number of servers
+func (z *Pool) ErasureSetDriveCount() int {
+	servers := int(z.Servers)
+	drives := servers * int(z.VolumesPerServer)
+	for size := 16; size >= 2; size-- {
+		if drives%size != 0 {
+			continue
+		}
+		if servers%size == 0 || size%servers == 0 {
+			return size
+		}
+	}
+	return drives
+}
+
+// ErasureSetServers returns the number of servers the drives of an erasure set of the pool are spread across. MinIO
+// fills the erasure sets with the drives of consecutive servers, so when the pool has more servers than drives per
+// erasure set, each group of `ErasureSetServers` consecutive servers holds erasure sets of its own.
+func (z *Pool) ErasureSetServers() int {
+	servers := int(z.Servers)
+	if setDriveCount := z.ErasureSetDriveCount(); setDriveCount < servers {
+		return setDriveCount
+	}
+	return servers
+}
+
+// ServerFaultTolerance returns the number of servers of the pool that can be lost at the same time without any of
+// its erasure sets losing write quorum, every server holds the same number of drives of an erasure set
+func (z *Pool) ServerFaultTolerance(storageClass string) int {
+	if z.Servers <= 0 || z.VolumesPerServer <= 0 {
+		return 0
+	}
+	setDriveCount := z.ErasureSetDriveCount()
+	parity := ErasureSetParity(setDriveCount, storageClass)
+	tolerance := setDriveCount - ErasureSetWriteQuorum(setDriveCount, parity)
+	drivesPerServer := (setDriveCount + int(z.Servers) - 1) / int(z.Servers)
+	return tolerance / drivesPerServer
+}
+
+// ValidatePlacement makes sure the pools strictly spread across zones survive the loss of a zone with the parity of the
+// `STANDARD` storage class, the servers of every erasure set are spread across the zones on their own. The best effort
+// `SpreadZones` placement may put more servers in a zone, its layout can't be validated.
+func (t *Tenant) ValidatePlacement(storageClass string) error {
+	for zi, pool := range t.Spec.Pools {
+		if pool.Placement == nil || pool.Placement.Policy != PoolPlacementStrictSpreadZones {
+			continue
+		}
+		zones := pool.Placement.Zones
+		if zones < 2 {
+			return fmt.Errorf("pool #%d must be spread across at least 2 zones with the %s placement", zi, pool.Placement.Policy)
+		}
+		serversPerZone := (pool.ErasureSetServers() + int(zones) - 1) / int(zones)
+		if tolerance := pool.ServerFaultTolerance(storageClass); serversPerZone > tolerance {
+			return fmt.Errorf("pool #%d can't survive the loss of a zone, each of the %d zones runs up to %d servers of an erasure set and the erasure sets tolerate the loss of %d",
+				zi, zones, serversPerZone, tolerance)
+		}
+	}
 	return nil
 }
 
@@ -972,6 +1161,47 @@ func GetMonitoringInterval() int {
 	return monitoringInterval
 }
 
+// GetMonitoringWorkers returns how many tenants are checked for cluster/health concurrently
+func GetMonitoringWorkers() int {
+	monitoringWorkersOnce.Do(func() {
+		monitoringWorkers = DefaultMonitoringWorkers
+		if val, err := strconv.Atoi(envGet(monitoringWorkersEnv, "")); err == nil && val > 0 {
+			monitoringWorkers = val
+		}
+	})
+	return monitoringWorkers
+}
+
+// GetMonitoringTimeout returns how long a single tenant health check may take before it is abandoned
+func GetMonitoringTimeout() time.Duration {
+	monitoringTimeoutOnce.Do(func() {
+		monitoringTimeout = DefaultMonitoringTimeout
+		if val, err := time.ParseDuration(envGet(monitoringTimeoutEnv, "")); err == nil && val > 0 {
+			monitoringTimeout = val
+		}
+	})
+	return monitoringTimeout
+}
+
+// HealthCheckInterval returns how often the health of the tenant is checked
+func (t *Tenant) HealthCheckInterval() time.Duration {
+	if t.Spec.HealthCheckIntervalSeconds != nil && *t.Spec.HealthCheckIntervalSeconds > 0 {
+		return time.Duration(*t.Spec.HealthCheckIntervalSeconds) * time.Second
+	}
+	return time.Duration(GetMonitoringInterval()) * time.Minute
+}
+
+// GetPrometheusCredentialsRotationInterval returns how often the Prometheus metrics user secret key is rotated
+func GetPrometheusCredentialsRotationInterval() time.Duration {
+	prometheusRotationOnce.Do(func() {
+		prometheusRotation = DefaultPrometheusCredentialsRotationInterval
+		if val, err := time.ParseDuration(envGet(prometheusCredentialsRotationEnv, "")); err == nil && val > 0 {
+			prometheusRotation = val
+		}
+	})
+	return prometheusRotation
+}
+
 // GetTenantServiceURL gets tenant's service url with the proper scheme and port
 func (t *Tenant) GetTenantServiceURL() (svcURL string) {
 	scheme := "http"
@@ -1062,6 +1292,46 @@ func ParseRawConfiguration(configuration []byte) (config map[string][]byte) {
 	return config
 }
 
+// SetRootCredentials returns the MinIO config.env file with the root credentials replaced, the legacy
+// MINIO_ACCESS_KEY and MINIO_SECRET_KEY variables are replaced in place and the other lines are kept as they are
+func SetRootCredentials(configuration []byte, accessKey, secretKey string) []byte {
+	var lines []string
+	var userSet, passwordSet bool
+	scanner := bufio.NewScanner(strings.NewReader(string(configuration)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		ekv, err := parsEnvEntry(line)
+		if err == nil && !ekv.Skip {
+			switch ekv.Key {
+			case "MINIO_ROOT_USER", "MINIO_ACCESS_KEY":
+				line = fmt.Sprintf("export %s=\"%s\"", ekv.Key, accessKey)
+				userSet = true
+			case "MINIO_ROOT_PASSWORD", "MINIO_SECRET_KEY":
+				line = fmt.Sprintf("export %s=\"%s\"", ekv.Key, secretKey)
+				passwordSet = true
+			}
+		}
+		lines = append(lines, line)
+	}
+	if !userSet {
+		lines = append(lines, fmt.Sprintf("export MINIO_ROOT_USER=\"%s\"", accessKey))
+	}
+	if !passwordSet {
+		lines = append(lines, fmt.Sprintf("export MINIO_ROOT_PASSWORD=\"%s\"", secretKey))
+	}
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+// RootCredentialsHash returns the hash the sidecars compare with the root credentials of config.env, so the operator
+// can check the credentials a pod loaded without sending them
+func RootCredentialsHash(accessKey, secretKey []byte) string {
+	h := sha256.New()
+	h.Write(accessKey)
+	h.Write([]byte{0})
+	h.Write(secretKey)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // GetPrometheusNamespace returns namespace of the prometheus managed by prometheus operator
 func GetPrometheusNamespace() string {
 	prometheusNamespaceOnce.Do(func() {
@@ -1162,6 +1432,36 @@ func (t *Tenant) GetDomainHosts() []string {
 	return nil
 }
 
+// DomainCertificates indicates whether the Operator issues the certificates of the domains of the tenant
+func (t *Tenant) DomainCertificates() bool {
+	return t.AutoCert() && t.Spec.CertConfig != nil && t.Spec.CertConfig.DomainCertificates
+}
+
+// CertificateDomains returns the hosts of the MinIO and Console domains the Operator issues certificates for, see
+// DomainCertificates
+func (t *Tenant) CertificateDomains() []string {
+	if !t.DomainCertificates() {
+		return nil
+	}
+	hosts := t.GetDomainHosts()
+	if t.HasConsoleDomains() {
+		domain := t.Spec.Features.Domains.Console
+		if !strings.HasPrefix(domain, "http") {
+			domain = "https://" + domain
+		}
+		if u, err := url.Parse(domain); err == nil && u.Hostname() != "" {
+			hosts = append(hosts, u.Hostname())
+		}
+	}
+	var domains []string
+	for _, host := range hosts {
+		if !slices.Contains(domains, host) {
+			domains = append(domains, host)
+		}
+	}
+	return domains
+}
+
 // HasEnv returns whether an environment variable is defined in the .spec.env field
 func (t *Tenant) HasEnv(envName string) bool {
 	for _, env := range t.Spec.Env {
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -540,3 +541,107 @@ func TestTenant_ValidateDomains(t1 *testing.T) {
 		})
 	}
 }
+
+func TestTenant_ValidatePlacement(t1 *testing.T) {
+	tests := []struct {
+		name         string
+		pool         Pool
+		storageClass string
+		wantErr      bool
+	}{
+		{
+			name: "Spread across nodes",
+			pool: Pool{Servers: 4, VolumesPerServer: 4, Placement: &PoolPlacement{Policy: PoolPlacementSpreadNodes}},
+		},
+		{
+			name: "Zone loss tolerated",
+			pool: Pool{Servers: 8, VolumesPerServer: 4, Placement: &PoolPlacement{Policy: PoolPlacementStrictSpreadZones, Zones: 4}},
+		},
+		{
+			name:    "Zone loss beyond the parity",
+			pool:    Pool{Servers: 8, VolumesPerServer: 4, Placement: &PoolPlacement{Policy: PoolPlacementStrictSpreadZones, Zones: 2}},
+			wantErr: true,
+		},
+		{
+			name:         "Zone loss beyond the configured parity",
+			pool:         Pool{Servers: 4, VolumesPerServer: 4, Placement: &PoolPlacement{Policy: PoolPlacementStrictSpreadZones, Zones: 2}},
+			storageClass: "EC:2",
+			wantErr:      true,
+		},
+		{
+			name:    "Single zone",
+			pool:    Pool{Servers: 4, VolumesPerServer: 4, Placement: &PoolPlacement{Policy: PoolPlacementStrictSpreadZones, Zones: 1}},
+			wantErr: true,
+		},
+		{
+			name: "Zone loss tolerated by every group of erasure sets",
+			pool: Pool{Servers: 32, VolumesPerServer: 1, Placement: &PoolPlacement{Policy: PoolPlacementStrictSpreadZones, Zones: 4}},
+		},
+		{
+			name:    "Zone loss beyond the parity of a group of erasure sets",
+			pool:    Pool{Servers: 32, VolumesPerServer: 1, Placement: &PoolPlacement{Policy: PoolPlacementStrictSpreadZones, Zones: 2}},
+			wantErr: true,
+		},
+		{
+			name: "Best effort zone spread",
+			pool: Pool{Servers: 8, VolumesPerServer: 4, Placement: &PoolPlacement{Policy: PoolPlacementSpreadZones, Zones: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			t := &Tenant{Spec: TenantSpec{Pools: []Pool{tt.pool}}}
+			err := t.ValidatePlacement(tt.storageClass)
+			if (err != nil) != tt.wantErr {
+				t1.Errorf("ValidatePlacement() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPool_ValidateStorageAutoscaling(t1 *testing.T) {
+	tests := []struct {
+		name        string
+		autoscaling *StorageAutoscaling
+		wantErr     bool
+	}{
+		{
+			name:        "Valid",
+			autoscaling: &StorageAutoscaling{Step: resource.MustParse("10Gi"), MaxSize: resource.MustParse("1Ti")},
+		},
+		{
+			name:        "Missing step",
+			autoscaling: &StorageAutoscaling{MaxSize: resource.MustParse("1Ti")},
+			wantErr:     true,
+		},
+		{
+			name:        "Negative step",
+			autoscaling: &StorageAutoscaling{Step: resource.MustParse("-10Gi"), MaxSize: resource.MustParse("1Ti")},
+			wantErr:     true,
+		},
+		{
+			name:        "Missing max size",
+			autoscaling: &StorageAutoscaling{Step: resource.MustParse("10Gi")},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			pool := Pool{
+				Servers:          4,
+				VolumesPerServer: 4,
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+						},
+					},
+				},
+				StorageAutoscaling: tt.autoscaling,
+			}
+			if err := pool.Validate(0); (err != nil) != tt.wantErr {
+				t1.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -42,6 +42,29 @@ func (t *Tenant) MinIOTLSSecretName() string {
 	return t.Name + TLSSecretSuffix
 }
 
+// MinIODomainTLSSecretName returns the name of the Secret holding the certificate of a domain, index is the position
+// of the domain in CertificateDomains
+func (t *Tenant) MinIODomainTLSSecretName(index int) string {
+	return fmt.Sprintf("%s-domain-%d%s", t.Name, index, TLSSecretSuffix)
+}
+
+// CABundleConfigMapName returns the name of the ConfigMap publishing the CA bundle and the endpoints of the tenant
+func (t *Tenant) CABundleConfigMapName() string {
+	if t.Spec.CABundle != nil && t.Spec.CABundle.ConfigMapName != "" {
+		return t.Spec.CABundle.ConfigMapName
+	}
+	return t.Name + "-ca-bundle"
+}
+
+// CABundleReplicaName returns the name of the ConfigMap publishing the CA bundle of the tenant in a namespace, the
+// replicas outside the tenant namespace are prefixed with it so tenants of different namespaces don't collide
+func (t *Tenant) CABundleReplicaName(namespace string) string {
+	if namespace == t.Namespace {
+		return t.CABundleConfigMapName()
+	}
+	return fmt.Sprintf("%s-%s", t.Namespace, t.CABundleConfigMapName())
+}
+
 // MinIOClientTLSSecretName returns the name of Secret that has TLS related Info (Cert & Private Key)
 // for MinIO <-> KES client side authentication.
 func (t *Tenant) MinIOClientTLSSecretName() string {
@@ -99,6 +122,11 @@ func (t *Tenant) MinIOCSRName() string {
 	return t.Name + "-" + t.Namespace + CSRNameSuffix
 }
 
+// MinIODomainCSRName returns the name of CSR that is generated for the certificate of a domain
+func (t *Tenant) MinIODomainCSRName(index int) string {
+	return fmt.Sprintf("%s-domain-%d-%s%s", t.Name, index, t.Namespace, CSRNameSuffix)
+}
+
 // MinIOClientCSRName returns the name of CSR that is generated for Client side authentication
 // Used by KES Pods
 func (t *Tenant) MinIOClientCSRName() string {
@@ -210,6 +238,51 @@ func (t *Tenant) PrometheusOperatorAddlConfigJobName() string {
 	return fmt.Sprintf("%s-%s-minio-job", t.Name, t.Namespace)
 }
 
+// PrometheusMonitorName returns the name of the ServiceMonitor or PodMonitor of the tenant
+func (t *Tenant) PrometheusMonitorName() string {
+	return fmt.Sprintf("%s-minio", t.Name)
+}
+
+// PrometheusBearerTokenSecretName returns the name of the secret holding the Prometheus bearer token
+func (t *Tenant) PrometheusBearerTokenSecretName() string {
+	return fmt.Sprintf("%s-prometheus-bearer-token", t.Name)
+}
+
+// PrometheusRuleName returns the name of the PrometheusRule of the tenant
+func (t *Tenant) PrometheusRuleName() string {
+	return fmt.Sprintf("%s-minio-rules", t.Name)
+}
+
+// PrometheusMetricsUserSecretName returns the name of the secret holding the credentials of the metrics user
+func (t *Tenant) PrometheusMetricsUserSecretName() string {
+	return fmt.Sprintf("%s-prometheus-metrics-user", t.Name)
+}
+
+// ActiveRootCredentialsSecretName returns the name of the secret holding the root credentials MinIO runs with, they
+// differ from the configured ones while a rotation is in progress
+func (t *Tenant) ActiveRootCredentialsSecretName() string {
+	return fmt.Sprintf("%s-active-root-credentials", t.Name)
+}
+
+// UsageHistoryConfigMapName returns the name of the ConfigMap holding the usage history of the tenant
+func (t *Tenant) UsageHistoryConfigMapName() string {
+	return fmt.Sprintf("%s-usage-history", t.Name)
+}
+
+// BucketUsageConfigMapName returns the name of the ConfigMap holding the bucket usage report of the tenant
+func (t *Tenant) BucketUsageConfigMapName() string {
+	return fmt.Sprintf("%s-bucket-usage", t.Name)
+}
+
+// BucketUsageShardConfigMapName returns the name of the ConfigMap holding a shard of the bucket usage report of the
+// tenant, the first shard is held by the BucketUsageConfigMapName ConfigMap
+func (t *Tenant) BucketUsageShardConfigMapName(index int) string {
+	if index == 0 {
+		return t.BucketUsageConfigMapName()
+	}
+	return fmt.Sprintf("%s-%d", t.BucketUsageConfigMapName(), index)
+}
+
 // PrometheusConfigMapName returns name of the config map for Prometheus.
 func (t *Tenant) PrometheusConfigMapName() string {
 	return fmt.Sprintf("%s-%s", t.Name, "prometheus-config-map")
@@ -17,7 +17,9 @@ package v2
 import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // Tenant is a https://kubernetes.io/docs/concepts/overview/working-with-objects/kubernetes-objects/[Kubernetes object] describing a MinIO Tenant. +
@@ -239,6 +241,12 @@ type TenantSpec struct {
 	// +optional
 	CertExpiryAlertThreshold *int32 `json:"certExpiryAlertThreshold,omitempty"`
 
+	// *Optional* +
+	//
+	// Publishes the CA bundle, the S3 and STS endpoints and the region of the tenant in a ConfigMap of the tenant namespace, replicated to the namespaces of the PolicyBindings of the tenant and to the namespaces selected by `namespaceSelector`. The ConfigMaps are kept in sync as the certificates rotate. +
+	// +optional
+	CABundle *CABundleConfig `json:"caBundle,omitempty"`
+
 	// Liveness Probe for container liveness. Container will be restarted if the probe fails.
 	// +optional
 	Liveness *corev1.Probe `json:"liveness,omitempty"`
@@ -285,6 +293,53 @@ type TenantSpec struct {
 	PrometheusOperatorScrapeMetricsPaths []string `json:"prometheusOperatorScrapeMetricsPaths,omitempty"`
 	// *Optional* +
 	//
+	// How the tenant scrape configuration is handed to the prometheus-operator when `prometheusOperator` is enabled. +
+	//
+	// * `additionalScrapeConfigs` (default) - add the scrape jobs to the additional scrape config secret of the Prometheus instance. +
+	// * `serviceMonitor` - create a ServiceMonitor owned by the Tenant. +
+	// * `podMonitor` - create a PodMonitor owned by the Tenant. +
+	//
+	// +kubebuilder:validation:Enum=additionalScrapeConfigs;serviceMonitor;podMonitor
+	// +optional
+	PrometheusOperatorMode PrometheusOperatorMode `json:"prometheusOperatorMode,omitempty"`
+	// *Optional* +
+	//
+	// Labels added to the ServiceMonitor or PodMonitor, use them to match the monitor selectors of the Prometheus instance. +
+	//
+	// +optional
+	PrometheusOperatorMonitorLabels map[string]string `json:"prometheusOperatorMonitorLabels,omitempty"`
+	// *Optional* +
+	//
+	// Generate a PrometheusRule owned by the Tenant with alerts on drives, erasure sets, healing, capacity, nodes and certificate expiry. +
+	// Requires `prometheusOperator` to be enabled, the alert expressions select the series scraped in the configured `prometheusOperatorMode`. +
+	// The drive error alerts are only generated in the `serviceMonitor` and `podMonitor` modes, which scrape every server, `/minio/v2/metrics/node` is then added to the scraped paths. +
+	//
+	// +optional
+	PrometheusRules *PrometheusRulesConfig `json:"prometheusRules,omitempty"`
+	// *Optional* +
+	//
+	// How often, in seconds, the Operator checks the health, drives and usage of the tenant. +
+	// Defaults to the `MONITORING_INTERVAL` of the Operator, unreachable tenants are checked with an exponential backoff. +
+	//
+	// +optional
+	HealthCheckIntervalSeconds *int32 `json:"healthCheckIntervalSeconds,omitempty"`
+	// *Optional* +
+	//
+	// Thresholds at which the Operator raises the `CapacityWarning` and `CapacityCritical` conditions and events of the tenant. +
+	// The Operator keeps a rolling usage history of the tenant and its pools in the `<tenant>-usage-history` ConfigMap to forecast when it runs full. +
+	//
+	// +optional
+	CapacityAlerts *CapacityAlertsConfig `json:"capacityAlerts,omitempty"`
+	// *Optional* +
+	//
+	// Pool appended to the tenant when its usage crosses a threshold, so the tenant scales out without editing the spec. +
+	// Each expansion must be approved with the `min.io/pool-expansion-approval` annotation, set it to `always` to approve all of them
+	// or to the name of the pool reported in `status.pendingPoolExpansion` to approve a single one. +
+	//
+	// +optional
+	PoolTemplate *PoolTemplate `json:"poolTemplate,omitempty"`
+	// *Optional* +
+	//
 	// The https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/[Kubernetes Service Account] to use for running MinIO pods created as part of the Tenant. +
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
@@ -481,6 +536,98 @@ const (
 	PoolInitialized PoolState = "PoolInitialized"
 )
 
+// PrometheusRulesConfig (`prometheusRules`) defines the alerting rules generated for the MinIO Tenant. +
+type PrometheusRulesConfig struct {
+	// *Optional* +
+	//
+	// Labels added to the PrometheusRule, use them to match the rule selector of the Prometheus instance. +
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// *Optional* +
+	//
+	// Labels added to every alert, they override the default labels such as `severity`. +
+	//
+	// +optional
+	AlertLabels map[string]string `json:"alertLabels,omitempty"`
+	// *Optional* +
+	//
+	// Percentage of the usable capacity in use above which a warning alert is fired. Defaults to `75`. +
+	//
+	// +optional
+	CapacityWarningThreshold *int32 `json:"capacityWarningThreshold,omitempty"`
+	// *Optional* +
+	//
+	// Percentage of the usable capacity in use above which a critical alert is fired. Defaults to `90`. +
+	//
+	// +optional
+	CapacityCriticalThreshold *int32 `json:"capacityCriticalThreshold,omitempty"`
+	// *Optional* +
+	//
+	// Number of minutes drives can be healing before an alert is fired. Defaults to `60`. +
+	//
+	// +optional
+	HealingThresholdMinutes *int32 `json:"healingThresholdMinutes,omitempty"`
+}
+
+// PoolTemplate (`poolTemplate`) defines the pools appended to the tenant when it runs out of capacity. +
+type PoolTemplate struct {
+	// *Required* +
+	//
+	// Pool appended to the tenant, its `name` is the prefix of the generated pools, named `<name>-<index>`. +
+	Pool Pool `json:"pool"`
+	// *Optional* +
+	//
+	// Percentage of the usable capacity of the tenant in use above which a pool is appended. Defaults to `80`. +
+	//
+	// +optional
+	ThresholdPercent *int32 `json:"thresholdPercent,omitempty"`
+	// *Required* +
+	//
+	// Number of pools, including the ones declared in `pools`, the tenant never grows beyond. +
+	MaxPools int32 `json:"maxPools"`
+}
+
+// CapacityAlertsConfig (`capacityAlerts`) defines when the tenant is reported as running out of capacity. +
+type CapacityAlertsConfig struct {
+	// *Optional* +
+	//
+	// Percentage of the usable capacity in use above which the `CapacityWarning` condition is raised. Defaults to `75`. +
+	//
+	// +optional
+	WarningThreshold *int32 `json:"warningThreshold,omitempty"`
+	// *Optional* +
+	//
+	// Percentage of the usable capacity in use above which the `CapacityCritical` condition is raised. Defaults to `90`. +
+	//
+	// +optional
+	CriticalThreshold *int32 `json:"criticalThreshold,omitempty"`
+	// *Optional* +
+	//
+	// Forecasted number of days until the tenant is full below which the `CapacityWarning` condition is raised. Defaults to `30`. +
+	//
+	// +optional
+	WarningDaysUntilFull *int32 `json:"warningDaysUntilFull,omitempty"`
+	// *Optional* +
+	//
+	// Forecasted number of days until the tenant is full below which the `CapacityCritical` condition is raised. Defaults to `7`. +
+	//
+	// +optional
+	CriticalDaysUntilFull *int32 `json:"criticalDaysUntilFull,omitempty"`
+}
+
+// PrometheusOperatorMode defines how the tenant scrape configuration is handed to the prometheus-operator
+type PrometheusOperatorMode string
+
+const (
+	// PrometheusOperatorModeAdditionalScrapeConfigs adds the scrape jobs to the additional scrape config secret
+	PrometheusOperatorModeAdditionalScrapeConfigs PrometheusOperatorMode = "additionalScrapeConfigs"
+	// PrometheusOperatorModeServiceMonitor creates a ServiceMonitor owned by the tenant
+	PrometheusOperatorModeServiceMonitor PrometheusOperatorMode = "serviceMonitor"
+	// PrometheusOperatorModePodMonitor creates a PodMonitor owned by the tenant
+	PrometheusOperatorModePodMonitor PrometheusOperatorMode = "podMonitor"
+)
+
 // PoolStatus keeps track of all the pools and their current state
 type PoolStatus struct {
 	SSName string    `json:"ssName"`
@@ -490,6 +637,193 @@ type PoolStatus struct {
 	// Security Context
 	// +optional
 	LegacySecurityContext bool `json:"legacySecurityContext"`
+	// *Optional* +
+	//
+	// Net free capacity of the pool in bytes, the total usable space of the pool is the capacity plus the usage
+	Capacity int64 `json:"capacity,omitempty"`
+	// *Optional* +
+	//
+	// Net usage of the pool in bytes
+	Usage int64 `json:"usage,omitempty"`
+	// *Optional* +
+	//
+	// Number of drives online in the pool
+	DrivesOnline int32 `json:"drivesOnline,omitempty"`
+	// *Optional* +
+	//
+	// Number of drives offline in the pool
+	DrivesOffline int32 `json:"drivesOffline,omitempty"`
+	// *Optional* +
+	//
+	// Number of drives healing in the pool
+	DrivesHealing int32 `json:"drivesHealing,omitempty"`
+	// *Optional* +
+	//
+	// Health of each erasure set of the pool
+	ErasureSets []ErasureSetStatus `json:"erasureSets,omitempty"`
+	// *Optional* +
+	//
+	// How many bytes the usage of the pool grows per day, averaged over the last week
+	GrowthPerDay int64 `json:"growthPerDay,omitempty"`
+	// *Optional* +
+	//
+	// Forecasted number of days until the pool is full, unset while the usage is not growing
+	DaysUntilFull *int32 `json:"daysUntilFull,omitempty"`
+	// *Optional* +
+	//
+	// Last time the PVCs of the pool were expanded by the storage autoscaling
+	LastStorageExpansion *metav1.Time `json:"lastStorageExpansion,omitempty"`
+	// *Optional* +
+	//
+	// PVCs of the pool with a resize in progress
+	ResizingPVCs []PVCResizeStatus `json:"resizingPVCs,omitempty"`
+}
+
+// PVCResizeStatus keeps track of the resize of a PVC
+type PVCResizeStatus struct {
+	// Name of the PersistentVolumeClaim
+	Name string `json:"name"`
+	// Storage requested by the PVC
+	Requested string `json:"requested"`
+	// Storage currently provisioned for the PVC
+	Capacity string `json:"capacity"`
+	// *Optional* +
+	//
+	// Resize condition reported on the PVC, such as `Resizing` or `FileSystemResizePending`
+	Condition string `json:"condition,omitempty"`
+	// *Optional* +
+	//
+	// Message of the resize condition
+	Message string `json:"message,omitempty"`
+}
+
+// ErasureSetStatus keeps track of the health of an erasure set
+type ErasureSetStatus struct {
+	// Index of the erasure set in the pool
+	Index int32 `json:"index"`
+	// Number of drives online in the erasure set
+	DrivesOnline int32 `json:"drivesOnline"`
+	// *Optional* +
+	//
+	// Number of drives offline in the erasure set
+	DrivesOffline int32 `json:"drivesOffline,omitempty"`
+	// *Optional* +
+	//
+	// Number of drives healing in the erasure set
+	DrivesHealing int32 `json:"drivesHealing,omitempty"`
+	// Minimum number of drives that need to be online to write to the erasure set
+	WriteQuorum int32 `json:"writeQuorum"`
+	// Health of the erasure set, `red` once it lost write quorum
+	HealthStatus HealthStatus `json:"healthStatus"`
+}
+
+// UnhealthyDrive identifies a drive that is not online, or healing, along with the pod and PVC backing it
+type UnhealthyDrive struct {
+	// Pool the drive belongs to
+	Pool string `json:"pool"`
+	// Pod serving the drive
+	Pod string `json:"pod"`
+	// Path of the drive in the pod
+	Path string `json:"path"`
+	// *Optional* +
+	//
+	// PersistentVolumeClaim backing the drive
+	PVC string `json:"pvc,omitempty"`
+	// State reported by MinIO, `healing` for online drives being healed
+	State string `json:"state"`
+}
+
+// RootCredentialsStatus keeps track of the rotation of the root credentials of the tenant
+type RootCredentialsStatus struct {
+	// *Optional* +
+	//
+	// Step the rotation is at, empty when MinIO runs with the configured root credentials
+	Phase RootCredentialsPhase `json:"phase,omitempty"`
+	// *Optional* +
+	//
+	// Value of the `min.io/rotate-root-credentials` annotation last handled
+	RotationRequest string `json:"rotationRequest,omitempty"`
+	// *Optional* +
+	//
+	// Time the new root credentials were detected
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// *Optional* +
+	//
+	// Time MinIO was restarted to load the new root credentials
+	RestartedAt *metav1.Time `json:"restartedAt,omitempty"`
+	// *Optional* +
+	//
+	// Hash of the root credentials being rotated, a failed rotation is retried once they change
+	CredentialsHash string `json:"credentialsHash,omitempty"`
+	// *Optional* +
+	//
+	// Time MinIO last accepted new root credentials, the previous ones stay valid for an hour after it
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+	// *Optional* +
+	//
+	// Details about the current step, such as why the rotation is blocked
+	Message string `json:"message,omitempty"`
+}
+
+// RootCredentialsPhase is the step a root credentials rotation is at
+type RootCredentialsPhase string
+
+const (
+	// RootCredentialsPropagating waiting for the MinIO pods to regenerate their configuration with the new credentials
+	RootCredentialsPropagating RootCredentialsPhase = "Propagating"
+	// RootCredentialsRestarting MinIO was restarted and the operator waits for it to accept the new credentials
+	RootCredentialsRestarting RootCredentialsPhase = "Restarting"
+	// RootCredentialsRetiring MinIO runs with the new credentials, the previous ones stay valid as a temporary user
+	RootCredentialsRetiring RootCredentialsPhase = "Retiring"
+	// RootCredentialsFailed the rotation didn't complete in time, the operator stops waiting for it until the
+	// credentials change again and completes it if every server accepts the new credentials later on
+	RootCredentialsFailed RootCredentialsPhase = "Failed"
+)
+
+// DriveReplacementPhase is the step a drive replacement is at
+type DriveReplacementPhase string
+
+const (
+	// DriveReplacementPending the replacement was requested and waits for the write quorum check
+	DriveReplacementPending DriveReplacementPhase = "Pending"
+	// DriveReplacementBlocked the drive can't be replaced without losing write quorum
+	DriveReplacementBlocked DriveReplacementPhase = "Blocked"
+	// DriveReplacementReplacing the PVC and the pod of the drive are being recreated
+	DriveReplacementReplacing DriveReplacementPhase = "Replacing"
+	// DriveReplacementWaitingForDrive waiting for MinIO to detect the fresh drive
+	DriveReplacementWaitingForDrive DriveReplacementPhase = "WaitingForDrive"
+	// DriveReplacementHealing MinIO is healing the fresh drive
+	DriveReplacementHealing DriveReplacementPhase = "Healing"
+)
+
+// DriveReplacement keeps track of the replacement of a drive
+type DriveReplacement struct {
+	// Pod serving the drive
+	Pod string `json:"pod"`
+	// PersistentVolumeClaim backing the drive
+	PVC string `json:"pvc"`
+	// Step the replacement is at
+	Phase DriveReplacementPhase `json:"phase"`
+	// *Optional* +
+	//
+	// UID of the replaced PVC, the drive is fresh once the PVC was recreated with another UID
+	PVCUID string `json:"pvcUID,omitempty"`
+	// *Optional* +
+	//
+	// Time the replacement of the drive started
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// *Optional* +
+	//
+	// Number of bytes healed on the fresh drive
+	HealedBytes int64 `json:"healedBytes,omitempty"`
+	// *Optional* +
+	//
+	// Number of bytes to heal on the fresh drive
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+	// *Optional* +
+	//
+	// Details about the current step, such as why the replacement is blocked
+	Message string `json:"message,omitempty"`
 }
 
 // HealthStatus represents whether the tenant is healthy, with decreased service or offline
@@ -531,6 +865,13 @@ type TenantUsage struct {
 	// Tiers includes the usage of individual tiers in the tenant
 	// +optional
 	Tiers []TierUsage `json:"tiers,omitempty"`
+	// GrowthPerDay is how many bytes the usage grows per day, averaged over the last week.
+	// +optional
+	GrowthPerDay int64 `json:"growthPerDay,omitempty"`
+	// DaysUntilFull is the forecasted number of days until the usable capacity is exhausted, unset while the usage
+	// is not growing.
+	// +optional
+	DaysUntilFull *int32 `json:"daysUntilFull,omitempty"`
 }
 
 // TenantStatus is the status for a Tenant resource
@@ -571,12 +912,43 @@ type TenantStatus struct {
 	HealthMessage string `json:"healthMessage,omitempty"`
 	// *Optional* +
 	//
+	// Time since the health checks of the tenant fail, the health reported in the status is the last known one until
+	// a check succeeds again
+	HealthStaleSince *metav1.Time `json:"healthStaleSince,omitempty"`
+	// *Optional* +
+	//
 	// If set, we will wait until cleared for up a given time
 	WaitingOnReady *metav1.Time `json:"waitingOnReady,omitempty"`
 	// *Optional* +
 	//
 	// Information about tenant usage
 	Usage TenantUsage `json:"usage,omitempty"`
+	// *Optional* +
+	//
+	// Drives that are not online or are healing
+	UnhealthyDrives []UnhealthyDrive `json:"unhealthyDrives,omitempty"`
+	// *Optional* +
+	//
+	// Name of the pool from the `poolTemplate` waiting for approval to be appended to the tenant
+	PendingPoolExpansion string `json:"pendingPoolExpansion,omitempty"`
+	// *Optional* +
+	//
+	// Drives being replaced after their PVC or pod was annotated with `operator.min.io/replace-drive=true`, until MinIO healed them
+	DriveReplacements []DriveReplacement `json:"driveReplacements,omitempty"`
+	// *Optional* +
+	//
+	// Rotation of the root credentials, see the `min.io/rotate-root-credentials` annotation
+	RootCredentials *RootCredentialsStatus `json:"rootCredentials,omitempty"`
+	// *Optional* +
+	//
+	// ConfigMaps the CA bundle of the tenant was last published to, see `spec.caBundle`
+	CABundle *CABundleStatus `json:"caBundle,omitempty"`
+	// *Optional* +
+	//
+	// Conditions of the tenant, such as `CapacityWarning`, `CapacityCritical` and `CertificatesExpiring`
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
 	// ProvisionedUsers keeps track for telling if operator already created initial users for the tenant
 	// +deprecated
@@ -603,6 +975,129 @@ type CertificateConfig struct {
 	//
 	// Specify one or more x.509 Subject Alternative Names (SAN) to associate to automatically generated TLS certificates. MinIO Server pods use SNI to determine which certificate to respond with based on the requested hostname.
 	DNSNames []string `json:"dnsNames,omitempty"`
+	// *Optional* +
+	//
+	// Reference to a cert-manager `Issuer` or `ClusterIssuer`. When set, the Operator requests the automatically generated certificates of MinIO, the MinIO client certificate used with KES and the KES server certificate as cert-manager `Certificate` resources signed by this issuer instead of using the Kubernetes CertificateSigningRequest API. +
+	// +optional
+	IssuerRef *CertificateIssuerRef `json:"issuerRef,omitempty"`
+	// *Optional* +
+	//
+	// Algorithm of the private keys of the automatically generated TLS certificates: `ECDSA` (default), `RSA` or `Ed25519`. The certificates are issued again when the algorithm or the size of their key changes. +
+	// +kubebuilder:validation:Enum=ECDSA;RSA;Ed25519
+	// +optional
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+	// *Optional* +
+	//
+	// Size of the private keys in bits: `256` (default), `384` or `521` for `ECDSA`, `2048` (default), `3072` or `4096` for `RSA`. Ignored for `Ed25519`. +
+	// +optional
+	KeySize int `json:"keySize,omitempty"`
+	// *Optional* +
+	//
+	// Requested lifetime of the automatically generated TLS certificates, such as `2160h`. The signer may issue certificates with a shorter lifetime. +
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// *Optional* +
+	//
+	// Additional IP addresses to associate as x.509 Subject Alternative Names to automatically generated TLS certificates. +
+	// +optional
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+	// *Optional* +
+	//
+	// URIs, such as SPIFFE IDs, to associate as x.509 Subject Alternative Names to automatically generated TLS certificates. +
+	// +optional
+	URIs []string `json:"uris,omitempty"`
+	// *Optional* +
+	//
+	// Additional subject fields of automatically generated TLS certificates. +
+	// +optional
+	Subject *CertificateSubject `json:"subject,omitempty"`
+	// *Optional* +
+	//
+	// Specify `true` to have the Operator issue a certificate for each host of `features.domains`, including the wildcard bucket subdomains of the MinIO domains when `features.bucketDNS` is enabled. The certificates are signed like the other automatically generated certificates and served by MinIO through SNI. Requires `requestAutoCert`. +
+	// +optional
+	DomainCertificates bool `json:"domainCertificates,omitempty"`
+}
+
+// CertificateSubject (`subject`) defines the subject fields of the TLS certificates generated by the Operator, the organization is set by `organizationName`.
+type CertificateSubject struct {
+	// *Optional* +
+	//
+	// Organizational units (`OU`) of the certificates. +
+	// +optional
+	OrganizationalUnits []string `json:"organizationalUnits,omitempty"`
+	// *Optional* +
+	//
+	// Countries (`C`) of the certificates. +
+	// +optional
+	Countries []string `json:"countries,omitempty"`
+	// *Optional* +
+	//
+	// Provinces or states (`ST`) of the certificates. +
+	// +optional
+	Provinces []string `json:"provinces,omitempty"`
+	// *Optional* +
+	//
+	// Localities (`L`) of the certificates. +
+	// +optional
+	Localities []string `json:"localities,omitempty"`
+	// *Optional* +
+	//
+	// Street addresses of the certificates. +
+	// +optional
+	StreetAddresses []string `json:"streetAddresses,omitempty"`
+	// *Optional* +
+	//
+	// Postal codes of the certificates. +
+	// +optional
+	PostalCodes []string `json:"postalCodes,omitempty"`
+	// *Optional* +
+	//
+	// Serial number attribute of the subject of the certificates. +
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+}
+
+// CABundleConfig (`caBundle`) defines the ConfigMap publishing how to reach the tenant over TLS.
+type CABundleConfig struct {
+	// *Optional* +
+	//
+	// Name of the ConfigMap, `<tenant>-ca-bundle` by default. +
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// *Optional* +
+	//
+	// Label selector of the namespaces the ConfigMap is replicated to, in addition to the tenant namespace and the namespaces of the PolicyBindings of the tenant. +
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// CABundleStatus keeps track of the ConfigMaps publishing the CA bundle of the tenant, to remove them when they are
+// no longer consumed
+type CABundleStatus struct {
+	// *Optional* +
+	//
+	// Name of the ConfigMap in the tenant namespace, the replicas in other namespaces are prefixed with the tenant namespace
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// *Optional* +
+	//
+	// Sorted namespaces the ConfigMap is published to
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// CertificateIssuerRef (`issuerRef`) references the cert-manager issuer signing the certificates generated by the Operator.
+type CertificateIssuerRef struct {
+	// Name of the `Issuer` or `ClusterIssuer`. An `Issuer` must live in the namespace of the tenant.
+	Name string `json:"name"`
+	// *Optional* +
+	//
+	// Kind of the issuer, defaults to `Issuer`. Use `ClusterIssuer` for a cluster wide issuer. +
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// *Optional* +
+	//
+	// API group of the issuer, defaults to `cert-manager.io`. Set it to use an external issuer. +
+	// +optional
+	Group string `json:"group,omitempty"`
 }
 
 // CustomCertificates (`customCertificates`) provides groupings of the TLS certificates manually added to the Operator as part of tenant creation. These fields contain no data if there are no custom TLS certificates.
@@ -619,6 +1114,14 @@ type CustomCertificates struct {
 	//
 	// Certificate Authorities
 	MinioCAs []*CustomCertificateConfig `json:"minioCAs,omitempty"`
+	// *Optional* +
+	//
+	// KES server and KMS client certificates
+	KES []*CustomCertificateConfig `json:"kes,omitempty"`
+	// *Optional* +
+	//
+	// Hash of the content of the user provided secrets mounted by the KES pods, the pods are restarted when it changes +
+	KESSecretsHash string `json:"kesSecretsHash,omitempty"`
 }
 
 // CustomCertificateConfig (`customCertificateConfig`) provides attributes associated of the TLS certificates manually added to the Operator as part of tenant creation. These fields contain no data if there are no custom TLS certificates.
@@ -750,6 +1253,105 @@ type Pool struct {
 	// If provided, each pod on the Statefulset will get the specified terminationGracePeriodSeconds.
 	// +optional
 	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// *Optional* +
+	//
+	// Grow the PVCs of the pool before they fill up, based on the usage reported by the tenant health monitoring. +
+	// The StorageClass of the PVCs must set `allowVolumeExpansion`. The volume claim template keeps its size, PVCs recreated at that size, for example by a drive replacement or a node recovery, are grown to the size of the other PVCs of the pool. +
+	// +optional
+	StorageAutoscaling *StorageAutoscaling `json:"storageAutoscaling,omitempty"`
+	// *Optional* +
+	//
+	// Release the PVCs of the pods stuck on a node that is lost, so they are rescheduled on a healthy node with fresh drives that MinIO heals. +
+	// Meant for pools on local persistent volumes, the data on the released drives is lost. +
+	// +optional
+	NodeFailureRecovery *NodeFailureRecovery `json:"nodeFailureRecovery,omitempty"`
+	// *Optional* +
+	//
+	// The PodDisruptionBudget the Operator creates for the pool. By default `maxUnavailable` is the number of servers the erasure sets of the pool can lose without losing write quorum, computed from the servers, the volumes per server and the parity of the `STANDARD` storage class. When the parity doesn't tolerate the loss of a whole server it is `0`, which blocks the drain of the nodes of the pool, and the Operator records a `PodDisruptionBudgetBlocksDrains` warning. +
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
+	// *Optional* +
+	//
+	// Spread the servers of the pool across nodes or zones. The Operator generates the pod anti-affinity and the topology spread constraints of the pool from the tenant labels, the `affinity` and `topologySpreadConstraints` of the pool take precedence when set. +
+	// +optional
+	Placement *PoolPlacement `json:"placement,omitempty"`
+}
+
+// StorageAutoscaling (`storageAutoscaling`) defines when and how much the PVCs of a pool are expanded. +
+type StorageAutoscaling struct {
+	// *Optional* +
+	//
+	// Percentage of the usable capacity of the pool in use above which its PVCs are expanded. Defaults to `80`. +
+	//
+	// +optional
+	ThresholdPercent *int32 `json:"thresholdPercent,omitempty"`
+	// *Required* +
+	//
+	// Storage added to each PVC of the pool on every expansion, for example `100Gi`. +
+	Step resource.Quantity `json:"step"`
+	// *Required* +
+	//
+	// Size the PVCs of the pool are never expanded beyond. +
+	MaxSize resource.Quantity `json:"maxSize"`
+	// *Optional* +
+	//
+	// Minutes to wait after an expansion before the pool is expanded again. Defaults to `60`. +
+	//
+	// +optional
+	CooldownMinutes *int32 `json:"cooldownMinutes,omitempty"`
+}
+
+// NodeFailureRecovery (`nodeFailureRecovery`) defines when the PVCs of the pods of a pool on a lost node are released. +
+type NodeFailureRecovery struct {
+	// *Optional* +
+	//
+	// Minutes a node has to be `NotReady` or deleted before the PVCs of its pods are released. Defaults to `30`. +
+	//
+	// +optional
+	GracePeriodMinutes *int32 `json:"gracePeriodMinutes,omitempty"`
+}
+
+// PoolPlacementPolicy is how the servers of a pool are spread
+// +kubebuilder:validation:Enum=SpreadNodes;SpreadZones;StrictSpreadZones
+type PoolPlacementPolicy string
+
+const (
+	// PoolPlacementSpreadNodes runs every server of the pool on a different node
+	PoolPlacementSpreadNodes PoolPlacementPolicy = "SpreadNodes"
+	// PoolPlacementSpreadZones runs every server of the pool on a different node and balances the servers of every erasure set across zones when possible, the servers may end up in fewer zones
+	PoolPlacementSpreadZones PoolPlacementPolicy = "SpreadZones"
+	// PoolPlacementStrictSpreadZones runs every server of the pool on a different node and only schedules them when the zones hold the same number of servers of every erasure set, give or take one
+	PoolPlacementStrictSpreadZones PoolPlacementPolicy = "StrictSpreadZones"
+)
+
+// PoolPlacement (`placement`) defines how the servers of a pool are spread across nodes and zones. +
+type PoolPlacement struct {
+	// *Required* +
+	//
+	// The placement policy, one of `SpreadNodes`, `SpreadZones` or `StrictSpreadZones`. The zone policies balance the servers of every erasure set across the zones, a pool whose erasure sets span groups of consecutive servers balances each group on its own. `SpreadZones` is best effort, only `StrictSpreadZones` keeps the servers off a zone when the others can't take their share. +
+	Policy PoolPlacementPolicy `json:"policy"`
+	// *Optional* +
+	//
+	// Number of zones the servers of the pool are spread across, required by `StrictSpreadZones`. The Operator rejects a strict layout whose erasure sets can't survive the loss of a zone with the parity of the `STANDARD` storage class. +
+	//
+	// +optional
+	Zones int32 `json:"zones,omitempty"`
+}
+
+// PodDisruptionBudgetConfig (`podDisruptionBudget`) overrides the PodDisruptionBudget the Operator creates for a pool or for KES. +
+type PodDisruptionBudgetConfig struct {
+	// *Optional* +
+	//
+	// Set to `false` to not create the PodDisruptionBudget. Defaults to `true`. +
+	//
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// *Optional* +
+	//
+	// Number or percentage of pods that can be evicted at the same time, overrides the value computed by the Operator. +
+	//
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 // EqualImage returns true if config image and current input image are same
@@ -881,6 +1483,11 @@ type KESConfig struct {
 	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 	// *Optional* +
 	//
+	// The PodDisruptionBudget the Operator creates for the KES pods. By default a single KES pod can be evicted at a time. +
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
+	// *Optional* +
+	//
 	// If provided, use this as the name of the key that KES creates on the KMS backend
 	// +optional
 	KeyName string `json:"keyName,omitempty"`
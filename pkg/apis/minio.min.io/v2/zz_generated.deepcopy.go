@@ -22,8 +22,10 @@
 package v2
 
 import (
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -63,6 +65,84 @@ func (in *Bucket) DeepCopy() *Bucket {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleConfig) DeepCopyInto(out *CABundleConfig) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleConfig.
+func (in *CABundleConfig) DeepCopy() *CABundleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleStatus) DeepCopyInto(out *CABundleStatus) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleStatus.
+func (in *CABundleStatus) DeepCopy() *CABundleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityAlertsConfig) DeepCopyInto(out *CapacityAlertsConfig) {
+	*out = *in
+	if in.WarningThreshold != nil {
+		in, out := &in.WarningThreshold, &out.WarningThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CriticalThreshold != nil {
+		in, out := &in.CriticalThreshold, &out.CriticalThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WarningDaysUntilFull != nil {
+		in, out := &in.WarningDaysUntilFull, &out.WarningDaysUntilFull
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CriticalDaysUntilFull != nil {
+		in, out := &in.CriticalDaysUntilFull, &out.CriticalDaysUntilFull
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityAlertsConfig.
+func (in *CapacityAlertsConfig) DeepCopy() *CapacityAlertsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityAlertsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateConfig) DeepCopyInto(out *CertificateConfig) {
 	*out = *in
@@ -76,6 +156,31 @@ func (in *CertificateConfig) DeepCopyInto(out *CertificateConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertificateIssuerRef)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.URIs != nil {
+		in, out := &in.URIs, &out.URIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Subject != nil {
+		in, out := &in.Subject, &out.Subject
+		*out = new(CertificateSubject)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -89,6 +194,22 @@ func (in *CertificateConfig) DeepCopy() *CertificateConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateIssuerRef) DeepCopyInto(out *CertificateIssuerRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateIssuerRef.
+func (in *CertificateIssuerRef) DeepCopy() *CertificateIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
 	*out = *in
@@ -115,6 +236,52 @@ func (in *CertificateStatus) DeepCopy() *CertificateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateSubject) DeepCopyInto(out *CertificateSubject) {
+	*out = *in
+	if in.OrganizationalUnits != nil {
+		in, out := &in.OrganizationalUnits, &out.OrganizationalUnits
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Countries != nil {
+		in, out := &in.Countries, &out.Countries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Provinces != nil {
+		in, out := &in.Provinces, &out.Provinces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Localities != nil {
+		in, out := &in.Localities, &out.Localities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StreetAddresses != nil {
+		in, out := &in.StreetAddresses, &out.StreetAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostalCodes != nil {
+		in, out := &in.PostalCodes, &out.PostalCodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateSubject.
+func (in *CertificateSubject) DeepCopy() *CertificateSubject {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateSubject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomCertificateConfig) DeepCopyInto(out *CustomCertificateConfig) {
 	*out = *in
@@ -172,6 +339,17 @@ func (in *CustomCertificates) DeepCopyInto(out *CustomCertificates) {
 			}
 		}
 	}
+	if in.KES != nil {
+		in, out := &in.KES, &out.KES
+		*out = make([]*CustomCertificateConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(CustomCertificateConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	return
 }
 
@@ -185,6 +363,42 @@ func (in *CustomCertificates) DeepCopy() *CustomCertificates {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriveReplacement) DeepCopyInto(out *DriveReplacement) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriveReplacement.
+func (in *DriveReplacement) DeepCopy() *DriveReplacement {
+	if in == nil {
+		return nil
+	}
+	out := new(DriveReplacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErasureSetStatus) DeepCopyInto(out *ErasureSetStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErasureSetStatus.
+func (in *ErasureSetStatus) DeepCopy() *ErasureSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ErasureSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExposeServices) DeepCopyInto(out *ExposeServices) {
 	*out = *in
@@ -232,7 +446,7 @@ func (in *KESConfig) DeepCopyInto(out *KESConfig) {
 	*out = *in
 	if in.Configuration != nil {
 		in, out := &in.Configuration, &out.Configuration
-		*out = new(v1.LocalObjectReference)
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
 	if in.ExternalCertSecret != nil {
@@ -269,36 +483,41 @@ func (in *KESConfig) DeepCopyInto(out *KESConfig) {
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.TopologySpreadConstraints != nil {
 		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
-		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SecurityContext != nil {
 		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.PodSecurityContext)
+		*out = new(corev1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ContainerSecurityContext != nil {
 		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
-		*out = new(v1.SecurityContext)
+		*out = new(corev1.SecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -348,12 +567,75 @@ func (in *Logging) DeepCopy() *Logging {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeFailureRecovery) DeepCopyInto(out *NodeFailureRecovery) {
+	*out = *in
+	if in.GracePeriodMinutes != nil {
+		in, out := &in.GracePeriodMinutes, &out.GracePeriodMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFailureRecovery.
+func (in *NodeFailureRecovery) DeepCopy() *NodeFailureRecovery {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeFailureRecovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVCResizeStatus) DeepCopyInto(out *PVCResizeStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCResizeStatus.
+func (in *PVCResizeStatus) DeepCopy() *PVCResizeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PVCResizeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetConfig) DeepCopyInto(out *PodDisruptionBudgetConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetConfig.
+func (in *PodDisruptionBudgetConfig) DeepCopy() *PodDisruptionBudgetConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Pool) DeepCopyInto(out *Pool) {
 	*out = *in
 	if in.VolumeClaimTemplate != nil {
 		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
-		*out = new(v1.PersistentVolumeClaim)
+		*out = new(corev1.PersistentVolumeClaim)
 		(*in).DeepCopyInto(*out)
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
@@ -366,31 +648,31 @@ func (in *Pool) DeepCopyInto(out *Pool) {
 	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.TopologySpreadConstraints != nil {
 		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
-		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.SecurityContext != nil {
 		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.PodSecurityContext)
+		*out = new(corev1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ContainerSecurityContext != nil {
 		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
-		*out = new(v1.SecurityContext)
+		*out = new(corev1.SecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Annotations != nil {
@@ -412,6 +694,31 @@ func (in *Pool) DeepCopyInto(out *Pool) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.StorageAutoscaling != nil {
+		in, out := &in.StorageAutoscaling, &out.StorageAutoscaling
+		*out = new(StorageAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeFailureRecovery != nil {
+		in, out := &in.NodeFailureRecovery, &out.NodeFailureRecovery
+		*out = new(NodeFailureRecovery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(PoolPlacement)
+		**out = **in
+	}
 	return
 }
 
@@ -425,9 +732,44 @@ func (in *Pool) DeepCopy() *Pool {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolPlacement) DeepCopyInto(out *PoolPlacement) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolPlacement.
+func (in *PoolPlacement) DeepCopy() *PoolPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PoolStatus) DeepCopyInto(out *PoolStatus) {
 	*out = *in
+	if in.ErasureSets != nil {
+		in, out := &in.ErasureSets, &out.ErasureSets
+		*out = make([]ErasureSetStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.DaysUntilFull != nil {
+		in, out := &in.DaysUntilFull, &out.DaysUntilFull
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LastStorageExpansion != nil {
+		in, out := &in.LastStorageExpansion, &out.LastStorageExpansion
+		*out = (*in).DeepCopy()
+	}
+	if in.ResizingPVCs != nil {
+		in, out := &in.ResizingPVCs, &out.ResizingPVCs
+		*out = make([]PVCResizeStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -441,6 +783,28 @@ func (in *PoolStatus) DeepCopy() *PoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolTemplate) DeepCopyInto(out *PoolTemplate) {
+	*out = *in
+	in.Pool.DeepCopyInto(&out.Pool)
+	if in.ThresholdPercent != nil {
+		in, out := &in.ThresholdPercent, &out.ThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolTemplate.
+func (in *PoolTemplate) DeepCopy() *PoolTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PoolsMetadata) DeepCopyInto(out *PoolsMetadata) {
 	*out = *in
@@ -471,6 +835,79 @@ func (in *PoolsMetadata) DeepCopy() *PoolsMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRulesConfig) DeepCopyInto(out *PrometheusRulesConfig) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AlertLabels != nil {
+		in, out := &in.AlertLabels, &out.AlertLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CapacityWarningThreshold != nil {
+		in, out := &in.CapacityWarningThreshold, &out.CapacityWarningThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CapacityCriticalThreshold != nil {
+		in, out := &in.CapacityCriticalThreshold, &out.CapacityCriticalThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HealingThresholdMinutes != nil {
+		in, out := &in.HealingThresholdMinutes, &out.HealingThresholdMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRulesConfig.
+func (in *PrometheusRulesConfig) DeepCopy() *PrometheusRulesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRulesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RootCredentialsStatus) DeepCopyInto(out *RootCredentialsStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.RestartedAt != nil {
+		in, out := &in.RestartedAt, &out.RestartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RootCredentialsStatus.
+func (in *RootCredentialsStatus) DeepCopy() *RootCredentialsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RootCredentialsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceMetadata) DeepCopyInto(out *ServiceMetadata) {
 	*out = *in
@@ -534,28 +971,28 @@ func (in *SideCars) DeepCopyInto(out *SideCars) {
 	*out = *in
 	if in.Containers != nil {
 		in, out := &in.Containers, &out.Containers
-		*out = make([]v1.Container, len(*in))
+		*out = make([]corev1.Container, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.VolumeClaimTemplates != nil {
 		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
-		*out = make([]v1.PersistentVolumeClaim, len(*in))
+		*out = make([]corev1.PersistentVolumeClaim, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -571,6 +1008,34 @@ func (in *SideCars) DeepCopy() *SideCars {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageAutoscaling) DeepCopyInto(out *StorageAutoscaling) {
+	*out = *in
+	if in.ThresholdPercent != nil {
+		in, out := &in.ThresholdPercent, &out.ThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+	out.Step = in.Step.DeepCopy()
+	out.MaxSize = in.MaxSize.DeepCopy()
+	if in.CooldownMinutes != nil {
+		in, out := &in.CooldownMinutes, &out.CooldownMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageAutoscaling.
+func (in *StorageAutoscaling) DeepCopy() *StorageAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tenant) DeepCopyInto(out *Tenant) {
 	*out = *in
@@ -683,7 +1148,7 @@ func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 	out.ImagePullSecret = in.ImagePullSecret
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -736,24 +1201,29 @@ func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(CABundleConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Liveness != nil {
 		in, out := &in.Liveness, &out.Liveness
-		*out = new(v1.Probe)
+		*out = new(corev1.Probe)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Readiness != nil {
 		in, out := &in.Readiness, &out.Readiness
-		*out = new(v1.Probe)
+		*out = new(corev1.Probe)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Startup != nil {
 		in, out := &in.Startup, &out.Startup
-		*out = new(v1.Probe)
+		*out = new(corev1.Probe)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Lifecycle != nil {
 		in, out := &in.Lifecycle, &out.Lifecycle
-		*out = new(v1.Lifecycle)
+		*out = new(corev1.Lifecycle)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Features != nil {
@@ -776,6 +1246,33 @@ func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrometheusOperatorMonitorLabels != nil {
+		in, out := &in.PrometheusOperatorMonitorLabels, &out.PrometheusOperatorMonitorLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PrometheusRules != nil {
+		in, out := &in.PrometheusRules, &out.PrometheusRules
+		*out = new(PrometheusRulesConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthCheckIntervalSeconds != nil {
+		in, out := &in.HealthCheckIntervalSeconds, &out.HealthCheckIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CapacityAlerts != nil {
+		in, out := &in.CapacityAlerts, &out.CapacityAlerts
+		*out = new(CapacityAlertsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PoolTemplate != nil {
+		in, out := &in.PoolTemplate, &out.PoolTemplate
+		*out = new(PoolTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SideCars != nil {
 		in, out := &in.SideCars, &out.SideCars
 		*out = new(SideCars)
@@ -798,7 +1295,7 @@ func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 	}
 	if in.Users != nil {
 		in, out := &in.Users, &out.Users
-		*out = make([]v1.LocalObjectReference, len(*in))
+		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	if in.Buckets != nil {
@@ -813,26 +1310,26 @@ func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 	}
 	if in.Configuration != nil {
 		in, out := &in.Configuration, &out.Configuration
-		*out = new(v1.LocalObjectReference)
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
 	if in.InitContainers != nil {
 		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]v1.Container, len(*in))
+		*out = make([]corev1.Container, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.AdditionalVolumes != nil {
 		in, out := &in.AdditionalVolumes, &out.AdditionalVolumes
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.AdditionalVolumeMounts != nil {
 		in, out := &in.AdditionalVolumeMounts, &out.AdditionalVolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
+		*out = make([]corev1.VolumeMount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -857,13 +1354,48 @@ func (in *TenantStatus) DeepCopyInto(out *TenantStatus) {
 	if in.Pools != nil {
 		in, out := &in.Pools, &out.Pools
 		*out = make([]PoolStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HealthStaleSince != nil {
+		in, out := &in.HealthStaleSince, &out.HealthStaleSince
+		*out = (*in).DeepCopy()
 	}
 	if in.WaitingOnReady != nil {
 		in, out := &in.WaitingOnReady, &out.WaitingOnReady
 		*out = (*in).DeepCopy()
 	}
 	in.Usage.DeepCopyInto(&out.Usage)
+	if in.UnhealthyDrives != nil {
+		in, out := &in.UnhealthyDrives, &out.UnhealthyDrives
+		*out = make([]UnhealthyDrive, len(*in))
+		copy(*out, *in)
+	}
+	if in.DriveReplacements != nil {
+		in, out := &in.DriveReplacements, &out.DriveReplacements
+		*out = make([]DriveReplacement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RootCredentials != nil {
+		in, out := &in.RootCredentials, &out.RootCredentials
+		*out = new(RootCredentialsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(CABundleStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -885,6 +1417,11 @@ func (in *TenantUsage) DeepCopyInto(out *TenantUsage) {
 		*out = make([]TierUsage, len(*in))
 		copy(*out, *in)
 	}
+	if in.DaysUntilFull != nil {
+		in, out := &in.DaysUntilFull, &out.DaysUntilFull
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -913,3 +1450,19 @@ func (in *TierUsage) DeepCopy() *TierUsage {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthyDrive) DeepCopyInto(out *UnhealthyDrive) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyDrive.
+func (in *UnhealthyDrive) DeepCopy() *UnhealthyDrive {
+	if in == nil {
+		return nil
+	}
+	out := new(UnhealthyDrive)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -63,14 +63,37 @@ type PolicyBindingUsage struct {
 
 // PolicyBindingSpec (`spec`) defines the configuration of a MinIO PolicyBinding object. +
 type PolicyBindingSpec struct {
-	// *Required* +
+	// *Optional* +
+	//
+	// The Application Property identifies the namespace and service account that will be authorized through
+	// `AssumeRoleWithWebIdentity`. Either `application` or `certificate` must be set. +
+	// +optional
+	Application *Application `json:"application,omitempty"`
+	// *Optional* +
 	//
-	// The Application Property identifies the namespace and service account that will be authorized
-	Application *Application `json:"application"`
+	// The Certificate Property identifies the client certificate that will be authorized through
+	// `AssumeRoleWithCertificate`. Either `application` or `certificate` must be set. +
+	// +optional
+	Certificate *CertificateIdentity `json:"certificate,omitempty"`
 	// *Required* +
 	Policies []string `json:"policies"`
 }
 
+// CertificateIdentity matches the client certificates authorized to use the policies listed, all the fields set must
+// match the certificate
+type CertificateIdentity struct {
+	// *Optional* +
+	//
+	// The Common Name of the subject of the certificate. +
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+	// *Optional* +
+	//
+	// A DNS name, email address or URI among the Subject Alternative Names of the certificate. +
+	// +optional
+	SAN string `json:"san,omitempty"`
+}
+
 // Application defines the `Namespace` and `ServiceAccount` to authorize the usage of the policies listed
 type Application struct {
 	// *Required* +
@@ -41,6 +41,22 @@ func (in *Application) DeepCopy() *Application {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateIdentity) DeepCopyInto(out *CertificateIdentity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateIdentity.
+func (in *CertificateIdentity) DeepCopy() *CertificateIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyBinding) DeepCopyInto(out *PolicyBinding) {
 	*out = *in
@@ -110,6 +126,11 @@ func (in *PolicyBindingSpec) DeepCopyInto(out *PolicyBindingSpec) {
 		*out = new(Application)
 		**out = **in
 	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(CertificateIdentity)
+		**out = **in
+	}
 	if in.Policies != nil {
 		in, out := &in.Policies, &out.Policies
 		*out = make([]string, len(*in))
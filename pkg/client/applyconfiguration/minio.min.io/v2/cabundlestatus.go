@@ -0,0 +1,50 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+// CABundleStatusApplyConfiguration represents a declarative configuration of the CABundleStatus type for use
+// with apply.
+type CABundleStatusApplyConfiguration struct {
+	ConfigMapName *string  `json:"configMapName,omitempty"`
+	Namespaces    []string `json:"namespaces,omitempty"`
+}
+
+// CABundleStatusApplyConfiguration constructs a declarative configuration of the CABundleStatus type for use with
+// apply.
+func CABundleStatus() *CABundleStatusApplyConfiguration {
+	return &CABundleStatusApplyConfiguration{}
+}
+
+// WithConfigMapName sets the ConfigMapName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConfigMapName field is set to the value of the last call.
+func (b *CABundleStatusApplyConfiguration) WithConfigMapName(value string) *CABundleStatusApplyConfiguration {
+	b.ConfigMapName = &value
+	return b
+}
+
+// WithNamespaces adds the given value to the Namespaces field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Namespaces field.
+func (b *CABundleStatusApplyConfiguration) WithNamespaces(values ...string) *CABundleStatusApplyConfiguration {
+	for i := range values {
+		b.Namespaces = append(b.Namespaces, values[i])
+	}
+	return b
+}
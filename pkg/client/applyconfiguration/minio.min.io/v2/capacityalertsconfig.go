@@ -0,0 +1,66 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+// CapacityAlertsConfigApplyConfiguration represents a declarative configuration of the CapacityAlertsConfig type for use
+// with apply.
+type CapacityAlertsConfigApplyConfiguration struct {
+	WarningThreshold      *int32 `json:"warningThreshold,omitempty"`
+	CriticalThreshold     *int32 `json:"criticalThreshold,omitempty"`
+	WarningDaysUntilFull  *int32 `json:"warningDaysUntilFull,omitempty"`
+	CriticalDaysUntilFull *int32 `json:"criticalDaysUntilFull,omitempty"`
+}
+
+// CapacityAlertsConfigApplyConfiguration constructs a declarative configuration of the CapacityAlertsConfig type for use with
+// apply.
+func CapacityAlertsConfig() *CapacityAlertsConfigApplyConfiguration {
+	return &CapacityAlertsConfigApplyConfiguration{}
+}
+
+// WithWarningThreshold sets the WarningThreshold field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WarningThreshold field is set to the value of the last call.
+func (b *CapacityAlertsConfigApplyConfiguration) WithWarningThreshold(value int32) *CapacityAlertsConfigApplyConfiguration {
+	b.WarningThreshold = &value
+	return b
+}
+
+// WithCriticalThreshold sets the CriticalThreshold field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CriticalThreshold field is set to the value of the last call.
+func (b *CapacityAlertsConfigApplyConfiguration) WithCriticalThreshold(value int32) *CapacityAlertsConfigApplyConfiguration {
+	b.CriticalThreshold = &value
+	return b
+}
+
+// WithWarningDaysUntilFull sets the WarningDaysUntilFull field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WarningDaysUntilFull field is set to the value of the last call.
+func (b *CapacityAlertsConfigApplyConfiguration) WithWarningDaysUntilFull(value int32) *CapacityAlertsConfigApplyConfiguration {
+	b.WarningDaysUntilFull = &value
+	return b
+}
+
+// WithCriticalDaysUntilFull sets the CriticalDaysUntilFull field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CriticalDaysUntilFull field is set to the value of the last call.
+func (b *CapacityAlertsConfigApplyConfiguration) WithCriticalDaysUntilFull(value int32) *CapacityAlertsConfigApplyConfiguration {
+	b.CriticalDaysUntilFull = &value
+	return b
+}
@@ -18,12 +18,24 @@
 
 package v2
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // CertificateConfigApplyConfiguration represents a declarative configuration of the CertificateConfig type for use
 // with apply.
 type CertificateConfigApplyConfiguration struct {
-	CommonName       *string  `json:"commonName,omitempty"`
-	OrganizationName []string `json:"organizationName,omitempty"`
-	DNSNames         []string `json:"dnsNames,omitempty"`
+	CommonName         *string                                 `json:"commonName,omitempty"`
+	OrganizationName   []string                                `json:"organizationName,omitempty"`
+	DNSNames           []string                                `json:"dnsNames,omitempty"`
+	IssuerRef          *CertificateIssuerRefApplyConfiguration `json:"issuerRef,omitempty"`
+	KeyAlgorithm       *string                                 `json:"keyAlgorithm,omitempty"`
+	KeySize            *int                                    `json:"keySize,omitempty"`
+	Duration           *v1.Duration                            `json:"duration,omitempty"`
+	IPAddresses        []string                                `json:"ipAddresses,omitempty"`
+	URIs               []string                                `json:"uris,omitempty"`
+	Subject            *CertificateSubjectApplyConfiguration   `json:"subject,omitempty"`
+	DomainCertificates *bool                                   `json:"domainCertificates,omitempty"`
 }
 
 // CertificateConfigApplyConfiguration constructs a declarative configuration of the CertificateConfig type for use with
@@ -59,3 +71,71 @@ func (b *CertificateConfigApplyConfiguration) WithDNSNames(values ...string) *Ce
 	}
 	return b
 }
+
+// WithIssuerRef sets the IssuerRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IssuerRef field is set to the value of the last call.
+func (b *CertificateConfigApplyConfiguration) WithIssuerRef(value *CertificateIssuerRefApplyConfiguration) *CertificateConfigApplyConfiguration {
+	b.IssuerRef = value
+	return b
+}
+
+// WithKeyAlgorithm sets the KeyAlgorithm field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KeyAlgorithm field is set to the value of the last call.
+func (b *CertificateConfigApplyConfiguration) WithKeyAlgorithm(value string) *CertificateConfigApplyConfiguration {
+	b.KeyAlgorithm = &value
+	return b
+}
+
+// WithKeySize sets the KeySize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KeySize field is set to the value of the last call.
+func (b *CertificateConfigApplyConfiguration) WithKeySize(value int) *CertificateConfigApplyConfiguration {
+	b.KeySize = &value
+	return b
+}
+
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *CertificateConfigApplyConfiguration) WithDuration(value v1.Duration) *CertificateConfigApplyConfiguration {
+	b.Duration = &value
+	return b
+}
+
+// WithIPAddresses adds the given value to the IPAddresses field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the IPAddresses field.
+func (b *CertificateConfigApplyConfiguration) WithIPAddresses(values ...string) *CertificateConfigApplyConfiguration {
+	for i := range values {
+		b.IPAddresses = append(b.IPAddresses, values[i])
+	}
+	return b
+}
+
+// WithURIs adds the given value to the URIs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the URIs field.
+func (b *CertificateConfigApplyConfiguration) WithURIs(values ...string) *CertificateConfigApplyConfiguration {
+	for i := range values {
+		b.URIs = append(b.URIs, values[i])
+	}
+	return b
+}
+
+// WithSubject sets the Subject field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Subject field is set to the value of the last call.
+func (b *CertificateConfigApplyConfiguration) WithSubject(value *CertificateSubjectApplyConfiguration) *CertificateConfigApplyConfiguration {
+	b.Subject = value
+	return b
+}
+
+// WithDomainCertificates sets the DomainCertificates field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DomainCertificates field is set to the value of the last call.
+func (b *CertificateConfigApplyConfiguration) WithDomainCertificates(value bool) *CertificateConfigApplyConfiguration {
+	b.DomainCertificates = &value
+	return b
+}
@@ -0,0 +1,57 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+// CertificateIssuerRefApplyConfiguration represents a declarative configuration of the CertificateIssuerRef type for use
+// with apply.
+type CertificateIssuerRefApplyConfiguration struct {
+	Name  *string `json:"name,omitempty"`
+	Kind  *string `json:"kind,omitempty"`
+	Group *string `json:"group,omitempty"`
+}
+
+// CertificateIssuerRefApplyConfiguration constructs a declarative configuration of the CertificateIssuerRef type for use with
+// apply.
+func CertificateIssuerRef() *CertificateIssuerRefApplyConfiguration {
+	return &CertificateIssuerRefApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *CertificateIssuerRefApplyConfiguration) WithName(value string) *CertificateIssuerRefApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *CertificateIssuerRefApplyConfiguration) WithKind(value string) *CertificateIssuerRefApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithGroup sets the Group field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Group field is set to the value of the last call.
+func (b *CertificateIssuerRefApplyConfiguration) WithGroup(value string) *CertificateIssuerRefApplyConfiguration {
+	b.Group = &value
+	return b
+}
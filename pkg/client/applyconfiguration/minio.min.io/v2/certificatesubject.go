@@ -0,0 +1,105 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+// CertificateSubjectApplyConfiguration represents a declarative configuration of the CertificateSubject type for use
+// with apply.
+type CertificateSubjectApplyConfiguration struct {
+	OrganizationalUnits []string `json:"organizationalUnits,omitempty"`
+	Countries           []string `json:"countries,omitempty"`
+	Provinces           []string `json:"provinces,omitempty"`
+	Localities          []string `json:"localities,omitempty"`
+	StreetAddresses     []string `json:"streetAddresses,omitempty"`
+	PostalCodes         []string `json:"postalCodes,omitempty"`
+	SerialNumber        *string  `json:"serialNumber,omitempty"`
+}
+
+// CertificateSubjectApplyConfiguration constructs a declarative configuration of the CertificateSubject type for use with
+// apply.
+func CertificateSubject() *CertificateSubjectApplyConfiguration {
+	return &CertificateSubjectApplyConfiguration{}
+}
+
+// WithOrganizationalUnits adds the given value to the OrganizationalUnits field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OrganizationalUnits field.
+func (b *CertificateSubjectApplyConfiguration) WithOrganizationalUnits(values ...string) *CertificateSubjectApplyConfiguration {
+	for i := range values {
+		b.OrganizationalUnits = append(b.OrganizationalUnits, values[i])
+	}
+	return b
+}
+
+// WithCountries adds the given value to the Countries field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Countries field.
+func (b *CertificateSubjectApplyConfiguration) WithCountries(values ...string) *CertificateSubjectApplyConfiguration {
+	for i := range values {
+		b.Countries = append(b.Countries, values[i])
+	}
+	return b
+}
+
+// WithProvinces adds the given value to the Provinces field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Provinces field.
+func (b *CertificateSubjectApplyConfiguration) WithProvinces(values ...string) *CertificateSubjectApplyConfiguration {
+	for i := range values {
+		b.Provinces = append(b.Provinces, values[i])
+	}
+	return b
+}
+
+// WithLocalities adds the given value to the Localities field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Localities field.
+func (b *CertificateSubjectApplyConfiguration) WithLocalities(values ...string) *CertificateSubjectApplyConfiguration {
+	for i := range values {
+		b.Localities = append(b.Localities, values[i])
+	}
+	return b
+}
+
+// WithStreetAddresses adds the given value to the StreetAddresses field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the StreetAddresses field.
+func (b *CertificateSubjectApplyConfiguration) WithStreetAddresses(values ...string) *CertificateSubjectApplyConfiguration {
+	for i := range values {
+		b.StreetAddresses = append(b.StreetAddresses, values[i])
+	}
+	return b
+}
+
+// WithPostalCodes adds the given value to the PostalCodes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PostalCodes field.
+func (b *CertificateSubjectApplyConfiguration) WithPostalCodes(values ...string) *CertificateSubjectApplyConfiguration {
+	for i := range values {
+		b.PostalCodes = append(b.PostalCodes, values[i])
+	}
+	return b
+}
+
+// WithSerialNumber sets the SerialNumber field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SerialNumber field is set to the value of the last call.
+func (b *CertificateSubjectApplyConfiguration) WithSerialNumber(value string) *CertificateSubjectApplyConfiguration {
+	b.SerialNumber = &value
+	return b
+}
@@ -25,9 +25,11 @@ import (
 // CustomCertificatesApplyConfiguration represents a declarative configuration of the CustomCertificates type for use
 // with apply.
 type CustomCertificatesApplyConfiguration struct {
-	Client   []*miniominiov2.CustomCertificateConfig `json:"client,omitempty"`
-	Minio    []*miniominiov2.CustomCertificateConfig `json:"minio,omitempty"`
-	MinioCAs []*miniominiov2.CustomCertificateConfig `json:"minioCAs,omitempty"`
+	Client         []*miniominiov2.CustomCertificateConfig `json:"client,omitempty"`
+	Minio          []*miniominiov2.CustomCertificateConfig `json:"minio,omitempty"`
+	MinioCAs       []*miniominiov2.CustomCertificateConfig `json:"minioCAs,omitempty"`
+	KES            []*miniominiov2.CustomCertificateConfig `json:"kes,omitempty"`
+	KESSecretsHash *string                                 `json:"kesSecretsHash,omitempty"`
 }
 
 // CustomCertificatesApplyConfiguration constructs a declarative configuration of the CustomCertificates type for use with
@@ -74,3 +76,24 @@ func (b *CustomCertificatesApplyConfiguration) WithMinioCAs(values ...**miniomin
 	}
 	return b
 }
+
+// WithKES adds the given value to the KES field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the KES field.
+func (b *CustomCertificatesApplyConfiguration) WithKES(values ...**miniominiov2.CustomCertificateConfig) *CustomCertificatesApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithKES")
+		}
+		b.KES = append(b.KES, *values[i])
+	}
+	return b
+}
+
+// WithKESSecretsHash sets the KESSecretsHash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KESSecretsHash field is set to the value of the last call.
+func (b *CustomCertificatesApplyConfiguration) WithKESSecretsHash(value string) *CustomCertificatesApplyConfiguration {
+	b.KESSecretsHash = &value
+	return b
+}
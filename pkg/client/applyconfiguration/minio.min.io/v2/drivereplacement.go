@@ -0,0 +1,107 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+import (
+	miniominiov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DriveReplacementApplyConfiguration represents a declarative configuration of the DriveReplacement type for use
+// with apply.
+type DriveReplacementApplyConfiguration struct {
+	Pod         *string                             `json:"pod,omitempty"`
+	PVC         *string                             `json:"pvc,omitempty"`
+	Phase       *miniominiov2.DriveReplacementPhase `json:"phase,omitempty"`
+	PVCUID      *string                             `json:"pvcUID,omitempty"`
+	StartedAt   *v1.Time                            `json:"startedAt,omitempty"`
+	HealedBytes *int64                              `json:"healedBytes,omitempty"`
+	TotalBytes  *int64                              `json:"totalBytes,omitempty"`
+	Message     *string                             `json:"message,omitempty"`
+}
+
+// DriveReplacementApplyConfiguration constructs a declarative configuration of the DriveReplacement type for use with
+// apply.
+func DriveReplacement() *DriveReplacementApplyConfiguration {
+	return &DriveReplacementApplyConfiguration{}
+}
+
+// WithPod sets the Pod field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Pod field is set to the value of the last call.
+func (b *DriveReplacementApplyConfiguration) WithPod(value string) *DriveReplacementApplyConfiguration {
+	b.Pod = &value
+	return b
+}
+
+// WithPVC sets the PVC field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PVC field is set to the value of the last call.
+func (b *DriveReplacementApplyConfiguration) WithPVC(value string) *DriveReplacementApplyConfiguration {
+	b.PVC = &value
+	return b
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *DriveReplacementApplyConfiguration) WithPhase(value miniominiov2.DriveReplacementPhase) *DriveReplacementApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithPVCUID sets the PVCUID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PVCUID field is set to the value of the last call.
+func (b *DriveReplacementApplyConfiguration) WithPVCUID(value string) *DriveReplacementApplyConfiguration {
+	b.PVCUID = &value
+	return b
+}
+
+// WithStartedAt sets the StartedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartedAt field is set to the value of the last call.
+func (b *DriveReplacementApplyConfiguration) WithStartedAt(value v1.Time) *DriveReplacementApplyConfiguration {
+	b.StartedAt = &value
+	return b
+}
+
+// WithHealedBytes sets the HealedBytes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HealedBytes field is set to the value of the last call.
+func (b *DriveReplacementApplyConfiguration) WithHealedBytes(value int64) *DriveReplacementApplyConfiguration {
+	b.HealedBytes = &value
+	return b
+}
+
+// WithTotalBytes sets the TotalBytes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TotalBytes field is set to the value of the last call.
+func (b *DriveReplacementApplyConfiguration) WithTotalBytes(value int64) *DriveReplacementApplyConfiguration {
+	b.TotalBytes = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *DriveReplacementApplyConfiguration) WithMessage(value string) *DriveReplacementApplyConfiguration {
+	b.Message = &value
+	return b
+}
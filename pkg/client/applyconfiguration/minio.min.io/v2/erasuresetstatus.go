@@ -0,0 +1,88 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+import (
+	miniominiov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+// ErasureSetStatusApplyConfiguration represents a declarative configuration of the ErasureSetStatus type for use
+// with apply.
+type ErasureSetStatusApplyConfiguration struct {
+	Index         *int32                     `json:"index,omitempty"`
+	DrivesOnline  *int32                     `json:"drivesOnline,omitempty"`
+	DrivesOffline *int32                     `json:"drivesOffline,omitempty"`
+	DrivesHealing *int32                     `json:"drivesHealing,omitempty"`
+	WriteQuorum   *int32                     `json:"writeQuorum,omitempty"`
+	HealthStatus  *miniominiov2.HealthStatus `json:"healthStatus,omitempty"`
+}
+
+// ErasureSetStatusApplyConfiguration constructs a declarative configuration of the ErasureSetStatus type for use with
+// apply.
+func ErasureSetStatus() *ErasureSetStatusApplyConfiguration {
+	return &ErasureSetStatusApplyConfiguration{}
+}
+
+// WithIndex sets the Index field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Index field is set to the value of the last call.
+func (b *ErasureSetStatusApplyConfiguration) WithIndex(value int32) *ErasureSetStatusApplyConfiguration {
+	b.Index = &value
+	return b
+}
+
+// WithDrivesOnline sets the DrivesOnline field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DrivesOnline field is set to the value of the last call.
+func (b *ErasureSetStatusApplyConfiguration) WithDrivesOnline(value int32) *ErasureSetStatusApplyConfiguration {
+	b.DrivesOnline = &value
+	return b
+}
+
+// WithDrivesOffline sets the DrivesOffline field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DrivesOffline field is set to the value of the last call.
+func (b *ErasureSetStatusApplyConfiguration) WithDrivesOffline(value int32) *ErasureSetStatusApplyConfiguration {
+	b.DrivesOffline = &value
+	return b
+}
+
+// WithDrivesHealing sets the DrivesHealing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DrivesHealing field is set to the value of the last call.
+func (b *ErasureSetStatusApplyConfiguration) WithDrivesHealing(value int32) *ErasureSetStatusApplyConfiguration {
+	b.DrivesHealing = &value
+	return b
+}
+
+// WithWriteQuorum sets the WriteQuorum field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WriteQuorum field is set to the value of the last call.
+func (b *ErasureSetStatusApplyConfiguration) WithWriteQuorum(value int32) *ErasureSetStatusApplyConfiguration {
+	b.WriteQuorum = &value
+	return b
+}
+
+// WithHealthStatus sets the HealthStatus field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HealthStatus field is set to the value of the last call.
+func (b *ErasureSetStatusApplyConfiguration) WithHealthStatus(value miniominiov2.HealthStatus) *ErasureSetStatusApplyConfiguration {
+	b.HealthStatus = &value
+	return b
+}
@@ -41,6 +41,7 @@ type KESConfigApplyConfiguration struct {
 	Tolerations               []v1.Toleration                              `json:"tolerations,omitempty"`
 	Affinity                  *v1.Affinity                                 `json:"affinity,omitempty"`
 	TopologySpreadConstraints []v1.TopologySpreadConstraint                `json:"topologySpreadConstraints,omitempty"`
+	PodDisruptionBudget       *PodDisruptionBudgetConfigApplyConfiguration `json:"podDisruptionBudget,omitempty"`
 	KeyName                   *string                                      `json:"keyName,omitempty"`
 	SecurityContext           *v1.PodSecurityContext                       `json:"securityContext,omitempty"`
 	ContainerSecurityContext  *v1.SecurityContext                          `json:"containerSecurityContext,omitempty"`
@@ -203,6 +204,14 @@ func (b *KESConfigApplyConfiguration) WithTopologySpreadConstraints(values ...v1
 	return b
 }
 
+// WithPodDisruptionBudget sets the PodDisruptionBudget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodDisruptionBudget field is set to the value of the last call.
+func (b *KESConfigApplyConfiguration) WithPodDisruptionBudget(value *PodDisruptionBudgetConfigApplyConfiguration) *KESConfigApplyConfiguration {
+	b.PodDisruptionBudget = value
+	return b
+}
+
 // WithKeyName sets the KeyName field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the KeyName field is set to the value of the last call.
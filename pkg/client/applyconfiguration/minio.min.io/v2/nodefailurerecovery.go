@@ -0,0 +1,39 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+// NodeFailureRecoveryApplyConfiguration represents a declarative configuration of the NodeFailureRecovery type for use
+// with apply.
+type NodeFailureRecoveryApplyConfiguration struct {
+	GracePeriodMinutes *int32 `json:"gracePeriodMinutes,omitempty"`
+}
+
+// NodeFailureRecoveryApplyConfiguration constructs a declarative configuration of the NodeFailureRecovery type for use with
+// apply.
+func NodeFailureRecovery() *NodeFailureRecoveryApplyConfiguration {
+	return &NodeFailureRecoveryApplyConfiguration{}
+}
+
+// WithGracePeriodMinutes sets the GracePeriodMinutes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GracePeriodMinutes field is set to the value of the last call.
+func (b *NodeFailureRecoveryApplyConfiguration) WithGracePeriodMinutes(value int32) *NodeFailureRecoveryApplyConfiguration {
+	b.GracePeriodMinutes = &value
+	return b
+}
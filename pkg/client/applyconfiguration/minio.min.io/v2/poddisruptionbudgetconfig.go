@@ -0,0 +1,52 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+import (
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// PodDisruptionBudgetConfigApplyConfiguration represents a declarative configuration of the PodDisruptionBudgetConfig type for use
+// with apply.
+type PodDisruptionBudgetConfigApplyConfiguration struct {
+	Enabled        *bool               `json:"enabled,omitempty"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// PodDisruptionBudgetConfigApplyConfiguration constructs a declarative configuration of the PodDisruptionBudgetConfig type for use with
+// apply.
+func PodDisruptionBudgetConfig() *PodDisruptionBudgetConfigApplyConfiguration {
+	return &PodDisruptionBudgetConfigApplyConfiguration{}
+}
+
+// WithEnabled sets the Enabled field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Enabled field is set to the value of the last call.
+func (b *PodDisruptionBudgetConfigApplyConfiguration) WithEnabled(value bool) *PodDisruptionBudgetConfigApplyConfiguration {
+	b.Enabled = &value
+	return b
+}
+
+// WithMaxUnavailable sets the MaxUnavailable field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxUnavailable field is set to the value of the last call.
+func (b *PodDisruptionBudgetConfigApplyConfiguration) WithMaxUnavailable(value intstr.IntOrString) *PodDisruptionBudgetConfigApplyConfiguration {
+	b.MaxUnavailable = &value
+	return b
+}
@@ -0,0 +1,52 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+import (
+	miniominiov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+// PoolPlacementApplyConfiguration represents a declarative configuration of the PoolPlacement type for use
+// with apply.
+type PoolPlacementApplyConfiguration struct {
+	Policy *miniominiov2.PoolPlacementPolicy `json:"policy,omitempty"`
+	Zones  *int32                            `json:"zones,omitempty"`
+}
+
+// PoolPlacementApplyConfiguration constructs a declarative configuration of the PoolPlacement type for use with
+// apply.
+func PoolPlacement() *PoolPlacementApplyConfiguration {
+	return &PoolPlacementApplyConfiguration{}
+}
+
+// WithPolicy sets the Policy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Policy field is set to the value of the last call.
+func (b *PoolPlacementApplyConfiguration) WithPolicy(value miniominiov2.PoolPlacementPolicy) *PoolPlacementApplyConfiguration {
+	b.Policy = &value
+	return b
+}
+
+// WithZones sets the Zones field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Zones field is set to the value of the last call.
+func (b *PoolPlacementApplyConfiguration) WithZones(value int32) *PoolPlacementApplyConfiguration {
+	b.Zones = &value
+	return b
+}
@@ -20,14 +20,25 @@ package v2
 
 import (
 	miniominiov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // PoolStatusApplyConfiguration represents a declarative configuration of the PoolStatus type for use
 // with apply.
 type PoolStatusApplyConfiguration struct {
-	SSName                *string                 `json:"ssName,omitempty"`
-	State                 *miniominiov2.PoolState `json:"state,omitempty"`
-	LegacySecurityContext *bool                   `json:"legacySecurityContext,omitempty"`
+	SSName                *string                              `json:"ssName,omitempty"`
+	State                 *miniominiov2.PoolState              `json:"state,omitempty"`
+	LegacySecurityContext *bool                                `json:"legacySecurityContext,omitempty"`
+	Capacity              *int64                               `json:"capacity,omitempty"`
+	Usage                 *int64                               `json:"usage,omitempty"`
+	DrivesOnline          *int32                               `json:"drivesOnline,omitempty"`
+	DrivesOffline         *int32                               `json:"drivesOffline,omitempty"`
+	DrivesHealing         *int32                               `json:"drivesHealing,omitempty"`
+	ErasureSets           []ErasureSetStatusApplyConfiguration `json:"erasureSets,omitempty"`
+	GrowthPerDay          *int64                               `json:"growthPerDay,omitempty"`
+	DaysUntilFull         *int32                               `json:"daysUntilFull,omitempty"`
+	LastStorageExpansion  *v1.Time                             `json:"lastStorageExpansion,omitempty"`
+	ResizingPVCs          []PVCResizeStatusApplyConfiguration  `json:"resizingPVCs,omitempty"`
 }
 
 // PoolStatusApplyConfiguration constructs a declarative configuration of the PoolStatus type for use with
@@ -59,3 +70,93 @@ func (b *PoolStatusApplyConfiguration) WithLegacySecurityContext(value bool) *Po
 	b.LegacySecurityContext = &value
 	return b
 }
+
+// WithCapacity sets the Capacity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Capacity field is set to the value of the last call.
+func (b *PoolStatusApplyConfiguration) WithCapacity(value int64) *PoolStatusApplyConfiguration {
+	b.Capacity = &value
+	return b
+}
+
+// WithUsage sets the Usage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Usage field is set to the value of the last call.
+func (b *PoolStatusApplyConfiguration) WithUsage(value int64) *PoolStatusApplyConfiguration {
+	b.Usage = &value
+	return b
+}
+
+// WithDrivesOnline sets the DrivesOnline field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DrivesOnline field is set to the value of the last call.
+func (b *PoolStatusApplyConfiguration) WithDrivesOnline(value int32) *PoolStatusApplyConfiguration {
+	b.DrivesOnline = &value
+	return b
+}
+
+// WithDrivesOffline sets the DrivesOffline field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DrivesOffline field is set to the value of the last call.
+func (b *PoolStatusApplyConfiguration) WithDrivesOffline(value int32) *PoolStatusApplyConfiguration {
+	b.DrivesOffline = &value
+	return b
+}
+
+// WithDrivesHealing sets the DrivesHealing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DrivesHealing field is set to the value of the last call.
+func (b *PoolStatusApplyConfiguration) WithDrivesHealing(value int32) *PoolStatusApplyConfiguration {
+	b.DrivesHealing = &value
+	return b
+}
+
+// WithErasureSets adds the given value to the ErasureSets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ErasureSets field.
+func (b *PoolStatusApplyConfiguration) WithErasureSets(values ...*ErasureSetStatusApplyConfiguration) *PoolStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithErasureSets")
+		}
+		b.ErasureSets = append(b.ErasureSets, *values[i])
+	}
+	return b
+}
+
+// WithGrowthPerDay sets the GrowthPerDay field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GrowthPerDay field is set to the value of the last call.
+func (b *PoolStatusApplyConfiguration) WithGrowthPerDay(value int64) *PoolStatusApplyConfiguration {
+	b.GrowthPerDay = &value
+	return b
+}
+
+// WithDaysUntilFull sets the DaysUntilFull field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DaysUntilFull field is set to the value of the last call.
+func (b *PoolStatusApplyConfiguration) WithDaysUntilFull(value int32) *PoolStatusApplyConfiguration {
+	b.DaysUntilFull = &value
+	return b
+}
+
+// WithLastStorageExpansion sets the LastStorageExpansion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastStorageExpansion field is set to the value of the last call.
+func (b *PoolStatusApplyConfiguration) WithLastStorageExpansion(value v1.Time) *PoolStatusApplyConfiguration {
+	b.LastStorageExpansion = &value
+	return b
+}
+
+// WithResizingPVCs adds the given value to the ResizingPVCs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ResizingPVCs field.
+func (b *PoolStatusApplyConfiguration) WithResizingPVCs(values ...*PVCResizeStatusApplyConfiguration) *PoolStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResizingPVCs")
+		}
+		b.ResizingPVCs = append(b.ResizingPVCs, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,57 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+// PoolTemplateApplyConfiguration represents a declarative configuration of the PoolTemplate type for use
+// with apply.
+type PoolTemplateApplyConfiguration struct {
+	Pool             *PoolApplyConfiguration `json:"pool,omitempty"`
+	ThresholdPercent *int32                  `json:"thresholdPercent,omitempty"`
+	MaxPools         *int32                  `json:"maxPools,omitempty"`
+}
+
+// PoolTemplateApplyConfiguration constructs a declarative configuration of the PoolTemplate type for use with
+// apply.
+func PoolTemplate() *PoolTemplateApplyConfiguration {
+	return &PoolTemplateApplyConfiguration{}
+}
+
+// WithPool sets the Pool field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Pool field is set to the value of the last call.
+func (b *PoolTemplateApplyConfiguration) WithPool(value *PoolApplyConfiguration) *PoolTemplateApplyConfiguration {
+	b.Pool = value
+	return b
+}
+
+// WithThresholdPercent sets the ThresholdPercent field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ThresholdPercent field is set to the value of the last call.
+func (b *PoolTemplateApplyConfiguration) WithThresholdPercent(value int32) *PoolTemplateApplyConfiguration {
+	b.ThresholdPercent = &value
+	return b
+}
+
+// WithMaxPools sets the MaxPools field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxPools field is set to the value of the last call.
+func (b *PoolTemplateApplyConfiguration) WithMaxPools(value int32) *PoolTemplateApplyConfiguration {
+	b.MaxPools = &value
+	return b
+}
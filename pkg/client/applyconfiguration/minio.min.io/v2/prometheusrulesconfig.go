@@ -0,0 +1,87 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+// PrometheusRulesConfigApplyConfiguration represents a declarative configuration of the PrometheusRulesConfig type for use
+// with apply.
+type PrometheusRulesConfigApplyConfiguration struct {
+	Labels                    map[string]string `json:"labels,omitempty"`
+	AlertLabels               map[string]string `json:"alertLabels,omitempty"`
+	CapacityWarningThreshold  *int32            `json:"capacityWarningThreshold,omitempty"`
+	CapacityCriticalThreshold *int32            `json:"capacityCriticalThreshold,omitempty"`
+	HealingThresholdMinutes   *int32            `json:"healingThresholdMinutes,omitempty"`
+}
+
+// PrometheusRulesConfigApplyConfiguration constructs a declarative configuration of the PrometheusRulesConfig type for use with
+// apply.
+func PrometheusRulesConfig() *PrometheusRulesConfigApplyConfiguration {
+	return &PrometheusRulesConfigApplyConfiguration{}
+}
+
+// WithLabels puts the entries into the Labels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Labels field,
+// overwriting an existing map entries in Labels field with the same key.
+func (b *PrometheusRulesConfigApplyConfiguration) WithLabels(entries map[string]string) *PrometheusRulesConfigApplyConfiguration {
+	if b.Labels == nil && len(entries) > 0 {
+		b.Labels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Labels[k] = v
+	}
+	return b
+}
+
+// WithAlertLabels puts the entries into the AlertLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the AlertLabels field,
+// overwriting an existing map entries in AlertLabels field with the same key.
+func (b *PrometheusRulesConfigApplyConfiguration) WithAlertLabels(entries map[string]string) *PrometheusRulesConfigApplyConfiguration {
+	if b.AlertLabels == nil && len(entries) > 0 {
+		b.AlertLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.AlertLabels[k] = v
+	}
+	return b
+}
+
+// WithCapacityWarningThreshold sets the CapacityWarningThreshold field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CapacityWarningThreshold field is set to the value of the last call.
+func (b *PrometheusRulesConfigApplyConfiguration) WithCapacityWarningThreshold(value int32) *PrometheusRulesConfigApplyConfiguration {
+	b.CapacityWarningThreshold = &value
+	return b
+}
+
+// WithCapacityCriticalThreshold sets the CapacityCriticalThreshold field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CapacityCriticalThreshold field is set to the value of the last call.
+func (b *PrometheusRulesConfigApplyConfiguration) WithCapacityCriticalThreshold(value int32) *PrometheusRulesConfigApplyConfiguration {
+	b.CapacityCriticalThreshold = &value
+	return b
+}
+
+// WithHealingThresholdMinutes sets the HealingThresholdMinutes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HealingThresholdMinutes field is set to the value of the last call.
+func (b *PrometheusRulesConfigApplyConfiguration) WithHealingThresholdMinutes(value int32) *PrometheusRulesConfigApplyConfiguration {
+	b.HealingThresholdMinutes = &value
+	return b
+}
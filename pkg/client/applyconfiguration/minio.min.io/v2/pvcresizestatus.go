@@ -0,0 +1,75 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+// PVCResizeStatusApplyConfiguration represents a declarative configuration of the PVCResizeStatus type for use
+// with apply.
+type PVCResizeStatusApplyConfiguration struct {
+	Name      *string `json:"name,omitempty"`
+	Requested *string `json:"requested,omitempty"`
+	Capacity  *string `json:"capacity,omitempty"`
+	Condition *string `json:"condition,omitempty"`
+	Message   *string `json:"message,omitempty"`
+}
+
+// PVCResizeStatusApplyConfiguration constructs a declarative configuration of the PVCResizeStatus type for use with
+// apply.
+func PVCResizeStatus() *PVCResizeStatusApplyConfiguration {
+	return &PVCResizeStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *PVCResizeStatusApplyConfiguration) WithName(value string) *PVCResizeStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithRequested sets the Requested field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Requested field is set to the value of the last call.
+func (b *PVCResizeStatusApplyConfiguration) WithRequested(value string) *PVCResizeStatusApplyConfiguration {
+	b.Requested = &value
+	return b
+}
+
+// WithCapacity sets the Capacity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Capacity field is set to the value of the last call.
+func (b *PVCResizeStatusApplyConfiguration) WithCapacity(value string) *PVCResizeStatusApplyConfiguration {
+	b.Capacity = &value
+	return b
+}
+
+// WithCondition sets the Condition field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Condition field is set to the value of the last call.
+func (b *PVCResizeStatusApplyConfiguration) WithCondition(value string) *PVCResizeStatusApplyConfiguration {
+	b.Condition = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *PVCResizeStatusApplyConfiguration) WithMessage(value string) *PVCResizeStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
@@ -0,0 +1,98 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+import (
+	miniominiov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RootCredentialsStatusApplyConfiguration represents a declarative configuration of the RootCredentialsStatus type for use
+// with apply.
+type RootCredentialsStatusApplyConfiguration struct {
+	Phase            *miniominiov2.RootCredentialsPhase `json:"phase,omitempty"`
+	RotationRequest  *string                            `json:"rotationRequest,omitempty"`
+	StartedAt        *v1.Time                           `json:"startedAt,omitempty"`
+	RestartedAt      *v1.Time                           `json:"restartedAt,omitempty"`
+	CredentialsHash  *string                            `json:"credentialsHash,omitempty"`
+	LastRotationTime *v1.Time                           `json:"lastRotationTime,omitempty"`
+	Message          *string                            `json:"message,omitempty"`
+}
+
+// RootCredentialsStatusApplyConfiguration constructs a declarative configuration of the RootCredentialsStatus type for use with
+// apply.
+func RootCredentialsStatus() *RootCredentialsStatusApplyConfiguration {
+	return &RootCredentialsStatusApplyConfiguration{}
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *RootCredentialsStatusApplyConfiguration) WithPhase(value miniominiov2.RootCredentialsPhase) *RootCredentialsStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithRotationRequest sets the RotationRequest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RotationRequest field is set to the value of the last call.
+func (b *RootCredentialsStatusApplyConfiguration) WithRotationRequest(value string) *RootCredentialsStatusApplyConfiguration {
+	b.RotationRequest = &value
+	return b
+}
+
+// WithStartedAt sets the StartedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartedAt field is set to the value of the last call.
+func (b *RootCredentialsStatusApplyConfiguration) WithStartedAt(value v1.Time) *RootCredentialsStatusApplyConfiguration {
+	b.StartedAt = &value
+	return b
+}
+
+// WithRestartedAt sets the RestartedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RestartedAt field is set to the value of the last call.
+func (b *RootCredentialsStatusApplyConfiguration) WithRestartedAt(value v1.Time) *RootCredentialsStatusApplyConfiguration {
+	b.RestartedAt = &value
+	return b
+}
+
+// WithCredentialsHash sets the CredentialsHash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CredentialsHash field is set to the value of the last call.
+func (b *RootCredentialsStatusApplyConfiguration) WithCredentialsHash(value string) *RootCredentialsStatusApplyConfiguration {
+	b.CredentialsHash = &value
+	return b
+}
+
+// WithLastRotationTime sets the LastRotationTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastRotationTime field is set to the value of the last call.
+func (b *RootCredentialsStatusApplyConfiguration) WithLastRotationTime(value v1.Time) *RootCredentialsStatusApplyConfiguration {
+	b.LastRotationTime = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *RootCredentialsStatusApplyConfiguration) WithMessage(value string) *RootCredentialsStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
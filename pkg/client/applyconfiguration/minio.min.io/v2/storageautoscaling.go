@@ -0,0 +1,70 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+import (
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// StorageAutoscalingApplyConfiguration represents a declarative configuration of the StorageAutoscaling type for use
+// with apply.
+type StorageAutoscalingApplyConfiguration struct {
+	ThresholdPercent *int32             `json:"thresholdPercent,omitempty"`
+	Step             *resource.Quantity `json:"step,omitempty"`
+	MaxSize          *resource.Quantity `json:"maxSize,omitempty"`
+	CooldownMinutes  *int32             `json:"cooldownMinutes,omitempty"`
+}
+
+// StorageAutoscalingApplyConfiguration constructs a declarative configuration of the StorageAutoscaling type for use with
+// apply.
+func StorageAutoscaling() *StorageAutoscalingApplyConfiguration {
+	return &StorageAutoscalingApplyConfiguration{}
+}
+
+// WithThresholdPercent sets the ThresholdPercent field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ThresholdPercent field is set to the value of the last call.
+func (b *StorageAutoscalingApplyConfiguration) WithThresholdPercent(value int32) *StorageAutoscalingApplyConfiguration {
+	b.ThresholdPercent = &value
+	return b
+}
+
+// WithStep sets the Step field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Step field is set to the value of the last call.
+func (b *StorageAutoscalingApplyConfiguration) WithStep(value resource.Quantity) *StorageAutoscalingApplyConfiguration {
+	b.Step = &value
+	return b
+}
+
+// WithMaxSize sets the MaxSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxSize field is set to the value of the last call.
+func (b *StorageAutoscalingApplyConfiguration) WithMaxSize(value resource.Quantity) *StorageAutoscalingApplyConfiguration {
+	b.MaxSize = &value
+	return b
+}
+
+// WithCooldownMinutes sets the CooldownMinutes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CooldownMinutes field is set to the value of the last call.
+func (b *StorageAutoscalingApplyConfiguration) WithCooldownMinutes(value int32) *StorageAutoscalingApplyConfiguration {
+	b.CooldownMinutes = &value
+	return b
+}
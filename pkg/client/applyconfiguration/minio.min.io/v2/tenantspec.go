@@ -40,6 +40,7 @@ type TenantSpecApplyConfiguration struct {
 	Subpath                              *string                                      `json:"subPath,omitempty"`
 	RequestAutoCert                      *bool                                        `json:"requestAutoCert,omitempty"`
 	CertExpiryAlertThreshold             *int32                                       `json:"certExpiryAlertThreshold,omitempty"`
+	CABundle                             *CABundleConfigApplyConfiguration            `json:"caBundle,omitempty"`
 	Liveness                             *v1.Probe                                    `json:"liveness,omitempty"`
 	Readiness                            *v1.Probe                                    `json:"readiness,omitempty"`
 	Startup                              *v1.Probe                                    `json:"startup,omitempty"`
@@ -49,6 +50,12 @@ type TenantSpecApplyConfiguration struct {
 	KES                                  *KESConfigApplyConfiguration                 `json:"kes,omitempty"`
 	PrometheusOperator                   *bool                                        `json:"prometheusOperator,omitempty"`
 	PrometheusOperatorScrapeMetricsPaths []string                                     `json:"prometheusOperatorScrapeMetricsPaths,omitempty"`
+	PrometheusOperatorMode               *miniominiov2.PrometheusOperatorMode         `json:"prometheusOperatorMode,omitempty"`
+	PrometheusOperatorMonitorLabels      map[string]string                            `json:"prometheusOperatorMonitorLabels,omitempty"`
+	PrometheusRules                      *PrometheusRulesConfigApplyConfiguration     `json:"prometheusRules,omitempty"`
+	HealthCheckIntervalSeconds           *int32                                       `json:"healthCheckIntervalSeconds,omitempty"`
+	CapacityAlerts                       *CapacityAlertsConfigApplyConfiguration      `json:"capacityAlerts,omitempty"`
+	PoolTemplate                         *PoolTemplateApplyConfiguration              `json:"poolTemplate,omitempty"`
 	ServiceAccountName                   *string                                      `json:"serviceAccountName,omitempty"`
 	PriorityClassName                    *string                                      `json:"priorityClassName,omitempty"`
 	ImagePullPolicy                      *v1.PullPolicy                               `json:"imagePullPolicy,omitempty"`
@@ -197,6 +204,14 @@ func (b *TenantSpecApplyConfiguration) WithCertExpiryAlertThreshold(value int32)
 	return b
 }
 
+// WithCABundle sets the CABundle field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CABundle field is set to the value of the last call.
+func (b *TenantSpecApplyConfiguration) WithCABundle(value *CABundleConfigApplyConfiguration) *TenantSpecApplyConfiguration {
+	b.CABundle = value
+	return b
+}
+
 // WithLiveness sets the Liveness field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Liveness field is set to the value of the last call.
@@ -271,6 +286,60 @@ func (b *TenantSpecApplyConfiguration) WithPrometheusOperatorScrapeMetricsPaths(
 	return b
 }
 
+// WithPrometheusOperatorMode sets the PrometheusOperatorMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PrometheusOperatorMode field is set to the value of the last call.
+func (b *TenantSpecApplyConfiguration) WithPrometheusOperatorMode(value miniominiov2.PrometheusOperatorMode) *TenantSpecApplyConfiguration {
+	b.PrometheusOperatorMode = &value
+	return b
+}
+
+// WithPrometheusOperatorMonitorLabels puts the entries into the PrometheusOperatorMonitorLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PrometheusOperatorMonitorLabels field,
+// overwriting an existing map entries in PrometheusOperatorMonitorLabels field with the same key.
+func (b *TenantSpecApplyConfiguration) WithPrometheusOperatorMonitorLabels(entries map[string]string) *TenantSpecApplyConfiguration {
+	if b.PrometheusOperatorMonitorLabels == nil && len(entries) > 0 {
+		b.PrometheusOperatorMonitorLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PrometheusOperatorMonitorLabels[k] = v
+	}
+	return b
+}
+
+// WithPrometheusRules sets the PrometheusRules field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PrometheusRules field is set to the value of the last call.
+func (b *TenantSpecApplyConfiguration) WithPrometheusRules(value *PrometheusRulesConfigApplyConfiguration) *TenantSpecApplyConfiguration {
+	b.PrometheusRules = value
+	return b
+}
+
+// WithHealthCheckIntervalSeconds sets the HealthCheckIntervalSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HealthCheckIntervalSeconds field is set to the value of the last call.
+func (b *TenantSpecApplyConfiguration) WithHealthCheckIntervalSeconds(value int32) *TenantSpecApplyConfiguration {
+	b.HealthCheckIntervalSeconds = &value
+	return b
+}
+
+// WithCapacityAlerts sets the CapacityAlerts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CapacityAlerts field is set to the value of the last call.
+func (b *TenantSpecApplyConfiguration) WithCapacityAlerts(value *CapacityAlertsConfigApplyConfiguration) *TenantSpecApplyConfiguration {
+	b.CapacityAlerts = value
+	return b
+}
+
+// WithPoolTemplate sets the PoolTemplate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PoolTemplate field is set to the value of the last call.
+func (b *TenantSpecApplyConfiguration) WithPoolTemplate(value *PoolTemplateApplyConfiguration) *TenantSpecApplyConfiguration {
+	b.PoolTemplate = value
+	return b
+}
+
 // WithServiceAccountName sets the ServiceAccountName field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ServiceAccountName field is set to the value of the last call.
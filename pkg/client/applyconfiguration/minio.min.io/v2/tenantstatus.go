@@ -21,27 +21,35 @@ package v2
 import (
 	miniominiov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
 // TenantStatusApplyConfiguration represents a declarative configuration of the TenantStatus type for use
 // with apply.
 type TenantStatusApplyConfiguration struct {
-	CurrentState       *string                              `json:"currentState,omitempty"`
-	AvailableReplicas  *int32                               `json:"availableReplicas,omitempty"`
-	Revision           *int32                               `json:"revision,omitempty"`
-	SyncVersion        *string                              `json:"syncVersion,omitempty"`
-	Certificates       *CertificateStatusApplyConfiguration `json:"certificates,omitempty"`
-	Pools              []PoolStatusApplyConfiguration       `json:"pools,omitempty"`
-	WriteQuorum        *int32                               `json:"writeQuorum,omitempty"`
-	DrivesOnline       *int32                               `json:"drivesOnline,omitempty"`
-	DrivesOffline      *int32                               `json:"drivesOffline,omitempty"`
-	DrivesHealing      *int32                               `json:"drivesHealing,omitempty"`
-	HealthStatus       *miniominiov2.HealthStatus           `json:"healthStatus,omitempty"`
-	HealthMessage      *string                              `json:"healthMessage,omitempty"`
-	WaitingOnReady     *v1.Time                             `json:"waitingOnReady,omitempty"`
-	Usage              *TenantUsageApplyConfiguration       `json:"usage,omitempty"`
-	ProvisionedUsers   *bool                                `json:"provisionedUsers,omitempty"`
-	ProvisionedBuckets *bool                                `json:"provisionedBuckets,omitempty"`
+	CurrentState         *string                                  `json:"currentState,omitempty"`
+	AvailableReplicas    *int32                                   `json:"availableReplicas,omitempty"`
+	Revision             *int32                                   `json:"revision,omitempty"`
+	SyncVersion          *string                                  `json:"syncVersion,omitempty"`
+	Certificates         *CertificateStatusApplyConfiguration     `json:"certificates,omitempty"`
+	Pools                []PoolStatusApplyConfiguration           `json:"pools,omitempty"`
+	WriteQuorum          *int32                                   `json:"writeQuorum,omitempty"`
+	DrivesOnline         *int32                                   `json:"drivesOnline,omitempty"`
+	DrivesOffline        *int32                                   `json:"drivesOffline,omitempty"`
+	DrivesHealing        *int32                                   `json:"drivesHealing,omitempty"`
+	HealthStatus         *miniominiov2.HealthStatus               `json:"healthStatus,omitempty"`
+	HealthMessage        *string                                  `json:"healthMessage,omitempty"`
+	HealthStaleSince     *v1.Time                                 `json:"healthStaleSince,omitempty"`
+	WaitingOnReady       *v1.Time                                 `json:"waitingOnReady,omitempty"`
+	Usage                *TenantUsageApplyConfiguration           `json:"usage,omitempty"`
+	UnhealthyDrives      []UnhealthyDriveApplyConfiguration       `json:"unhealthyDrives,omitempty"`
+	PendingPoolExpansion *string                                  `json:"pendingPoolExpansion,omitempty"`
+	DriveReplacements    []DriveReplacementApplyConfiguration     `json:"driveReplacements,omitempty"`
+	RootCredentials      *RootCredentialsStatusApplyConfiguration `json:"rootCredentials,omitempty"`
+	CABundle             *CABundleStatusApplyConfiguration        `json:"caBundle,omitempty"`
+	Conditions           []metav1.ConditionApplyConfiguration     `json:"conditions,omitempty"`
+	ProvisionedUsers     *bool                                    `json:"provisionedUsers,omitempty"`
+	ProvisionedBuckets   *bool                                    `json:"provisionedBuckets,omitempty"`
 }
 
 // TenantStatusApplyConfiguration constructs a declarative configuration of the TenantStatus type for use with
@@ -151,6 +159,14 @@ func (b *TenantStatusApplyConfiguration) WithHealthMessage(value string) *Tenant
 	return b
 }
 
+// WithHealthStaleSince sets the HealthStaleSince field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HealthStaleSince field is set to the value of the last call.
+func (b *TenantStatusApplyConfiguration) WithHealthStaleSince(value v1.Time) *TenantStatusApplyConfiguration {
+	b.HealthStaleSince = &value
+	return b
+}
+
 // WithWaitingOnReady sets the WaitingOnReady field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the WaitingOnReady field is set to the value of the last call.
@@ -167,6 +183,69 @@ func (b *TenantStatusApplyConfiguration) WithUsage(value *TenantUsageApplyConfig
 	return b
 }
 
+// WithUnhealthyDrives adds the given value to the UnhealthyDrives field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the UnhealthyDrives field.
+func (b *TenantStatusApplyConfiguration) WithUnhealthyDrives(values ...*UnhealthyDriveApplyConfiguration) *TenantStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithUnhealthyDrives")
+		}
+		b.UnhealthyDrives = append(b.UnhealthyDrives, *values[i])
+	}
+	return b
+}
+
+// WithPendingPoolExpansion sets the PendingPoolExpansion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PendingPoolExpansion field is set to the value of the last call.
+func (b *TenantStatusApplyConfiguration) WithPendingPoolExpansion(value string) *TenantStatusApplyConfiguration {
+	b.PendingPoolExpansion = &value
+	return b
+}
+
+// WithDriveReplacements adds the given value to the DriveReplacements field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DriveReplacements field.
+func (b *TenantStatusApplyConfiguration) WithDriveReplacements(values ...*DriveReplacementApplyConfiguration) *TenantStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDriveReplacements")
+		}
+		b.DriveReplacements = append(b.DriveReplacements, *values[i])
+	}
+	return b
+}
+
+// WithRootCredentials sets the RootCredentials field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RootCredentials field is set to the value of the last call.
+func (b *TenantStatusApplyConfiguration) WithRootCredentials(value *RootCredentialsStatusApplyConfiguration) *TenantStatusApplyConfiguration {
+	b.RootCredentials = value
+	return b
+}
+
+// WithCABundle sets the CABundle field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CABundle field is set to the value of the last call.
+func (b *TenantStatusApplyConfiguration) WithCABundle(value *CABundleStatusApplyConfiguration) *TenantStatusApplyConfiguration {
+	b.CABundle = value
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *TenantStatusApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *TenantStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
 // WithProvisionedUsers sets the ProvisionedUsers field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ProvisionedUsers field is set to the value of the last call.
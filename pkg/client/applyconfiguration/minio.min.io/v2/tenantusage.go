@@ -21,11 +21,13 @@ package v2
 // TenantUsageApplyConfiguration represents a declarative configuration of the TenantUsage type for use
 // with apply.
 type TenantUsageApplyConfiguration struct {
-	Capacity    *int64                        `json:"capacity,omitempty"`
-	RawCapacity *int64                        `json:"rawCapacity,omitempty"`
-	Usage       *int64                        `json:"usage,omitempty"`
-	RawUsage    *int64                        `json:"rawUsage,omitempty"`
-	Tiers       []TierUsageApplyConfiguration `json:"tiers,omitempty"`
+	Capacity      *int64                        `json:"capacity,omitempty"`
+	RawCapacity   *int64                        `json:"rawCapacity,omitempty"`
+	Usage         *int64                        `json:"usage,omitempty"`
+	RawUsage      *int64                        `json:"rawUsage,omitempty"`
+	Tiers         []TierUsageApplyConfiguration `json:"tiers,omitempty"`
+	GrowthPerDay  *int64                        `json:"growthPerDay,omitempty"`
+	DaysUntilFull *int32                        `json:"daysUntilFull,omitempty"`
 }
 
 // TenantUsageApplyConfiguration constructs a declarative configuration of the TenantUsage type for use with
@@ -78,3 +80,19 @@ func (b *TenantUsageApplyConfiguration) WithTiers(values ...*TierUsageApplyConfi
 	}
 	return b
 }
+
+// WithGrowthPerDay sets the GrowthPerDay field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GrowthPerDay field is set to the value of the last call.
+func (b *TenantUsageApplyConfiguration) WithGrowthPerDay(value int64) *TenantUsageApplyConfiguration {
+	b.GrowthPerDay = &value
+	return b
+}
+
+// WithDaysUntilFull sets the DaysUntilFull field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DaysUntilFull field is set to the value of the last call.
+func (b *TenantUsageApplyConfiguration) WithDaysUntilFull(value int32) *TenantUsageApplyConfiguration {
+	b.DaysUntilFull = &value
+	return b
+}
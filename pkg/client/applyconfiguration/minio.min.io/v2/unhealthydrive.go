@@ -0,0 +1,75 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+// UnhealthyDriveApplyConfiguration represents a declarative configuration of the UnhealthyDrive type for use
+// with apply.
+type UnhealthyDriveApplyConfiguration struct {
+	Pool  *string `json:"pool,omitempty"`
+	Pod   *string `json:"pod,omitempty"`
+	Path  *string `json:"path,omitempty"`
+	PVC   *string `json:"pvc,omitempty"`
+	State *string `json:"state,omitempty"`
+}
+
+// UnhealthyDriveApplyConfiguration constructs a declarative configuration of the UnhealthyDrive type for use with
+// apply.
+func UnhealthyDrive() *UnhealthyDriveApplyConfiguration {
+	return &UnhealthyDriveApplyConfiguration{}
+}
+
+// WithPool sets the Pool field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Pool field is set to the value of the last call.
+func (b *UnhealthyDriveApplyConfiguration) WithPool(value string) *UnhealthyDriveApplyConfiguration {
+	b.Pool = &value
+	return b
+}
+
+// WithPod sets the Pod field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Pod field is set to the value of the last call.
+func (b *UnhealthyDriveApplyConfiguration) WithPod(value string) *UnhealthyDriveApplyConfiguration {
+	b.Pod = &value
+	return b
+}
+
+// WithPath sets the Path field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Path field is set to the value of the last call.
+func (b *UnhealthyDriveApplyConfiguration) WithPath(value string) *UnhealthyDriveApplyConfiguration {
+	b.Path = &value
+	return b
+}
+
+// WithPVC sets the PVC field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PVC field is set to the value of the last call.
+func (b *UnhealthyDriveApplyConfiguration) WithPVC(value string) *UnhealthyDriveApplyConfiguration {
+	b.PVC = &value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *UnhealthyDriveApplyConfiguration) WithState(value string) *UnhealthyDriveApplyConfiguration {
+	b.State = &value
+	return b
+}
@@ -0,0 +1,48 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2024 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// CertificateIdentityApplyConfiguration represents a declarative configuration of the CertificateIdentity type for use
+// with apply.
+type CertificateIdentityApplyConfiguration struct {
+	CommonName *string `json:"commonName,omitempty"`
+	SAN        *string `json:"san,omitempty"`
+}
+
+// CertificateIdentityApplyConfiguration constructs a declarative configuration of the CertificateIdentity type for use with
+// apply.
+func CertificateIdentity() *CertificateIdentityApplyConfiguration {
+	return &CertificateIdentityApplyConfiguration{}
+}
+
+// WithCommonName sets the CommonName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CommonName field is set to the value of the last call.
+func (b *CertificateIdentityApplyConfiguration) WithCommonName(value string) *CertificateIdentityApplyConfiguration {
+	b.CommonName = &value
+	return b
+}
+
+// WithSAN sets the SAN field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SAN field is set to the value of the last call.
+func (b *CertificateIdentityApplyConfiguration) WithSAN(value string) *CertificateIdentityApplyConfiguration {
+	b.SAN = &value
+	return b
+}
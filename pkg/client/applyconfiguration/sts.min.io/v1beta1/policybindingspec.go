@@ -21,8 +21,9 @@ package v1beta1
 // PolicyBindingSpecApplyConfiguration represents a declarative configuration of the PolicyBindingSpec type for use
 // with apply.
 type PolicyBindingSpecApplyConfiguration struct {
-	Application *ApplicationApplyConfiguration `json:"application,omitempty"`
-	Policies    []string                       `json:"policies,omitempty"`
+	Application *ApplicationApplyConfiguration         `json:"application,omitempty"`
+	Certificate *CertificateIdentityApplyConfiguration `json:"certificate,omitempty"`
+	Policies    []string                               `json:"policies,omitempty"`
 }
 
 // PolicyBindingSpecApplyConfiguration constructs a declarative configuration of the PolicyBindingSpec type for use with
@@ -39,6 +40,14 @@ func (b *PolicyBindingSpecApplyConfiguration) WithApplication(value *Application
 	return b
 }
 
+// WithCertificate sets the Certificate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Certificate field is set to the value of the last call.
+func (b *PolicyBindingSpecApplyConfiguration) WithCertificate(value *CertificateIdentityApplyConfiguration) *PolicyBindingSpecApplyConfiguration {
+	b.Certificate = value
+	return b
+}
+
 // WithPolicies adds the given value to the Policies field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Policies field.
@@ -38,14 +38,28 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 	// Group=minio.min.io, Version=v2
 	case v2.SchemeGroupVersion.WithKind("Bucket"):
 		return &miniominiov2.BucketApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("CABundleConfig"):
+		return &miniominiov2.CABundleConfigApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("CABundleStatus"):
+		return &miniominiov2.CABundleStatusApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("CapacityAlertsConfig"):
+		return &miniominiov2.CapacityAlertsConfigApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("CertificateConfig"):
 		return &miniominiov2.CertificateConfigApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("CertificateIssuerRef"):
+		return &miniominiov2.CertificateIssuerRefApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("CertificateStatus"):
 		return &miniominiov2.CertificateStatusApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("CertificateSubject"):
+		return &miniominiov2.CertificateSubjectApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("CustomCertificateConfig"):
 		return &miniominiov2.CustomCertificateConfigApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("CustomCertificates"):
 		return &miniominiov2.CustomCertificatesApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("DriveReplacement"):
+		return &miniominiov2.DriveReplacementApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("ErasureSetStatus"):
+		return &miniominiov2.ErasureSetStatusApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("ExposeServices"):
 		return &miniominiov2.ExposeServicesApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("Features"):
@@ -56,16 +70,32 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &miniominiov2.LocalCertificateReferenceApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("Logging"):
 		return &miniominiov2.LoggingApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("NodeFailureRecovery"):
+		return &miniominiov2.NodeFailureRecoveryApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("PodDisruptionBudgetConfig"):
+		return &miniominiov2.PodDisruptionBudgetConfigApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("Pool"):
 		return &miniominiov2.PoolApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("PoolPlacement"):
+		return &miniominiov2.PoolPlacementApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("PoolsMetadata"):
 		return &miniominiov2.PoolsMetadataApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("PoolStatus"):
 		return &miniominiov2.PoolStatusApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("PoolTemplate"):
+		return &miniominiov2.PoolTemplateApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("PrometheusRulesConfig"):
+		return &miniominiov2.PrometheusRulesConfigApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("PVCResizeStatus"):
+		return &miniominiov2.PVCResizeStatusApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("RootCredentialsStatus"):
+		return &miniominiov2.RootCredentialsStatusApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("ServiceMetadata"):
 		return &miniominiov2.ServiceMetadataApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("SideCars"):
 		return &miniominiov2.SideCarsApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("StorageAutoscaling"):
+		return &miniominiov2.StorageAutoscalingApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("Tenant"):
 		return &miniominiov2.TenantApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("TenantDomains"):
@@ -80,6 +110,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &miniominiov2.TenantUsageApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("TierUsage"):
 		return &miniominiov2.TierUsageApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("UnhealthyDrive"):
+		return &miniominiov2.UnhealthyDriveApplyConfiguration{}
 
 		// Group=sts.min.io, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithKind("Application"):
@@ -96,6 +128,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		// Group=sts.min.io, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithKind("Application"):
 		return &stsminiov1beta1.ApplicationApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("CertificateIdentity"):
+		return &stsminiov1beta1.CertificateIdentityApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PolicyBinding"):
 		return &stsminiov1beta1.PolicyBindingApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PolicyBindingSpec"):
@@ -18,12 +18,17 @@ package common
 
 // Constants for the webhook endpoints
 const (
-	WebhookAPIVersion        = "/webhook/v1"
-	UpgradeServerPort        = "4221"
-	WebhookDefaultPort       = "4222"
-	WebhookAPIBucketService  = WebhookAPIVersion + "/bucketsrv"
-	WebhookAPIUpdate         = WebhookAPIVersion + "/update"
-	SidecarHTTPPort          = "4224"
-	SidecarAPIVersion        = "/sidecar/v1"
-	SidecarAPIConfigEndpoint = SidecarAPIVersion + "/config"
+	WebhookAPIVersion                 = "/webhook/v1"
+	UpgradeServerPort                 = "4221"
+	WebhookDefaultPort                = "4222"
+	WebhookAPIBucketService           = WebhookAPIVersion + "/bucketsrv"
+	WebhookAPIUpdate                  = WebhookAPIVersion + "/update"
+	WebhookAPISTSInjection            = WebhookAPIVersion + "/sts-injection"
+	SidecarHTTPPort                   = "4224"
+	MetricsServerPort                 = "4225"
+	MetricsEndpoint                   = "/metrics"
+	TrustDebugEndpoint                = "/debug/trust"
+	SidecarAPIVersion                 = "/sidecar/v1"
+	SidecarAPIConfigEndpoint          = SidecarAPIVersion + "/config"
+	SidecarAPIRootCredentialsEndpoint = SidecarAPIVersion + "/root-credentials"
 )
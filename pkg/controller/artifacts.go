@@ -24,6 +24,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/docker/cli/cli/config/configfile"
 
@@ -94,6 +95,15 @@ func (c *Controller) getKeychainForTenant(ctx context.Context, ref name.Referenc
 // Attempts to fetch given image and then extracts and keeps relevant files
 // (minio, minio.sha256sum & minio.minisig) at a pre-defined location (/tmp/webhook/v1/update)
 func (c *Controller) fetchArtifacts(tenant *miniov2.Tenant) (latest string, err error) {
+	fetchStart := time.Now()
+	defer func() {
+		result := "success"
+		if err != nil {
+			result = "error"
+		}
+		artifactFetchDuration.WithLabelValues(result).Observe(time.Since(fetchStart).Seconds())
+	}()
+
 	c.removeArtifacts() // remove before a fresh fetch.
 
 	basePath := updatePath
@@ -175,6 +185,9 @@ func (c *Controller) fetchArtifacts(tenant *miniov2.Tenant) (latest string, err
 		f.Close()
 		return latest, err
 	}
+	if fi, err := f.Stat(); err == nil {
+		artifactFetchBytes.Add(float64(fi.Size()))
+	}
 
 	if err = f.Close(); err != nil {
 		return latest, err
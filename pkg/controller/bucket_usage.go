@@ -0,0 +1,299 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+const (
+	// BucketUsageReportKey is the key of the gzip compressed bucket usage report in the ConfigMaps of the tenant
+	BucketUsageReportKey = "report.json.gz"
+	// BucketUsageReportFailedReason is the event reason recorded when the bucket usage report can't be stored
+	BucketUsageReportFailedReason = "BucketUsageReportFailed"
+
+	// bucketUsageShardsKey is the number of ConfigMaps the report is split across
+	bucketUsageShardsKey = "shards"
+	// bucketUsageChecksumKey is the SHA-256 of the compressed report, it detects a report read while being updated
+	bucketUsageChecksumKey = "sha256"
+	// bucketUsageShardSize keeps every ConfigMap well below the 1 MiB limit of Kubernetes objects once base64 encoded
+	bucketUsageShardSize = 512 * 1024
+)
+
+// BucketUsage is the usage of a bucket as last scanned by MinIO
+type BucketUsage struct {
+	Name                    string `json:"name"`
+	Size                    uint64 `json:"size"`
+	Objects                 uint64 `json:"objects"`
+	Versions                uint64 `json:"versions"`
+	DeleteMarkers           uint64 `json:"deleteMarkers"`
+	ReplicationPendingSize  uint64 `json:"replicationPendingSize"`
+	ReplicationPendingCount uint64 `json:"replicationPendingCount"`
+	ReplicationFailedSize   uint64 `json:"replicationFailedSize"`
+	ReplicationFailedCount  uint64 `json:"replicationFailedCount"`
+	ReplicatedSize          uint64 `json:"replicatedSize"`
+}
+
+// TierUsageReport is the usage of a remote tier as last scanned by MinIO
+type TierUsageReport struct {
+	Name     string `json:"name"`
+	Size     uint64 `json:"size"`
+	Objects  int    `json:"objects"`
+	Versions int    `json:"versions"`
+}
+
+// BucketUsageReport is the per bucket and per tier usage of a tenant, meant for chargeback
+type BucketUsageReport struct {
+	Namespace string `json:"namespace"`
+	Tenant    string `json:"tenant"`
+	// ScannedAt is the last time MinIO updated the usage
+	ScannedAt time.Time         `json:"scannedAt"`
+	Buckets   []BucketUsage     `json:"buckets"`
+	Tiers     []TierUsageReport `json:"tiers,omitempty"`
+}
+
+// newBucketUsageReport builds the usage report of a tenant from the data usage reported by MinIO, buckets and tiers
+// are sorted by name so the report only changes with the usage
+func newBucketUsageReport(tenant *miniov2.Tenant, dataUsage madmin.DataUsageInfo) *BucketUsageReport {
+	report := &BucketUsageReport{
+		Namespace: tenant.Namespace,
+		Tenant:    tenant.Name,
+		ScannedAt: dataUsage.LastUpdate.UTC(),
+		Buckets:   make([]BucketUsage, 0, len(dataUsage.BucketsUsage)),
+	}
+	for name, usage := range dataUsage.BucketsUsage {
+		report.Buckets = append(report.Buckets, BucketUsage{
+			Name:                    name,
+			Size:                    usage.Size,
+			Objects:                 usage.ObjectsCount,
+			Versions:                usage.VersionsCount,
+			DeleteMarkers:           usage.DeleteMarkersCount,
+			ReplicationPendingSize:  usage.ReplicationPendingSize,
+			ReplicationPendingCount: usage.ReplicationPendingCount,
+			ReplicationFailedSize:   usage.ReplicationFailedSize,
+			ReplicationFailedCount:  usage.ReplicationFailedCount,
+			ReplicatedSize:          usage.ReplicatedSize,
+		})
+	}
+	sort.Slice(report.Buckets, func(i, j int) bool { return report.Buckets[i].Name < report.Buckets[j].Name })
+	for name, stats := range dataUsage.TierStats {
+		report.Tiers = append(report.Tiers, TierUsageReport{
+			Name:     name,
+			Size:     stats.TotalSize,
+			Objects:  stats.NumObjects,
+			Versions: stats.NumVersions,
+		})
+	}
+	sort.Slice(report.Tiers, func(i, j int) bool { return report.Tiers[i].Name < report.Tiers[j].Name })
+	return report
+}
+
+// WriteBucketUsageCSV writes the reports as CSV, one row per bucket followed by one row per remote tier. The rows of
+// the tiers leave the bucket and the columns that only apply to buckets empty.
+func WriteBucketUsageCSV(w io.Writer, reports []*BucketUsageReport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{
+		"namespace", "tenant", "scannedAt", "bucket", "tier", "size", "objects", "versions", "deleteMarkers",
+		"replicationPendingSize", "replicationPendingCount", "replicationFailedSize", "replicationFailedCount", "replicatedSize",
+	}); err != nil {
+		return err
+	}
+	for _, report := range reports {
+		scannedAt := report.ScannedAt.Format(time.RFC3339)
+		for _, bucket := range report.Buckets {
+			if err := writer.Write([]string{
+				report.Namespace,
+				report.Tenant,
+				scannedAt,
+				bucket.Name,
+				"",
+				strconv.FormatUint(bucket.Size, 10),
+				strconv.FormatUint(bucket.Objects, 10),
+				strconv.FormatUint(bucket.Versions, 10),
+				strconv.FormatUint(bucket.DeleteMarkers, 10),
+				strconv.FormatUint(bucket.ReplicationPendingSize, 10),
+				strconv.FormatUint(bucket.ReplicationPendingCount, 10),
+				strconv.FormatUint(bucket.ReplicationFailedSize, 10),
+				strconv.FormatUint(bucket.ReplicationFailedCount, 10),
+				strconv.FormatUint(bucket.ReplicatedSize, 10),
+			}); err != nil {
+				return err
+			}
+		}
+		for _, tier := range report.Tiers {
+			if err := writer.Write([]string{
+				report.Namespace,
+				report.Tenant,
+				scannedAt,
+				"",
+				tier.Name,
+				strconv.FormatUint(tier.Size, 10),
+				strconv.Itoa(tier.Objects),
+				strconv.Itoa(tier.Versions),
+				"", "", "", "", "", "",
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// encodeBucketUsageReport compresses the report and splits it in shards small enough for a ConfigMap
+func encodeBucketUsageReport(report *BucketUsageReport) ([][]byte, string, error) {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, "", err
+	}
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err = gz.Write(data); err != nil {
+		return nil, "", err
+	}
+	if err = gz.Close(); err != nil {
+		return nil, "", err
+	}
+	payload := buf.Bytes()
+	checksum := sha256.Sum256(payload)
+	shards := [][]byte{}
+	for len(payload) > bucketUsageShardSize {
+		shards = append(shards, payload[:bucketUsageShardSize])
+		payload = payload[bucketUsageShardSize:]
+	}
+	return append(shards, payload), hex.EncodeToString(checksum[:]), nil
+}
+
+// updateBucketUsageReport stores the bucket usage report of the tenant compressed in its ConfigMaps, the report is
+// split across `<tenant>-bucket-usage` and `<tenant>-bucket-usage-<index>` ConfigMaps when it doesn't fit in one. The
+// ConfigMaps are only updated when MinIO scanned the usage again.
+func (c *Controller) updateBucketUsageReport(ctx context.Context, tenant *miniov2.Tenant, dataUsage madmin.DataUsageInfo) error {
+	shards, checksum, err := encodeBucketUsageReport(newBucketUsageReport(tenant, dataUsage))
+	if err != nil {
+		return err
+	}
+	configMap, err := c.kubeClientSet.CoreV1().ConfigMaps(tenant.Namespace).Get(ctx, tenant.BucketUsageConfigMapName(), metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && configMap.Data[bucketUsageChecksumKey] == checksum {
+		return nil
+	}
+
+	// the first ConfigMap records the checksum of the whole report, it is written last so a report is never read
+	// from shards of different scans
+	for index := len(shards) - 1; index >= 0; index-- {
+		var data map[string]string
+		if index == 0 {
+			data = map[string]string{
+				bucketUsageShardsKey:   strconv.Itoa(len(shards)),
+				bucketUsageChecksumKey: checksum,
+			}
+		}
+		if err = c.applyBucketUsageShard(ctx, tenant, index, shards[index], data); err != nil {
+			return err
+		}
+	}
+	// drop the shards left from a larger report
+	for index := len(shards); ; index++ {
+		err = c.kubeClientSet.CoreV1().ConfigMaps(tenant.Namespace).Delete(ctx, tenant.BucketUsageShardConfigMapName(index), metav1.DeleteOptions{})
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// applyBucketUsageShard creates or updates a ConfigMap holding a shard of the compressed report
+func (c *Controller) applyBucketUsageShard(ctx context.Context, tenant *miniov2.Tenant, index int, shard []byte, data map[string]string) error {
+	name := tenant.BucketUsageShardConfigMapName(index)
+	binaryData := map[string][]byte{BucketUsageReportKey: shard}
+	configMap, err := c.kubeClientSet.CoreV1().ConfigMaps(tenant.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = c.kubeClientSet.CoreV1().ConfigMaps(tenant.Namespace).Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       tenant.Namespace,
+				Labels:          map[string]string{miniov2.TenantLabel: tenant.Name},
+				OwnerReferences: tenant.OwnerRef(),
+			},
+			Data:       data,
+			BinaryData: binaryData,
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	configMap.Data = data
+	configMap.BinaryData = binaryData
+	_, err = c.kubeClientSet.CoreV1().ConfigMaps(tenant.Namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	return err
+}
+
+// ReadBucketUsageReport reads the bucket usage report of a tenant from its first ConfigMap and the shards it references
+func ReadBucketUsageReport(ctx context.Context, kubeClient kubernetes.Interface, configMap *corev1.ConfigMap) (*BucketUsageReport, error) {
+	tenant := miniov2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: configMap.Labels[miniov2.TenantLabel], Namespace: configMap.Namespace}}
+	shards, err := strconv.Atoi(configMap.Data[bucketUsageShardsKey])
+	if err != nil || shards < 1 {
+		return nil, fmt.Errorf("invalid number of shards %q", configMap.Data[bucketUsageShardsKey])
+	}
+	payload := append([]byte{}, configMap.BinaryData[BucketUsageReportKey]...)
+	for index := 1; index < shards; index++ {
+		shard, err := kubeClient.CoreV1().ConfigMaps(tenant.Namespace).Get(ctx, tenant.BucketUsageShardConfigMapName(index), metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, shard.BinaryData[BucketUsageReportKey]...)
+	}
+	if checksum := sha256.Sum256(payload); hex.EncodeToString(checksum[:]) != configMap.Data[bucketUsageChecksumKey] {
+		return nil, errors.New("the report is being updated, try again")
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	report := &BucketUsageReport{}
+	if err = json.NewDecoder(gz).Decode(report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
@@ -0,0 +1,152 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+func Test_updateBucketUsageReport(t *testing.T) {
+	ctx := context.Background()
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+		},
+	}
+	dataUsage := madmin.DataUsageInfo{
+		LastUpdate: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		BucketsUsage: map[string]madmin.BucketUsageInfo{
+			"logs":   {Size: 2048, ObjectsCount: 10, VersionsCount: 12, ReplicationPendingSize: 512, ReplicationPendingCount: 2},
+			"assets": {Size: 1024, ObjectsCount: 5, VersionsCount: 5},
+		},
+		TierStats: map[string]madmin.TierStats{
+			"GLACIER": {TotalSize: 4096, NumObjects: 3, NumVersions: 3},
+		},
+	}
+	controller := Controller{kubeClientSet: fake.NewSimpleClientset()}
+	if err := controller.updateBucketUsageReport(ctx, tenant, dataUsage); err != nil {
+		t.Fatal(err)
+	}
+	configMap, err := controller.kubeClientSet.CoreV1().ConfigMaps("ns").Get(ctx, "myminio-bucket-usage", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := ReadBucketUsageReport(ctx, controller.kubeClientSet, configMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Buckets) != 2 || report.Buckets[0].Name != "assets" || report.Buckets[1].ReplicationPendingSize != 512 {
+		t.Errorf("buckets = %+v, want assets and logs sorted by name", report.Buckets)
+	}
+	if len(report.Tiers) != 1 || report.Tiers[0].Size != 4096 {
+		t.Errorf("tiers = %+v, want GLACIER", report.Tiers)
+	}
+
+	var csv bytes.Buffer
+	if err := WriteBucketUsageCSV(&csv, []*BucketUsageReport{report}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	if len(lines) != 4 || lines[2] != "ns,myminio,2025-01-02T03:04:05Z,logs,,2048,10,12,0,512,2,0,0,0" {
+		t.Errorf("csv = %q", csv.String())
+	}
+	// the tiers follow the buckets of the tenant
+	if len(lines) == 4 && lines[3] != "ns,myminio,2025-01-02T03:04:05Z,,GLACIER,4096,3,3,,,,,," {
+		t.Errorf("tier row = %q", lines[3])
+	}
+}
+
+func Test_updateBucketUsageReportShards(t *testing.T) {
+	ctx := context.Background()
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+		},
+	}
+	// bucket names that don't compress so the report is larger than a ConfigMap
+	dataUsage := madmin.DataUsageInfo{BucketsUsage: map[string]madmin.BucketUsageInfo{}}
+	for i := 0; i < 40000; i++ {
+		name := sha256.Sum256([]byte{byte(i), byte(i >> 8), byte(i >> 16)})
+		dataUsage.BucketsUsage[hex.EncodeToString(name[:])] = madmin.BucketUsageInfo{Size: uint64(i)}
+	}
+	controller := Controller{kubeClientSet: fake.NewSimpleClientset()}
+	if err := controller.updateBucketUsageReport(ctx, tenant, dataUsage); err != nil {
+		t.Fatal(err)
+	}
+	configMaps, err := controller.kubeClientSet.CoreV1().ConfigMaps("ns").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configMaps.Items) < 2 {
+		t.Fatalf("%d ConfigMaps, want the report split across several", len(configMaps.Items))
+	}
+	for _, configMap := range configMaps.Items {
+		if size := len(configMap.BinaryData[BucketUsageReportKey]); size > bucketUsageShardSize {
+			t.Errorf("ConfigMap %s holds %d bytes, more than a shard", configMap.Name, size)
+		}
+	}
+	configMap, err := controller.kubeClientSet.CoreV1().ConfigMaps("ns").Get(ctx, tenant.BucketUsageConfigMapName(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := ReadBucketUsageReport(ctx, controller.kubeClientSet, configMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Buckets) != 40000 {
+		t.Errorf("%d buckets in the report, want 40000", len(report.Buckets))
+	}
+
+	// a shard from another scan is detected
+	shard, err := controller.kubeClientSet.CoreV1().ConfigMaps("ns").Get(ctx, tenant.BucketUsageShardConfigMapName(1), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	shard.BinaryData[BucketUsageReportKey] = shard.BinaryData[BucketUsageReportKey][1:]
+	if _, err = controller.kubeClientSet.CoreV1().ConfigMaps("ns").Update(ctx, shard, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ReadBucketUsageReport(ctx, controller.kubeClientSet, configMap); err == nil {
+		t.Error("a report mixing shards was read")
+	}
+
+	// the shards of a larger report are dropped
+	if err = controller.updateBucketUsageReport(ctx, tenant, madmin.DataUsageInfo{}); err != nil {
+		t.Fatal(err)
+	}
+	if configMaps, err = controller.kubeClientSet.CoreV1().ConfigMaps("ns").List(ctx, metav1.ListOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(configMaps.Items) != 1 {
+		t.Errorf("%d ConfigMaps, want the shards of the previous report deleted", len(configMaps.Items))
+	}
+}
@@ -0,0 +1,324 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/certs"
+	"github.com/minio/operator/pkg/controller/certificates"
+)
+
+// Fields of the CA bundle ConfigMaps besides `ca.crt`
+const (
+	caBundleS3EndpointKey  = "s3Endpoint"
+	caBundleSTSEndpointKey = "stsEndpoint"
+	caBundleRegionKey      = "region"
+)
+
+// isCABundleCertificate returns true for the certificates clients should trust, CAs and self-signed certificates
+func isCABundleCertificate(cert *x509.Certificate) bool {
+	if cert.IsCA {
+		return true
+	}
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
+
+// tenantCABundle returns the PEM encoded CAs signing the certificates served by the tenant
+func (c *Controller) tenantCABundle(ctx context.Context, tenant *miniov2.Tenant) ([]byte, error) {
+	type secretRef struct{ namespace, name string }
+	var refs []secretRef
+	for _, secret := range tenant.Spec.ExternalCertSecret {
+		if secret != nil {
+			refs = append(refs, secretRef{tenant.Namespace, secret.Name})
+		}
+	}
+	var pemData [][]byte
+	if tenant.AutoCert() {
+		switch {
+		case tenant.CertManagerIssuer():
+			refs = append(refs, secretRef{tenant.Namespace, tenant.MinIOTLSSecretName()})
+		case certificates.UseInternalCA():
+			// the published bundle already holds the next root during a rotation
+			refs = append(refs, secretRef{miniov2.GetNSFromFile(), InternalCATrustSecretName})
+		default:
+			pemData = append(pemData, miniov2.GetPodCAFromFile())
+		}
+	}
+	for _, ref := range refs {
+		secret, err := c.kubeClientSet.CoreV1().Secrets(ref.namespace).Get(ctx, ref.name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range trustSourceKeys {
+			pemData = append(pemData, secret.Data[key])
+		}
+	}
+
+	bundle := map[string]*x509.Certificate{}
+	for _, data := range pemData {
+		for _, cert := range parseTrustedCertificates("the CA bundle of tenant "+tenant.Name, data) {
+			if isCABundleCertificate(cert.cert) {
+				bundle[cert.Fingerprint] = cert.cert
+			}
+		}
+	}
+	// sorted so the ConfigMaps are only updated when the bundle changes
+	fingerprints := make([]string, 0, len(bundle))
+	for fingerprint := range bundle {
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	sort.Strings(fingerprints)
+	var encoded []byte
+	for _, fingerprint := range fingerprints {
+		encoded = append(encoded, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: bundle[fingerprint].Raw})...)
+	}
+	return encoded, nil
+}
+
+// caBundleNamespaces returns the namespaces the CA bundle of the tenant is published to
+func (c *Controller) caBundleNamespaces(ctx context.Context, tenant *miniov2.Tenant) (map[string]bool, error) {
+	namespaces := map[string]bool{tenant.Namespace: true}
+	if c.policyBindingLister != nil {
+		bindings, err := c.policyBindingLister.PolicyBindings(tenant.Namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, binding := range bindings {
+			if binding.Spec.Application != nil && binding.Spec.Application.Namespace != "" {
+				namespaces[binding.Spec.Application.Namespace] = true
+			}
+		}
+	}
+	if selector := tenant.Spec.CABundle.NamespaceSelector; selector != nil {
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return nil, err
+		}
+		selected, err := c.kubeClientSet.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+		if err != nil {
+			return nil, err
+		}
+		for _, namespace := range selected.Items {
+			namespaces[namespace.Name] = true
+		}
+	}
+	return namespaces, nil
+}
+
+// syncCABundle publishes the CA bundle, the endpoints and the region of the tenant in the ConfigMaps of the consuming
+// namespaces, and removes the ConfigMaps of the namespaces no longer consuming them. The published ConfigMaps are
+// recorded in the status of the tenant, so the ConfigMaps of the cluster are only listed when they changed.
+func (c *Controller) syncCABundle(ctx context.Context, tenant *miniov2.Tenant, tenantConfiguration map[string][]byte) (*miniov2.Tenant, error) {
+	if tenant.Spec.CABundle == nil {
+		if tenant.Status.CABundle == nil {
+			return tenant, nil
+		}
+		if err := c.deleteCABundles(ctx, tenant.Namespace, tenant.Name, nil); err != nil {
+			return tenant, err
+		}
+		return c.updateCABundleStatus(ctx, tenant, nil)
+	}
+	bundle, err := c.tenantCABundle(ctx, tenant)
+	if err != nil {
+		return tenant, err
+	}
+	region := "us-east-1"
+	for _, key := range []string{"MINIO_SITE_REGION", "MINIO_REGION"} {
+		if value, ok := tenantConfiguration[key]; ok && len(value) > 0 {
+			region = string(value)
+			break
+		}
+	}
+	data := map[string]string{
+		caBundleS3EndpointKey: tenant.MinIOServerEndpoint(),
+		caBundleRegionKey:     region,
+	}
+	if IsSTSEnabled() {
+		data[caBundleSTSEndpointKey] = stsInjectionEndpoint(tenant.Namespace)
+	}
+	if len(bundle) > 0 {
+		data[certs.CAPublicCertFile] = string(bundle)
+	}
+
+	namespaces, err := c.caBundleNamespaces(ctx, tenant)
+	if err != nil {
+		return tenant, err
+	}
+	bundleLabels := tenant.MinIOPodLabels()
+	bundleLabels[miniov2.CABundleSourceLabel] = tenant.Namespace
+	for namespace := range namespaces {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      tenant.CABundleReplicaName(namespace),
+				Namespace: namespace,
+				Labels:    bundleLabels,
+			},
+			Data: data,
+		}
+		// owner references can't cross namespaces, the replicas are removed along with the tenant by deleteCABundles
+		if namespace == tenant.Namespace {
+			configMap.OwnerReferences = tenant.OwnerRef()
+		}
+		if err = c.applyCABundle(ctx, configMap); err != nil {
+			return tenant, err
+		}
+	}
+
+	published := &miniov2.CABundleStatus{ConfigMapName: tenant.CABundleConfigMapName()}
+	for namespace := range namespaces {
+		published.Namespaces = append(published.Namespaces, namespace)
+	}
+	sort.Strings(published.Namespaces)
+	if equality.Semantic.DeepEqual(tenant.Status.CABundle, published) {
+		return tenant, nil
+	}
+	err = c.deleteCABundles(ctx, tenant.Namespace, tenant.Name, func(configMap *corev1.ConfigMap) bool {
+		return namespaces[configMap.Namespace] && configMap.Name == tenant.CABundleReplicaName(configMap.Namespace)
+	})
+	if err != nil {
+		return tenant, err
+	}
+	return c.updateCABundleStatus(ctx, tenant, published)
+}
+
+// applyCABundle creates the ConfigMap or updates it when its data changed
+func (c *Controller) applyCABundle(ctx context.Context, configMap *corev1.ConfigMap) error {
+	existing, err := c.kubeClientSet.CoreV1().ConfigMaps(configMap.Namespace).Get(ctx, configMap.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		klog.Infof("Publishing the CA bundle ConfigMap '%s/%s'", configMap.Namespace, configMap.Name)
+		_, err = c.kubeClientSet.CoreV1().ConfigMaps(configMap.Namespace).Create(ctx, configMap, metav1.CreateOptions{})
+		if k8serrors.IsNotFound(err) {
+			// the namespace is gone
+			return nil
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	source := existing.Labels[miniov2.CABundleSourceLabel]
+	if source == "" && len(existing.Data) > 0 {
+		klog.Warningf("ConfigMap '%s/%s' is not a CA bundle published by the Operator, not updating it", existing.Namespace, existing.Name)
+		return nil
+	}
+	if source != "" && (source != configMap.Labels[miniov2.CABundleSourceLabel] ||
+		existing.Labels[miniov2.TenantLabel] != configMap.Labels[miniov2.TenantLabel]) {
+		klog.Warningf("ConfigMap '%s/%s' is the CA bundle of tenant '%s/%s', not updating it",
+			existing.Namespace, existing.Name, source, existing.Labels[miniov2.TenantLabel])
+		return nil
+	}
+	if equality.Semantic.DeepEqual(existing.Data, configMap.Data) && equality.Semantic.DeepEqual(existing.Labels, configMap.Labels) {
+		return nil
+	}
+	klog.Infof("Updating the CA bundle ConfigMap '%s/%s'", configMap.Namespace, configMap.Name)
+	existing.Data, existing.Labels = configMap.Data, configMap.Labels
+	_, err = c.kubeClientSet.CoreV1().ConfigMaps(configMap.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// deleteCABundles removes the CA bundle ConfigMaps of a tenant, except the ones kept
+func (c *Controller) deleteCABundles(ctx context.Context, namespace, tenantName string, keep func(*corev1.ConfigMap) bool) error {
+	selector := labels.SelectorFromSet(labels.Set{
+		miniov2.TenantLabel:         tenantName,
+		miniov2.CABundleSourceLabel: namespace,
+	})
+	configMaps, err := c.kubeClientSet.CoreV1().ConfigMaps("").List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+	for i := range configMaps.Items {
+		configMap := &configMaps.Items[i]
+		if keep != nil && keep(configMap) {
+			continue
+		}
+		klog.Infof("Deleting the CA bundle ConfigMap '%s/%s'", configMap.Namespace, configMap.Name)
+		if err = c.kubeClientSet.CoreV1().ConfigMaps(configMap.Namespace).Delete(ctx, configMap.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// handlePolicyBinding requeues the tenant of the namespace of a PolicyBinding, so its CA bundle follows the binding
+func (c *Controller) handlePolicyBinding(obj interface{}) {
+	object, ok := obj.(metav1.Object)
+	if !ok || c.tenantLister == nil {
+		return
+	}
+	tenants, err := c.tenantLister.Tenants(object.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		return
+	}
+	for _, tenant := range tenants {
+		if tenant.Spec.CABundle != nil {
+			c.enqueueTenant(tenant)
+		}
+	}
+}
+
+// handleCABundleNamespace requeues the tenants whose namespace selector matches a created or relabelled namespace
+func (c *Controller) handleCABundleNamespace(obj interface{}) {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok || c.tenantLister == nil {
+		return
+	}
+	tenants, err := c.tenantLister.List(labels.Everything())
+	if err != nil {
+		return
+	}
+	for _, tenant := range tenants {
+		if tenant.Spec.CABundle == nil || tenant.Spec.CABundle.NamespaceSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(tenant.Spec.CABundle.NamespaceSelector)
+		if err == nil && selector.Matches(labels.Set(namespace.Labels)) {
+			c.enqueueTenant(tenant)
+		}
+	}
+}
+
+// enqueueCABundleTenants requeues the tenants publishing a CA bundle, after the internal CA changed
+func (c *Controller) enqueueCABundleTenants() {
+	if c.tenantLister == nil || c.workqueue == nil {
+		return
+	}
+	tenants, err := c.tenantLister.List(labels.Everything())
+	if err != nil {
+		return
+	}
+	for _, tenant := range tenants {
+		if tenant.Spec.CABundle != nil {
+			c.enqueueTenant(tenant)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	stsv1beta1 "github.com/minio/operator/pkg/apis/sts.min.io/v1beta1"
+	"github.com/minio/operator/pkg/certs"
+	miniofake "github.com/minio/operator/pkg/client/clientset/versioned/fake"
+	stsListers "github.com/minio/operator/pkg/client/listers/sts.min.io/v1beta1"
+)
+
+func Test_syncCABundle(t *testing.T) {
+	ctx := context.Background()
+	_, caPEM := newTrustedCA(t, "tenant-ca")
+
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "myminio", Namespace: "ns"},
+		Spec: miniov2.TenantSpec{
+			ExternalCertSecret: []*miniov2.LocalCertificateReference{{Name: "tenant-tls"}},
+			CABundle: &miniov2.CABundleConfig{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"s3-client": "true"}},
+			},
+		},
+	}
+	bindings := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := bindings.Add(&stsv1beta1.PolicyBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "binding", Namespace: "ns"},
+		Spec:       stsv1beta1.PolicyBindingSpec{Application: &stsv1beta1.Application{Namespace: "app"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	staleLabels := tenant.MinIOPodLabels()
+	staleLabels[miniov2.CABundleSourceLabel] = tenant.Namespace
+	// the replica of a tenant with the same name in another namespace
+	foreignLabels := tenant.MinIOPodLabels()
+	foreignLabels[miniov2.CABundleSourceLabel] = "elsewhere"
+	kubeClient := fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-tls", Namespace: "ns"},
+			Data:       map[string][]byte{certs.CAPublicCertFile: caPEM},
+		},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "selected", Labels: map[string]string{"s3-client": "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "ns-myminio-ca-bundle", Namespace: "other", Labels: staleLabels}},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "ns-myminio-ca-bundle", Namespace: "selected", Labels: foreignLabels},
+			Data:       map[string]string{certs.CAPublicCertFile: "foreign"},
+		},
+	)
+	configMapLists := 0
+	kubeClient.PrependReactor("list", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		configMapLists++
+		return false, nil, nil
+	})
+	controller := Controller{
+		kubeClientSet:       kubeClient,
+		minioClientSet:      miniofake.NewSimpleClientset(tenant),
+		policyBindingLister: stsListers.NewPolicyBindingLister(bindings),
+	}
+	sync := func() {
+		t.Helper()
+		updated, err := controller.syncCABundle(ctx, tenant, map[string][]byte{"MINIO_REGION": []byte("eu-west-1")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tenant.Status = updated.Status
+	}
+
+	sync()
+	for namespace, name := range map[string]string{"ns": "myminio-ca-bundle", "app": "ns-myminio-ca-bundle"} {
+		configMap, err := controller.kubeClientSet.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("CA bundle of namespace %s: %v", namespace, err)
+		}
+		if configMap.Data[certs.CAPublicCertFile] != string(caPEM) || configMap.Data[caBundleRegionKey] != "eu-west-1" ||
+			configMap.Data[caBundleS3EndpointKey] != tenant.MinIOServerEndpoint() {
+			t.Errorf("CA bundle of namespace %s = %v", namespace, configMap.Data)
+		}
+		if owned := len(configMap.OwnerReferences) > 0; owned != (namespace == tenant.Namespace) {
+			t.Errorf("CA bundle of namespace %s owned by the tenant = %t", namespace, owned)
+		}
+	}
+	if _, err := controller.kubeClientSet.CoreV1().ConfigMaps("other").Get(ctx, "ns-myminio-ca-bundle", metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("the CA bundle of a namespace no longer consuming the tenant was not removed: %v", err)
+	}
+	foreign, err := controller.kubeClientSet.CoreV1().ConfigMaps("selected").Get(ctx, "ns-myminio-ca-bundle", metav1.GetOptions{})
+	if err != nil || foreign.Data[certs.CAPublicCertFile] != "foreign" {
+		t.Errorf("the CA bundle of a tenant of another namespace was overwritten: %v, %v", foreign, err)
+	}
+	if published := tenant.Status.CABundle; published == nil || len(published.Namespaces) != 3 {
+		t.Errorf("status.caBundle = %+v, want the 3 namespaces", published)
+	}
+	if configMapLists != 1 {
+		t.Errorf("ConfigMaps listed %d times, want 1", configMapLists)
+	}
+
+	// the ConfigMaps of the cluster are not listed again while the namespaces are unchanged
+	sync()
+	if configMapLists != 1 {
+		t.Errorf("ConfigMaps listed %d times, want 1", configMapLists)
+	}
+
+	// disabling the bundle removes all the replicas
+	tenant.Spec.CABundle = nil
+	sync()
+	configMaps, err := controller.kubeClientSet.CoreV1().ConfigMaps("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configMaps.Items) != 1 || configMaps.Items[0].Labels[miniov2.CABundleSourceLabel] != "elsewhere" {
+		t.Errorf("CA bundles left after disabling them: %v", configMaps.Items)
+	}
+	if tenant.Status.CABundle != nil {
+		t.Errorf("status.caBundle = %+v after disabling the bundle", tenant.Status.CABundle)
+	}
+	configMapLists = 0
+	sync()
+	if configMapLists != 0 {
+		t.Errorf("ConfigMaps listed %d times without a published bundle", configMapLists)
+	}
+}
@@ -0,0 +1,234 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+const (
+	// usageHistoryKey is the key of the usage history in the ConfigMap
+	usageHistoryKey = "history.json"
+	// usageHistorySampleInterval is the minimum time between two samples of the usage history
+	usageHistorySampleInterval = time.Hour
+	// usageHistoryRetention is how long the samples of the usage history are kept
+	usageHistoryRetention = 30 * 24 * time.Hour
+	// usageGrowthWindow is the period the growth rate is averaged over
+	usageGrowthWindow = 7 * 24 * time.Hour
+)
+
+// usageSample is the usage and the total usable space of a tenant or pool at a point in time, the names are kept
+// short to keep the history compact
+type usageSample struct {
+	Time  int64 `json:"t"`
+	Usage int64 `json:"u"`
+	Total int64 `json:"s"`
+}
+
+// usableSpace returns the total usable space of a tenant or pool. The capacity in the status is built from the
+// available space of the drives, so it's the space that is still free and the total is the usage plus the capacity.
+func usableSpace(usage, capacity int64) int64 {
+	return usage + capacity
+}
+
+// usageHistory is the rolling usage history of a tenant and its pools
+type usageHistory struct {
+	Tenant []usageSample            `json:"tenant,omitempty"`
+	Pools  map[string][]usageSample `json:"pools,omitempty"`
+}
+
+// addUsageSample appends a sample when the last one is older than the sample interval and drops the samples past
+// the retention
+func addUsageSample(samples []usageSample, sample usageSample) []usageSample {
+	if n := len(samples); n > 0 && sample.Time-samples[n-1].Time < int64(usageHistorySampleInterval.Seconds()) {
+		return samples
+	}
+	samples = append(samples, sample)
+	cutoff := sample.Time - int64(usageHistoryRetention.Seconds())
+	for len(samples) > 0 && samples[0].Time < cutoff {
+		samples = samples[1:]
+	}
+	return samples
+}
+
+// usageForecast returns the growth of the usage in bytes per day, as the least squares slope of the samples in the
+// growth window, and the number of days until the total space of the latest sample is exhausted. The days are nil while
+// the usage is not growing or there is not enough history.
+func usageForecast(samples []usageSample) (int64, *int32) {
+	if len(samples) < 2 {
+		return 0, nil
+	}
+	latest := samples[len(samples)-1]
+	cutoff := latest.Time - int64(usageGrowthWindow.Seconds())
+	var n, sumX, sumY, sumXY, sumXX float64
+	for _, sample := range samples {
+		if sample.Time < cutoff {
+			continue
+		}
+		// days relative to the latest sample keep the sums small
+		x := float64(sample.Time-latest.Time) / (24 * 60 * 60)
+		y := float64(sample.Usage)
+		n++
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if n < 2 || denominator == 0 {
+		return 0, nil
+	}
+	growth := (n*sumXY - sumX*sumY) / denominator
+	if growth <= 0 {
+		return int64(growth), nil
+	}
+	free := float64(latest.Total - latest.Usage)
+	days := int32(math.Min(math.Max(free/growth, 0), math.MaxInt32))
+	return int64(growth), &days
+}
+
+// updateCapacityTrend records the current usage of the tenant and its pools in the usage history, forecasts when
+// they run full and raises the capacity conditions of the tenant.
+func (c *Controller) updateCapacityTrend(ctx context.Context, tenant *miniov2.Tenant) error {
+	if tenant.Status.Usage.Capacity <= 0 {
+		return nil
+	}
+	history, configMap, err := c.getUsageHistory(ctx, tenant)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	history.Tenant = addUsageSample(history.Tenant, usageSample{
+		Time:  now,
+		Usage: tenant.Status.Usage.Usage,
+		Total: usableSpace(tenant.Status.Usage.Usage, tenant.Status.Usage.Capacity),
+	})
+	tenant.Status.Usage.GrowthPerDay, tenant.Status.Usage.DaysUntilFull = usageForecast(history.Tenant)
+
+	pools := make(map[string][]usageSample, len(tenant.Status.Pools))
+	for i := range tenant.Status.Pools {
+		pool := &tenant.Status.Pools[i]
+		if pool.Capacity <= 0 {
+			continue
+		}
+		pools[pool.SSName] = addUsageSample(history.Pools[pool.SSName], usageSample{
+			Time:  now,
+			Usage: pool.Usage,
+			Total: usableSpace(pool.Usage, pool.Capacity),
+		})
+		pool.GrowthPerDay, pool.DaysUntilFull = usageForecast(pools[pool.SSName])
+	}
+	// decommissioned pools are dropped from the history
+	history.Pools = pools
+
+	c.updateCapacityConditions(tenant)
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	if configMap.Data[usageHistoryKey] == string(data) {
+		return nil
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[usageHistoryKey] = string(data)
+	if configMap.ResourceVersion == "" {
+		_, err = c.kubeClientSet.CoreV1().ConfigMaps(tenant.Namespace).Create(ctx, configMap, metav1.CreateOptions{})
+	} else {
+		_, err = c.kubeClientSet.CoreV1().ConfigMaps(tenant.Namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	}
+	return err
+}
+
+// getUsageHistory returns the usage history of the tenant and the ConfigMap it's stored in, a new ConfigMap is
+// returned when the tenant has no history yet
+func (c *Controller) getUsageHistory(ctx context.Context, tenant *miniov2.Tenant) (*usageHistory, *corev1.ConfigMap, error) {
+	history := &usageHistory{}
+	configMap, err := c.kubeClientSet.CoreV1().ConfigMaps(tenant.Namespace).Get(ctx, tenant.UsageHistoryConfigMapName(), metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, nil, err
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            tenant.UsageHistoryConfigMapName(),
+				Namespace:       tenant.Namespace,
+				Labels:          map[string]string{miniov2.TenantLabel: tenant.Name},
+				OwnerReferences: tenant.OwnerRef(),
+			},
+		}
+		return history, configMap, nil
+	}
+	if data, ok := configMap.Data[usageHistoryKey]; ok {
+		if err := json.Unmarshal([]byte(data), history); err != nil {
+			// a corrupt history is started over instead of blocking the monitoring
+			klog.Warningf("'%s/%s' Discarding unreadable usage history: %v", tenant.Namespace, tenant.Name, err)
+			history = &usageHistory{}
+		}
+	}
+	return history, configMap, nil
+}
+
+// updateCapacityConditions raises the CapacityWarning and CapacityCritical conditions of the tenant when the usage or
+// the forecast crosses the configured thresholds, an event is recorded when a condition is raised
+func (c *Controller) updateCapacityConditions(tenant *miniov2.Tenant) {
+	warning, critical, warningDays, criticalDays := tenant.GetCapacityAlertThresholds()
+	usage := tenant.Status.Usage
+	usedPercent := float64(usage.Usage) * 100 / float64(usableSpace(usage.Usage, usage.Capacity))
+
+	evaluate := func(conditionType string, threshold, days int32) {
+		condition := metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             "CapacitySufficient",
+			Message:            fmt.Sprintf("%.1f%% of the usable capacity is in use", usedPercent),
+			ObservedGeneration: tenant.Generation,
+		}
+		switch {
+		case usedPercent >= float64(threshold):
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = "UsageAboveThreshold"
+			condition.Message = fmt.Sprintf("%.1f%% of the usable capacity is in use, above the %d%% threshold", usedPercent, threshold)
+		case usage.DaysUntilFull != nil && *usage.DaysUntilFull <= days:
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = "ForecastBelowThreshold"
+			condition.Message = fmt.Sprintf("The usable capacity is forecasted to be exhausted in %d days, below the %d days threshold", *usage.DaysUntilFull, days)
+		}
+		raised := condition.Status == metav1.ConditionTrue && !meta.IsStatusConditionTrue(tenant.Status.Conditions, conditionType)
+		meta.SetStatusCondition(&tenant.Status.Conditions, condition)
+		if raised {
+			c.recorder.Event(tenant, corev1.EventTypeWarning, conditionType, condition.Message)
+		}
+	}
+	evaluate(miniov2.TenantConditionCapacityWarning, warning, warningDays)
+	evaluate(miniov2.TenantConditionCapacityCritical, critical, criticalDays)
+}
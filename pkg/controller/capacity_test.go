@@ -0,0 +1,178 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+const gib = 1 << 30
+
+func Test_usageForecast(t *testing.T) {
+	day := int64(24 * 60 * 60)
+	now := time.Now().Unix()
+
+	var samples []usageSample
+	for i := int64(10); i >= 0; i-- {
+		samples = addUsageSample(samples, usageSample{
+			Time:  now - i*day,
+			Usage: (100 - 10*i) * gib,
+			Total: 200 * gib,
+		})
+	}
+	if len(samples) != 11 {
+		t.Fatalf("samples = %d, want 11", len(samples))
+	}
+	// samples closer than the sample interval are skipped
+	if got := addUsageSample(samples, usageSample{Time: now + 60}); len(got) != 11 {
+		t.Errorf("samples = %d, want 11", len(got))
+	}
+
+	growth, days := usageForecast(samples)
+	if growth != 10*gib {
+		t.Errorf("growth = %d, want %d", growth, 10*gib)
+	}
+	if days == nil || *days != 10 {
+		t.Errorf("days until full = %v, want 10", days)
+	}
+
+	flat := []usageSample{{Time: now - day, Usage: gib, Total: 2 * gib}, {Time: now, Usage: gib, Total: 2 * gib}}
+	if growth, days := usageForecast(flat); growth != 0 || days != nil {
+		t.Errorf("flat usage forecast = %d, %v, want 0, nil", growth, days)
+	}
+}
+
+func Test_updateCapacityTrend(t *testing.T) {
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+		},
+		Status: miniov2.TenantStatus{
+			Usage: miniov2.TenantUsage{
+				Capacity: 20 * gib,
+				Usage:    80 * gib,
+			},
+			Pools: []miniov2.PoolStatus{
+				{SSName: "myminio-pool-0", Capacity: 20 * gib, Usage: 80 * gib},
+			},
+		},
+	}
+	// a day old sample forecasts the tenant to be full in 4 days
+	history := usageHistory{
+		Tenant: []usageSample{{Time: time.Now().Add(-24 * time.Hour).Unix(), Usage: 75 * gib, Total: 100 * gib}},
+		Pools: map[string][]usageSample{
+			"myminio-pool-0": {{Time: time.Now().Add(-24 * time.Hour).Unix(), Usage: 75 * gib, Total: 100 * gib}},
+			"myminio-pool-1": {{Time: time.Now().Add(-24 * time.Hour).Unix(), Usage: gib, Total: 100 * gib}},
+		},
+	}
+	data, _ := json.Marshal(history)
+	recorder := record.NewFakeRecorder(10)
+	controller := Controller{
+		kubeClientSet: fake.NewSimpleClientset(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            tenant.UsageHistoryConfigMapName(),
+				Namespace:       tenant.Namespace,
+				ResourceVersion: "1",
+			},
+			Data: map[string]string{usageHistoryKey: string(data)},
+		}),
+		recorder: recorder,
+	}
+
+	if err := controller.updateCapacityTrend(context.Background(), tenant); err != nil {
+		t.Fatal(err)
+	}
+
+	if tenant.Status.Usage.GrowthPerDay != 5*gib {
+		t.Errorf("growth = %d, want %d", tenant.Status.Usage.GrowthPerDay, 5*gib)
+	}
+	if days := tenant.Status.Usage.DaysUntilFull; days == nil || *days != 4 {
+		t.Errorf("days until full = %v, want 4", days)
+	}
+	if days := tenant.Status.Pools[0].DaysUntilFull; days == nil || *days != 4 {
+		t.Errorf("pool days until full = %v, want 4", days)
+	}
+	if !meta.IsStatusConditionTrue(tenant.Status.Conditions, miniov2.TenantConditionCapacityWarning) {
+		t.Error("CapacityWarning condition not raised above 75%")
+	}
+	critical := meta.FindStatusCondition(tenant.Status.Conditions, miniov2.TenantConditionCapacityCritical)
+	if critical == nil || critical.Status != metav1.ConditionTrue || critical.Reason != "ForecastBelowThreshold" {
+		t.Errorf("CapacityCritical condition = %+v, want raised by the forecast", critical)
+	}
+	if len(recorder.Events) != 2 {
+		t.Errorf("events = %d, want 2", len(recorder.Events))
+	}
+
+	configMap, err := controller.kubeClientSet.CoreV1().ConfigMaps(tenant.Namespace).Get(context.Background(), tenant.UsageHistoryConfigMapName(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored usageHistory
+	if err := json.Unmarshal([]byte(configMap.Data[usageHistoryKey]), &stored); err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Tenant) != 2 || len(stored.Pools["myminio-pool-0"]) != 2 {
+		t.Errorf("stored history = %+v, want 2 samples for the tenant and the pool", stored)
+	}
+	if _, ok := stored.Pools["myminio-pool-1"]; ok {
+		t.Error("history of a removed pool was kept")
+	}
+
+	// raised conditions don't record the events again
+	if err := controller.updateCapacityTrend(context.Background(), tenant); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.Events) != 2 {
+		t.Errorf("events = %d, want 2", len(recorder.Events))
+	}
+}
+
+func Test_updateCapacityConditionsFreeCapacity(t *testing.T) {
+	// the capacity is the free space, so 45 GiB used with 55 GiB free is 45% in use
+	tenant := &miniov2.Tenant{
+		Status: miniov2.TenantStatus{
+			Usage: miniov2.TenantUsage{
+				Capacity: 55 * gib,
+				Usage:    45 * gib,
+			},
+		},
+	}
+	controller := Controller{recorder: record.NewFakeRecorder(10)}
+	controller.updateCapacityConditions(tenant)
+
+	for _, conditionType := range []string{miniov2.TenantConditionCapacityWarning, miniov2.TenantConditionCapacityCritical} {
+		condition := meta.FindStatusCondition(tenant.Status.Conditions, conditionType)
+		if condition == nil || condition.Status != metav1.ConditionFalse {
+			t.Errorf("%s condition = %+v, want not raised", conditionType, condition)
+		}
+	}
+	if condition := meta.FindStatusCondition(tenant.Status.Conditions, miniov2.TenantConditionCapacityWarning); condition != nil && condition.Message != "45.0% of the usable capacity is in use" {
+		t.Errorf("message = %q", condition.Message)
+	}
+}
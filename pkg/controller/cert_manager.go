@@ -0,0 +1,279 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+const (
+	// CertificateCreated is used as part of the Event 'reason' when a cert-manager Certificate is created
+	CertificateCreated = "CertificateCreated"
+	// CertificateUpdated is used as part of the Event 'reason' when a cert-manager Certificate is updated
+	CertificateUpdated = "CertificateUpdated"
+)
+
+// certManagerCertificateGVK is the kind of the cert-manager Certificates created for the tenants
+var certManagerCertificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// maxCommonNameLength is the longest Common Name cert-manager accepts, longer names are only set as SANs
+const maxCommonNameLength = 64
+
+// newCertManagerCertificate returns a cert-manager Certificate owned by the tenant, signed by the issuer of the tenant
+// and written to the secret of the same name
+func newCertManagerCertificate(tenant *miniov2.Tenant, secretName string, labels map[string]string, commonName string, dnsNames []string, usages []string, privateKey map[string]interface{}) *unstructured.Unstructured {
+	issuerRef := tenant.Spec.CertConfig.IssuerRef
+	issuer := map[string]interface{}{
+		"name": issuerRef.Name,
+		"kind": "Issuer",
+	}
+	if issuerRef.Kind != "" {
+		issuer["kind"] = issuerRef.Kind
+	}
+	if issuerRef.Group != "" {
+		issuer["group"] = issuerRef.Group
+	}
+	spec := map[string]interface{}{
+		"secretName": secretName,
+		"dnsNames":   stringsToInterfaces(dnsNames),
+		"usages":     stringsToInterfaces(usages),
+		"privateKey": privateKey,
+		"issuerRef":  issuer,
+		"secretTemplate": map[string]interface{}{
+			"labels": labelsToInterfaces(labels),
+		},
+	}
+	if commonName != "" && len(commonName) <= maxCommonNameLength {
+		spec["commonName"] = commonName
+	}
+	if subject := certManagerSubject(tenant.Spec.CertConfig); len(subject) > 0 {
+		spec["subject"] = subject
+	}
+	if duration := certificateDuration(tenant.Spec.CertConfig); duration > 0 {
+		spec["duration"] = duration.String()
+	}
+	if len(tenant.Spec.CertConfig.IPAddresses) > 0 {
+		spec["ipAddresses"] = stringsToInterfaces(tenant.Spec.CertConfig.IPAddresses)
+	}
+	if len(tenant.Spec.CertConfig.URIs) > 0 {
+		spec["uris"] = stringsToInterfaces(tenant.Spec.CertConfig.URIs)
+	}
+
+	certificate := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	certificate.SetGroupVersionKind(certManagerCertificateGVK)
+	certificate.SetName(secretName)
+	certificate.SetNamespace(tenant.Namespace)
+	certificate.SetLabels(labels)
+	certificate.SetOwnerReferences(tenant.OwnerRef())
+	return certificate
+}
+
+// minioCertManagerCertificate returns the Certificate of the MinIO server, with the same SANs as the CSR
+func minioCertManagerCertificate(tenant *miniov2.Tenant, hostsTemplate string) *unstructured.Unstructured {
+	return newCertManagerCertificate(tenant, tenant.MinIOTLSSecretName(), tenant.MinIOPodLabels(),
+		tenant.Spec.CertConfig.CommonName, minioCertificateDNSNames(tenant, hostsTemplate),
+		[]string{"digital signature", "key encipherment", "server auth", "client auth"},
+		certManagerPrivateKey(tenant.Spec.CertConfig, map[string]interface{}{"algorithm": "ECDSA", "size": int64(256)}))
+}
+
+// minioClientCertManagerCertificate returns the Certificate MinIO uses to authenticate against KES, the private key
+// is kept on renewal since KES identifies MinIO by the hash of its public key
+func minioClientCertManagerCertificate(tenant *miniov2.Tenant) *unstructured.Unstructured {
+	return newCertManagerCertificate(tenant, tenant.MinIOClientTLSSecretName(), tenant.MinIOPodLabels(),
+		tenant.MinIOFQDNServiceName(), tenant.MinIOHosts(),
+		[]string{"digital signature", "server auth", "client auth"},
+		certManagerPrivateKey(tenant.Spec.CertConfig, map[string]interface{}{"algorithm": "Ed25519", "rotationPolicy": "Never"}))
+}
+
+// kesCertManagerCertificate returns the Certificate of the KES server
+func kesCertManagerCertificate(tenant *miniov2.Tenant) *unstructured.Unstructured {
+	return newCertManagerCertificate(tenant, tenant.KESTLSSecretName(), tenant.KESPodLabels(),
+		tenant.KESWildCardName(), tenant.KESHosts(),
+		[]string{"digital signature", "key encipherment", "server auth"},
+		certManagerPrivateKey(tenant.Spec.CertConfig, map[string]interface{}{"algorithm": "ECDSA", "size": int64(256)}))
+}
+
+// certManagerPrivateKey returns the private key settings of the key algorithm of the config, or the defaults when none
+// is set. The key is rotated on renewal so that cert-manager issues the certificate again when the algorithm changes,
+// unless the defaults pin the key with their rotation policy
+func certManagerPrivateKey(config *miniov2.CertificateConfig, defaults map[string]interface{}) map[string]interface{} {
+	if config == nil || config.KeyAlgorithm == "" {
+		return defaults
+	}
+	algorithm, size := certificateKeyParams(config, config.KeyAlgorithm)
+	privateKey := map[string]interface{}{"algorithm": algorithm, "rotationPolicy": "Always"}
+	if policy, ok := defaults["rotationPolicy"]; ok {
+		privateKey["rotationPolicy"] = policy
+	}
+	if size > 0 {
+		privateKey["size"] = int64(size)
+	}
+	return privateKey
+}
+
+// certManagerKeyChanged returns whether the algorithm or the size of the private key of the Certificate changes
+func certManagerKeyChanged(certificate, existing *unstructured.Unstructured) bool {
+	for _, field := range []string{"algorithm", "size"} {
+		desired, _, _ := unstructured.NestedFieldNoCopy(certificate.Object, "spec", "privateKey", field)
+		current, _, _ := unstructured.NestedFieldNoCopy(existing.Object, "spec", "privateKey", field)
+		if !equality.Semantic.DeepEqual(desired, current) {
+			return true
+		}
+	}
+	return false
+}
+
+// certManagerSubject returns the subject fields of the config
+func certManagerSubject(config *miniov2.CertificateConfig) map[string]interface{} {
+	subject := map[string]interface{}{}
+	if config == nil {
+		return subject
+	}
+	if len(config.OrganizationName) > 0 {
+		subject["organizations"] = stringsToInterfaces(config.OrganizationName)
+	}
+	if config.Subject == nil {
+		return subject
+	}
+	for field, values := range map[string][]string{
+		"organizationalUnits": config.Subject.OrganizationalUnits,
+		"countries":           config.Subject.Countries,
+		"provinces":           config.Subject.Provinces,
+		"localities":          config.Subject.Localities,
+		"streetAddresses":     config.Subject.StreetAddresses,
+		"postalCodes":         config.Subject.PostalCodes,
+	} {
+		if len(values) > 0 {
+			subject[field] = stringsToInterfaces(values)
+		}
+	}
+	if config.Subject.SerialNumber != "" {
+		subject["serialNumber"] = config.Subject.SerialNumber
+	}
+	return subject
+}
+
+// certManagerCertificateReady returns true once cert-manager issued the current spec of the Certificate
+func certManagerCertificateReady(certificate *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		if generation, found, _ := unstructured.NestedInt64(condition, "observedGeneration"); found && generation != certificate.GetGeneration() {
+			return false
+		}
+		return condition["status"] == string(metav1.ConditionTrue)
+	}
+	return false
+}
+
+// syncCertManagerCertificate creates or updates the cert-manager Certificate and returns true once its secret holds
+// a certificate for the current spec
+func (c *Controller) syncCertManagerCertificate(ctx context.Context, tenant *miniov2.Tenant, certificate *unstructured.Unstructured) (bool, error) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(certManagerCertificateGVK)
+	err := c.k8sClient.Get(ctx, client.ObjectKeyFromObject(certificate), existing)
+	if k8serrors.IsNotFound(err) {
+		// the Opaque secret generated through a CSR cannot become a kubernetes.io/tls secret, cert-manager
+		// writes a new one
+		secret, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Get(ctx, certificate.GetName(), metav1.GetOptions{})
+		if err == nil && secret.Type != corev1.SecretTypeTLS && metav1.IsControlledBy(secret, tenant) {
+			klog.Infof("'%s/%s' Deleting secret %s generated through a CSR", tenant.Namespace, tenant.Name, secret.Name)
+			if err = c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+				return false, err
+			}
+		} else if err != nil && !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+		klog.Infof("'%s/%s' Creating cert-manager Certificate %s", tenant.Namespace, tenant.Name, certificate.GetName())
+		if err = c.k8sClient.Create(ctx, certificate); err != nil {
+			return false, err
+		}
+		c.recorder.Event(tenant, corev1.EventTypeNormal, CertificateCreated, fmt.Sprintf("cert-manager Certificate %s created", certificate.GetName()))
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !metav1.IsControlledBy(existing, tenant) {
+		c.recorder.Event(tenant, corev1.EventTypeWarning, ErrResourceExists, fmt.Sprintf(MessageResourceExists, existing.GetName()))
+		return false, fmt.Errorf(MessageResourceExists, existing.GetName())
+	}
+	if !equality.Semantic.DeepDerivative(certificate.Object["spec"], existing.Object["spec"]) ||
+		!equality.Semantic.DeepDerivative(certificate.GetLabels(), existing.GetLabels()) {
+		klog.Infof("'%s/%s' Updating cert-manager Certificate %s", tenant.Namespace, tenant.Name, certificate.GetName())
+		keyChanged := certManagerKeyChanged(certificate, existing)
+		existing.Object["spec"] = certificate.Object["spec"]
+		existing.SetLabels(certificate.GetLabels())
+		if err = c.k8sClient.Update(ctx, existing); err != nil {
+			return false, err
+		}
+		// cert-manager keeps reusing a pinned key, its secret is deleted so a key of the new algorithm is generated
+		if policy, _, _ := unstructured.NestedString(certificate.Object, "spec", "privateKey", "rotationPolicy"); keyChanged && policy == "Never" {
+			secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName")
+			klog.Infof("'%s/%s' Deleting secret %s to generate a key of the new algorithm", tenant.Namespace, tenant.Name, secretName)
+			if err = c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Delete(ctx, secretName, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+				return false, err
+			}
+		}
+		c.recorder.Event(tenant, corev1.EventTypeNormal, CertificateUpdated, fmt.Sprintf("cert-manager Certificate %s updated", certificate.GetName()))
+		return false, nil
+	}
+	return certManagerCertificateReady(existing), nil
+}
+
+// checkCertManagerCertificate makes sure the Certificate exists, the tenant is requeued until its secret is ready
+func (c *Controller) checkCertManagerCertificate(ctx context.Context, tenant *miniov2.Tenant, certificate *unstructured.Unstructured, waitingStatus string) error {
+	ready, err := c.syncCertManagerCertificate(ctx, tenant, certificate)
+	if err != nil || ready {
+		return err
+	}
+	if _, err = c.updateTenantStatus(ctx, tenant, waitingStatus, tenant.Status.AvailableReplicas); err != nil {
+		return err
+	}
+	return fmt.Errorf("waiting for cert-manager Certificate %s", certificate.GetName())
+}
+
+func stringsToInterfaces(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+	return result
+}
+
+func labelsToInterfaces(labels map[string]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(labels))
+	for key, value := range labels {
+		result[key] = value
+	}
+	return result
+}
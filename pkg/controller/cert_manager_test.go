@@ -0,0 +1,189 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+func Test_syncCertManagerCertificate(t *testing.T) {
+	ctx := context.Background()
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+			UID:       "tenant-uid",
+		},
+		Spec: miniov2.TenantSpec{
+			Pools: []miniov2.Pool{{Name: "pool-0", Servers: 4, VolumesPerServer: 4}},
+			CertConfig: &miniov2.CertificateConfig{
+				IssuerRef: &miniov2.CertificateIssuerRef{Name: "ca-issuer", Kind: "ClusterIssuer"},
+			},
+		},
+	}
+	tenant.EnsureDefaults()
+	// secret left by the CSR flow
+	csrSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            tenant.MinIOTLSSecretName(),
+			Namespace:       "ns",
+			OwnerReferences: tenant.OwnerRef(),
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	controller := Controller{
+		kubeClientSet: fake.NewSimpleClientset(csrSecret),
+		k8sClient:     fakeclient.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+		recorder:      record.NewFakeRecorder(10),
+	}
+
+	certificate := minioCertManagerCertificate(tenant, "")
+	ready, err := controller.syncCertManagerCertificate(ctx, tenant, certificate)
+	if err != nil || ready {
+		t.Fatalf("syncCertManagerCertificate() = %v, %v, want a new certificate not ready yet", ready, err)
+	}
+	if _, err = controller.kubeClientSet.CoreV1().Secrets("ns").Get(ctx, csrSecret.Name, metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("secret generated through a CSR was not deleted: %v", err)
+	}
+
+	created := &unstructured.Unstructured{}
+	created.SetGroupVersionKind(certManagerCertificateGVK)
+	if err = controller.k8sClient.Get(ctx, client.ObjectKey{Namespace: "ns", Name: "myminio-tls"}, created); err != nil {
+		t.Fatal(err)
+	}
+	if secretName, _, _ := unstructured.NestedString(created.Object, "spec", "secretName"); secretName != "myminio-tls" {
+		t.Errorf("secretName = %q, want myminio-tls", secretName)
+	}
+	if kind, _, _ := unstructured.NestedString(created.Object, "spec", "issuerRef", "kind"); kind != "ClusterIssuer" {
+		t.Errorf("issuerRef.kind = %q, want ClusterIssuer", kind)
+	}
+	dnsNames, _, _ := unstructured.NestedStringSlice(created.Object, "spec", "dnsNames")
+	if len(dnsNames) != len(minioCertificateDNSNames(tenant, "")) {
+		t.Errorf("dnsNames = %v, want the SANs of the CSR", dnsNames)
+	}
+
+	// cert-manager issued the certificate
+	if err = unstructured.SetNestedSlice(created.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True"},
+	}, "status", "conditions"); err != nil {
+		t.Fatal(err)
+	}
+	if err = controller.k8sClient.Update(ctx, created); err != nil {
+		t.Fatal(err)
+	}
+	if ready, err = controller.syncCertManagerCertificate(ctx, tenant, certificate); err != nil || !ready {
+		t.Errorf("syncCertManagerCertificate() = %v, %v, want ready", ready, err)
+	}
+
+	// moving to another issuer issues the certificate again
+	tenant.Spec.CertConfig.IssuerRef.Name = "other-issuer"
+	if ready, err = controller.syncCertManagerCertificate(ctx, tenant, minioCertManagerCertificate(tenant, "")); err != nil || ready {
+		t.Errorf("syncCertManagerCertificate() = %v, %v, want an updated certificate not ready yet", ready, err)
+	}
+}
+
+func Test_syncCertManagerCertificatePinnedKey(t *testing.T) {
+	ctx := context.Background()
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+			UID:       "tenant-uid",
+		},
+		Spec: miniov2.TenantSpec{
+			Pools: []miniov2.Pool{{Name: "pool-0", Servers: 4, VolumesPerServer: 4}},
+			CertConfig: &miniov2.CertificateConfig{
+				IssuerRef:    &miniov2.CertificateIssuerRef{Name: "ca-issuer"},
+				KeyAlgorithm: miniov2.CertificateKeyAlgorithmECDSA,
+			},
+		},
+	}
+	tenant.EnsureDefaults()
+	// KES identifies MinIO by the hash of its public key, the key of the client certificate is never rotated
+	certificate := minioClientCertManagerCertificate(tenant)
+	if policy, _, _ := unstructured.NestedString(certificate.Object, "spec", "privateKey", "rotationPolicy"); policy != "Never" {
+		t.Errorf("rotationPolicy = %q, want Never", policy)
+	}
+	if policy, _, _ := unstructured.NestedString(minioCertManagerCertificate(tenant, "").Object, "spec", "privateKey", "rotationPolicy"); policy != "Always" {
+		t.Errorf("server rotationPolicy = %q, want Always", policy)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tenant.MinIOClientTLSSecretName(),
+			Namespace: "ns",
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+	controller := Controller{
+		kubeClientSet: fake.NewSimpleClientset(secret),
+		k8sClient:     fakeclient.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+		recorder:      record.NewFakeRecorder(10),
+	}
+	if _, err := controller.syncCertManagerCertificate(ctx, tenant, certificate); err != nil {
+		t.Fatal(err)
+	}
+
+	// an update that keeps the algorithm keeps the key
+	tenant.Spec.CertConfig.IssuerRef.Name = "other-issuer"
+	if _, err := controller.syncCertManagerCertificate(ctx, tenant, minioClientCertManagerCertificate(tenant)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := controller.kubeClientSet.CoreV1().Secrets("ns").Get(ctx, secret.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("secret of the pinned key was deleted: %v", err)
+	}
+
+	// a new key size generates a new key
+	tenant.Spec.CertConfig.KeySize = 384
+	if _, err := controller.syncCertManagerCertificate(ctx, tenant, minioClientCertManagerCertificate(tenant)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := controller.kubeClientSet.CoreV1().Secrets("ns").Get(ctx, secret.Name, metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("secret of the pinned key was kept after the key size changed: %v", err)
+	}
+}
+
+func Test_certManagerCertificateReady(t *testing.T) {
+	certificate := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	certificate.SetGeneration(2)
+	if certManagerCertificateReady(certificate) {
+		t.Error("certificate without status is ready")
+	}
+	_ = unstructured.SetNestedSlice(certificate.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True", "observedGeneration": int64(1)},
+	}, "status", "conditions")
+	if certManagerCertificateReady(certificate) {
+		t.Error("certificate issued for a previous generation is ready")
+	}
+	certificate.SetGeneration(1)
+	if !certManagerCertificateReady(certificate) {
+		t.Error("issued certificate is not ready")
+	}
+}
@@ -0,0 +1,194 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/minio/pkg/env"
+	"k8s.io/klog/v2"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/controller/certificates"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// certificateKeyParams returns the algorithm and size of the keys generated for the certificate config, the algorithm
+// defaults to defaultAlgorithm
+func certificateKeyParams(config *miniov2.CertificateConfig, defaultAlgorithm string) (string, int) {
+	algorithm, size := defaultAlgorithm, 0
+	if config != nil && config.KeyAlgorithm != "" {
+		algorithm, size = config.KeyAlgorithm, config.KeySize
+	}
+	switch algorithm {
+	case miniov2.CertificateKeyAlgorithmECDSA:
+		if size == 0 {
+			size = miniov2.DefaultEllipticCurve.Params().BitSize
+		}
+	case miniov2.CertificateKeyAlgorithmRSA:
+		if size == 0 {
+			size = miniov2.DefaultRSAKeySize
+		}
+	case miniov2.CertificateKeyAlgorithmEd25519:
+		size = 0
+	}
+	return algorithm, size
+}
+
+// newCertificateKey returns a randomly generated private key of the algorithm and size
+func newCertificateKey(algorithm string, size int) (crypto.Signer, error) {
+	switch algorithm {
+	case miniov2.CertificateKeyAlgorithmECDSA:
+		var curve elliptic.Curve
+		switch size {
+		case 256:
+			curve = elliptic.P256()
+		case 384:
+			curve = elliptic.P384()
+		case 521:
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA key size %d, use 256, 384 or 521", size)
+		}
+		return newPrivateKey(curve)
+	case miniov2.CertificateKeyAlgorithmRSA:
+		if size != 2048 && size != 3072 && size != 4096 {
+			return nil, fmt.Errorf("unsupported RSA key size %d, use 2048, 3072 or 4096", size)
+		}
+		return rsa.GenerateKey(rand.Reader, size)
+	case miniov2.CertificateKeyAlgorithmEd25519:
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		return privateKey, err
+	}
+	return nil, fmt.Errorf("unsupported key algorithm %q, use ECDSA, RSA or Ed25519", algorithm)
+}
+
+// certificateKeyMatches returns true if the public key of a certificate has the algorithm and size
+func certificateKeyMatches(publicKey crypto.PublicKey, algorithm string, size int) bool {
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		return algorithm == miniov2.CertificateKeyAlgorithmECDSA && key.Curve.Params().BitSize == size
+	case *rsa.PublicKey:
+		return algorithm == miniov2.CertificateKeyAlgorithmRSA && key.N.BitLen() == size
+	case ed25519.PublicKey:
+		return algorithm == miniov2.CertificateKeyAlgorithmEd25519
+	}
+	return false
+}
+
+// csrSignatureAlgorithm returns the algorithm signing the CSRs of the key, ECDSA keys keep using SHA-512
+func csrSignatureAlgorithm(key crypto.Signer) x509.SignatureAlgorithm {
+	if _, ok := key.(*ecdsa.PrivateKey); ok {
+		return x509.ECDSAWithSHA512
+	}
+	return x509.UnknownSignatureAlgorithm
+}
+
+// certificateSubject returns the subject of the certificates with the common name and the fields of the config
+func certificateSubject(config *miniov2.CertificateConfig, commonName string) pkix.Name {
+	subject := pkix.Name{CommonName: commonName}
+	if config == nil {
+		return subject
+	}
+	subject.Organization = config.OrganizationName
+	if config.Subject != nil {
+		subject.OrganizationalUnit = config.Subject.OrganizationalUnits
+		subject.Country = config.Subject.Countries
+		subject.Province = config.Subject.Provinces
+		subject.Locality = config.Subject.Localities
+		subject.StreetAddress = config.Subject.StreetAddresses
+		subject.PostalCode = config.Subject.PostalCodes
+		subject.SerialNumber = config.Subject.SerialNumber
+	}
+	return subject
+}
+
+// certificateIPAddresses returns the additional IP SANs of the config
+func certificateIPAddresses(config *miniov2.CertificateConfig) ([]net.IP, error) {
+	if config == nil {
+		return nil, nil
+	}
+	ips := make([]net.IP, 0, len(config.IPAddresses))
+	for _, address := range config.IPAddresses {
+		ip := net.ParseIP(address)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q in certConfig.ipAddresses", address)
+		}
+		ips = append(ips, ip)
+	}
+	return ips, nil
+}
+
+// certificateURIs returns the URI SANs of the config
+func certificateURIs(config *miniov2.CertificateConfig) ([]*url.URL, error) {
+	if config == nil {
+		return nil, nil
+	}
+	uris := make([]*url.URL, 0, len(config.URIs))
+	for _, uri := range config.URIs {
+		u, err := url.Parse(uri)
+		if err != nil || u.Scheme == "" {
+			return nil, fmt.Errorf("invalid URI %q in certConfig.uris", uri)
+		}
+		uris = append(uris, u)
+	}
+	return uris, nil
+}
+
+// certificateDuration returns the requested lifetime of the certificates, 0 lets the signer decide
+func certificateDuration(config *miniov2.CertificateConfig) time.Duration {
+	if config == nil || config.Duration == nil {
+		return 0
+	}
+	return config.Duration.Duration
+}
+
+// operatorCertificateConfig returns the profile of the certificates of the Operator services, such as STS, set
+// through environment variables since they are not part of a tenant
+func operatorCertificateConfig() *miniov2.CertificateConfig {
+	config := &miniov2.CertificateConfig{
+		KeyAlgorithm: env.Get(certificates.CertificateKeyAlgorithm, ""),
+	}
+	if value := env.Get(certificates.CertificateKeySize, ""); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			klog.Warningf("Ignoring invalid %s=%s: %v", certificates.CertificateKeySize, value, err)
+		}
+		config.KeySize = size
+	}
+	if value := env.Get(certificates.CertificateDuration, ""); value != "" {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			klog.Warningf("Ignoring invalid %s=%s: %v", certificates.CertificateDuration, value, err)
+		} else {
+			config.Duration = &metav1.Duration{Duration: duration}
+		}
+	}
+	return config
+}
@@ -0,0 +1,101 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+func Test_newCertificateKey(t *testing.T) {
+	tests := []struct {
+		config    *miniov2.CertificateConfig
+		algorithm string
+		size      int
+		wantErr   bool
+	}{
+		{config: nil, algorithm: miniov2.CertificateKeyAlgorithmECDSA, size: 256},
+		{config: &miniov2.CertificateConfig{KeyAlgorithm: "ECDSA", KeySize: 384}, algorithm: miniov2.CertificateKeyAlgorithmECDSA, size: 384},
+		{config: &miniov2.CertificateConfig{KeyAlgorithm: "RSA"}, algorithm: miniov2.CertificateKeyAlgorithmRSA, size: 2048},
+		{config: &miniov2.CertificateConfig{KeyAlgorithm: "Ed25519", KeySize: 256}, algorithm: miniov2.CertificateKeyAlgorithmEd25519},
+		{config: &miniov2.CertificateConfig{KeyAlgorithm: "RSA", KeySize: 1024}, wantErr: true},
+	}
+	for _, tt := range tests {
+		algorithm, size := certificateKeyParams(tt.config, miniov2.CertificateKeyAlgorithmECDSA)
+		key, err := newCertificateKey(algorithm, size)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("newCertificateKey(%s, %d) succeeded, want an error", algorithm, size)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if algorithm != tt.algorithm || size != tt.size || !certificateKeyMatches(key.Public(), tt.algorithm, tt.size) {
+			t.Errorf("newCertificateKey(%s, %d) doesn't match %s %d", algorithm, size, tt.algorithm, tt.size)
+		}
+		if certificateKeyMatches(key.Public(), miniov2.CertificateKeyAlgorithmECDSA, 521) {
+			t.Errorf("%s %d key matches ECDSA 521", algorithm, size)
+		}
+	}
+}
+
+func Test_generateMinIOCryptoDataProfile(t *testing.T) {
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "myminio", Namespace: "ns"},
+		Spec: miniov2.TenantSpec{
+			Pools: []miniov2.Pool{{Name: "pool-0", Servers: 4, VolumesPerServer: 4}},
+			CertConfig: &miniov2.CertificateConfig{
+				KeyAlgorithm: miniov2.CertificateKeyAlgorithmRSA,
+				KeySize:      3072,
+				Duration:     &metav1.Duration{Duration: 90 * 24 * time.Hour},
+				IPAddresses:  []string{"10.0.0.10"},
+				URIs:         []string{"spiffe://cluster.local/ns/ns/sa/minio"},
+				Subject:      &miniov2.CertificateSubject{OrganizationalUnits: []string{"storage"}},
+			},
+		},
+	}
+	tenant.EnsureDefaults()
+	_, csrBytes, err := generateMinIOCryptoData(tenant, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := x509.ParseCertificateRequest(csrBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !certificateKeyMatches(csr.PublicKey, miniov2.CertificateKeyAlgorithmRSA, 3072) {
+		t.Errorf("CSR key is not RSA 3072")
+	}
+	if len(csr.IPAddresses) != 1 || len(csr.URIs) != 1 || len(csr.Subject.OrganizationalUnit) != 1 {
+		t.Errorf("CSR = %v %v %v, want the SANs and subject of the profile", csr.IPAddresses, csr.URIs, csr.Subject)
+	}
+
+	tenant.Spec.CertConfig.IssuerRef = &miniov2.CertificateIssuerRef{Name: "ca-issuer"}
+	certificate := minioCertManagerCertificate(tenant, "")
+	spec := certificate.Object["spec"].(map[string]interface{})
+	privateKey := spec["privateKey"].(map[string]interface{})
+	if privateKey["algorithm"] != "RSA" || privateKey["size"] != int64(3072) || spec["duration"] != "2160h0m0s" {
+		t.Errorf("cert-manager Certificate spec = %v, want the profile", spec)
+	}
+}
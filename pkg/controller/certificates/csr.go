@@ -34,6 +34,18 @@ const (
 	CSRSignerName = "MINIO_OPERATOR_CSR_SIGNER_NAME"
 	// EKSCsrSignerName is the signer we should use on EKS after version 1.22
 	EKSCsrSignerName = "beta.eks.amazonaws.com/app-serving"
+	// CertificateAuthority is the ENV var selecting who signs the certificates generated by the operator, `kubernetes`
+	// (default) goes through the CSR API, `internal` uses a CA managed by the operator
+	CertificateAuthority = "MINIO_OPERATOR_CERTIFICATE_AUTHORITY"
+	// InternalCertificateAuthority is the value of CertificateAuthority selecting the CA managed by the operator
+	InternalCertificateAuthority = "internal"
+	// CertificateKeyAlgorithm is the ENV var setting the algorithm of the keys of the operator certificates, such as
+	// STS: `ECDSA` (default), `RSA` or `Ed25519`
+	CertificateKeyAlgorithm = "MINIO_OPERATOR_CERTIFICATE_KEY_ALGORITHM"
+	// CertificateKeySize is the ENV var setting the size in bits of the keys of the operator certificates
+	CertificateKeySize = "MINIO_OPERATOR_CERTIFICATE_KEY_SIZE"
+	// CertificateDuration is the ENV var setting the requested lifetime of the operator certificates
+	CertificateDuration = "MINIO_OPERATOR_CERTIFICATE_DURATION"
 )
 
 // CSRVersion represents the valid types of CSR that can be used
@@ -54,8 +66,18 @@ var (
 	defaultCsrSignerNameOnce sync.Once
 	csrSignerName            string
 	csrSignerNameOnce        sync.Once
+	internalCA               bool
+	internalCAOnce           sync.Once
 )
 
+// UseInternalCA returns true if the certificates are signed by the CA managed by the operator instead of the CSR API
+func UseInternalCA() bool {
+	internalCAOnce.Do(func() {
+		internalCA = strings.EqualFold(os.Getenv(CertificateAuthority), InternalCertificateAuthority)
+	})
+	return internalCA
+}
+
 func getDefaultCsrSignerName() string {
 	defaultCsrSignerNameOnce.Do(func() {
 		defaultCsrSignerName = os.Getenv(CSRSignerName)
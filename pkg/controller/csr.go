@@ -67,9 +67,15 @@ func isEqual(a, b []string) bool {
 	return true
 }
 
-// createCertificateSigningRequest is equivalent to kubectl create <csr-name> and kubectl approve csr <csr-name>
-func (c *Controller) createCertificateSigningRequest(ctx context.Context, labels map[string]string, name, namespace string, csrBytes []byte) error {
+// createCertificateSigningRequest is equivalent to kubectl create <csr-name> and kubectl approve csr <csr-name>, a
+// non zero duration requests the lifetime of the certificate
+func (c *Controller) createCertificateSigningRequest(ctx context.Context, labels map[string]string, name, namespace string, csrBytes []byte, duration time.Duration) error {
 	encodedBytes := pem.EncodeToMemory(&pem.Block{Type: csrType, Bytes: csrBytes})
+	var expirationSeconds *int32
+	if duration > 0 {
+		seconds := int32(duration.Seconds())
+		expirationSeconds = &seconds
+	}
 	// for the right set of csr configurations regarding CSR signers and Key usages please read:
 	// https://kubernetes.io/docs/reference/access-authn-authz/certificate-signing-requests/#kubernetes-signers
 	if certificates.GetCertificatesAPIVersion(c.kubeClientSet) == certificates.CSRV1 {
@@ -90,10 +96,11 @@ func (c *Controller) createCertificateSigningRequest(ctx context.Context, labels
 				Namespace: namespace,
 			},
 			Spec: certificatesV1.CertificateSigningRequestSpec{
-				SignerName: csrSignerName,
-				Request:    encodedBytes,
-				Groups:     []string{"system:authenticated", "system:nodes"},
-				Usages:     csrKeyUsage,
+				SignerName:        csrSignerName,
+				Request:           encodedBytes,
+				Groups:            []string{"system:authenticated", "system:nodes"},
+				Usages:            csrKeyUsage,
+				ExpirationSeconds: expirationSeconds,
 			},
 		}
 		ks, err := c.kubeClientSet.CertificatesV1().CertificateSigningRequests().Create(ctx, kubeCSR, metav1.CreateOptions{})
@@ -139,9 +146,10 @@ func (c *Controller) createCertificateSigningRequest(ctx context.Context, labels
 				Namespace: namespace,
 			},
 			Spec: certificatesV1beta1.CertificateSigningRequestSpec{
-				SignerName: &csrSignerName,
-				Request:    encodedBytes,
-				Usages:     csrKeyUsage,
+				SignerName:        &csrSignerName,
+				Request:           encodedBytes,
+				Usages:            csrKeyUsage,
+				ExpirationSeconds: expirationSeconds,
 			},
 		}
 
@@ -241,7 +249,7 @@ func (c *Controller) fetchCertificate(ctx context.Context, csrName string) ([]by
 	}
 }
 
-func (c *Controller) createSecret(ctx context.Context, tenant *miniov2.Tenant, labels map[string]string, secretName string, pkBytes, certBytes []byte) error {
+func (c *Controller) createSecret(ctx context.Context, tenant *miniov2.Tenant, labels map[string]string, secretName string, pkBytes, certBytes, caBytes []byte) error {
 	secret := &corev1.Secret{
 		Type: "Opaque",
 		ObjectMeta: metav1.ObjectMeta{
@@ -261,6 +269,10 @@ func (c *Controller) createSecret(ctx context.Context, tenant *miniov2.Tenant, l
 			certs.PublicCertFile: certBytes,
 		},
 	}
+	// certificates signed by the internal CA come with its bundle
+	if len(caBytes) > 0 {
+		secret.Data[certs.CAPublicCertFile] = caBytes
+	}
 	_, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Create(ctx, secret, metav1.CreateOptions{})
 	return err
 }
@@ -18,19 +18,38 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/pem"
 	"fmt"
+	"hash"
 	"math"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/minio/operator/pkg/certs"
 
 	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// CertificateExpiring is used as part of the Event 'reason' when a certificate expires within the alert threshold
+	CertificateExpiring = "CertificateExpiring"
+	// CertificateExpiryImminent is used as part of the Event 'reason' when a certificate expires in less than
+	// certificateExpiryImminentDays
+	CertificateExpiryImminent = "CertificateExpiryImminent"
+	// CertificateExpired is used as part of the Event 'reason' when a certificate has expired
+	CertificateExpired = "CertificateExpired"
+)
+
+// certificateExpiryImminentDays is the number of days under which an expiring certificate becomes imminent
+const certificateExpiryImminentDays = 10
+
 var secretTypePublicKeyNameMap = map[string]string{
 	"kubernetes.io/tls":        certs.TLSCertFile,
 	"cert-manager.io/v1":       certs.TLSCertFile,
@@ -38,53 +57,140 @@ var secretTypePublicKeyNameMap = map[string]string{
 	// Add newer secretTypes and their corresponding values in future
 }
 
-// getCustomCertificates
-func (c *Controller) getCustomCertificates(ctx context.Context, tenant *miniov2.Tenant) (customCertificates *miniov2.CustomCertificates, err error) {
+// expiringCertificate is a user provided certificate expiring within the alert threshold of the tenant
+type expiringCertificate struct {
+	reason  string
+	message string
+}
+
+// customCertificateSecrets is a group of user provided secrets reported together in the status of the tenant
+type customCertificateSecrets struct {
+	certType string
+	secrets  []*miniov2.LocalCertificateReference
+	// allKeys looks for certificates in every key of the secrets instead of the public key of their type
+	allKeys bool
+	// mountedByKES hashes the content of the secrets to restart the KES pods when they change
+	mountedByKES bool
+	result       *[]*miniov2.CustomCertificateConfig
+}
+
+// secretCertificates returns the certificates found in the public key of the secret, or in all its keys
+func secretCertificates(keyPair *corev1.Secret, secretType string, allKeys bool) ([]*x509.Certificate, error) {
+	keys := []string{certs.PublicCertFile}
+	if v, ok := secretTypePublicKeyNameMap[secretType]; ok {
+		keys = []string{v}
+	}
+	if allKeys {
+		keys = make([]string, 0, len(keyPair.Data))
+		for key := range keyPair.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+	} else if _, ok := keyPair.Data[keys[0]]; !ok {
+		return nil, fmt.Errorf("public key: %v not found inside certificate secret %v", keys[0], keyPair.Name)
+	}
+	var blocks []byte
+	for _, key := range keys {
+		rawCert := keyPair.Data[key]
+		for {
+			var block *pem.Block
+			block, rawCert = pem.Decode(rawCert)
+			if block == nil {
+				break
+			}
+			if block.Type == "CERTIFICATE" {
+				blocks = append(blocks, block.Bytes...)
+			}
+		}
+	}
+	// parse all certificates we found on this k8s secret
+	return x509.ParseCertificates(blocks)
+}
+
+// hashSecret writes the content of the secret to the hash in a stable order
+func hashSecret(h hash.Hash, secret *corev1.Secret) {
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	h.Write([]byte(secret.Name))
+	for _, key := range keys {
+		h.Write([]byte(key))
+		h.Write(secret.Data[key])
+	}
+}
+
+// checkCertificateExpiry returns the expiry of the certificate when it expires within the threshold
+func checkCertificateExpiry(certType, secretName string, expiresIn time.Duration, threshold int32) *expiringCertificate {
+	expiresInDays := int32(expiresIn.Hours() / 24)
+	switch {
+	case expiresIn <= 0:
+		return &expiringCertificate{CertificateExpired, fmt.Sprintf("%s certificate '%s' has expired", certType, secretName)}
+	case expiresInDays >= threshold:
+		return nil
+	case expiresInDays < certificateExpiryImminentDays:
+		return &expiringCertificate{CertificateExpiryImminent, fmt.Sprintf("%s certificate '%s' is expiring in %d days", certType, secretName, expiresInDays)}
+	default:
+		return &expiringCertificate{CertificateExpiring, fmt.Sprintf("%s certificate '%s' is expiring in %d days", certType, secretName, expiresInDays)}
+	}
+}
+
+// getCustomCertificates reads the certificates provided by the user to MinIO and KES, it returns their status, the
+// certificates expiring within the alert threshold of the tenant and the hash of the secrets mounted by the KES pods
+func (c *Controller) getCustomCertificates(ctx context.Context, tenant *miniov2.Tenant) (*miniov2.CustomCertificates, []expiringCertificate, error) {
 	namespace := tenant.Namespace
-	secretsMap := map[string][]*miniov2.LocalCertificateReference{
-		"Minio":    tenant.Spec.ExternalCertSecret,
-		"Client":   tenant.Spec.ExternalClientCertSecrets,
-		"MinioCAs": tenant.Spec.ExternalCaCertSecret,
+	customCertificates := &miniov2.CustomCertificates{}
+	kesHash := sha256.New()
+
+	clientSecrets := append([]*miniov2.LocalCertificateReference{}, tenant.Spec.ExternalClientCertSecrets...)
+	if tenant.ExternalClientCert() {
+		clientSecrets = append(clientSecrets, tenant.Spec.ExternalClientCertSecret)
+	}
+	groups := []customCertificateSecrets{
+		// MinIO reloads its certificates when the mounted secrets change, its pods are not restarted
+		{certType: "Minio", secrets: tenant.Spec.ExternalCertSecret, result: &customCertificates.Minio},
+		{certType: "Client", secrets: clientSecrets, result: &customCertificates.Client},
+		{certType: "MinioCAs", secrets: tenant.Spec.ExternalCaCertSecret, result: &customCertificates.MinioCAs},
 	}
-	var certificates []*miniov2.CustomCertificateConfig
-	var minioExternalServerCertificates []*miniov2.CustomCertificateConfig
-	var minioExternalClientCertificates []*miniov2.CustomCertificateConfig
-	var minioExternalCaCertificates []*miniov2.CustomCertificateConfig
-
-	for certType, secrets := range secretsMap {
-		certificates = nil
-		publicKey := certs.PublicCertFile
+	if tenant.KESExternalCert() {
+		groups = append(groups, customCertificateSecrets{
+			certType:     "KES",
+			secrets:      []*miniov2.LocalCertificateReference{tenant.Spec.KES.ExternalCertSecret},
+			mountedByKES: true,
+			result:       &customCertificates.KES,
+		})
+	}
+	if tenant.KESClientCert() {
+		// the KMS client certificate of KES is mounted as is, its keys are not known
+		groups = append(groups, customCertificateSecrets{
+			certType:     "KESClient",
+			secrets:      []*miniov2.LocalCertificateReference{tenant.Spec.KES.ClientCertSecret},
+			allKeys:      true,
+			mountedByKES: true,
+			result:       &customCertificates.KES,
+		})
+	}
+
+	threshold := tenant.GetCertExpiryAlertThreshold()
+	var expiring []expiringCertificate
+	for _, group := range groups {
 		// Iterate over TLS secrets and build array of CertificateInfo structure
 		// that will be used to display information about certs
-		for _, secret := range secrets {
+		for _, secret := range group.secrets {
 			keyPair, err := c.kubeClientSet.CoreV1().Secrets(namespace).Get(ctx, secret.Name, metav1.GetOptions{})
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
-			if v, ok := secretTypePublicKeyNameMap[secret.Type]; ok {
-				publicKey = v
+			if group.mountedByKES {
+				hashSecret(kesHash, keyPair)
 			}
-			var rawCert []byte
-			if _, ok := keyPair.Data[publicKey]; !ok {
-				return nil, fmt.Errorf("public key: %v not found inside certificate secret %v", publicKey, secret.Name)
-			}
-			rawCert = keyPair.Data[publicKey]
-			var blocks []byte
-			for {
-				var block *pem.Block
-				block, rawCert = pem.Decode(rawCert)
-				if block == nil {
-					break
-				}
-				if block.Type == "CERTIFICATE" {
-					blocks = append(blocks, block.Bytes...)
-				}
-			}
-			// parse all certificates we found on this k8s secret
-			certs, err := x509.ParseCertificates(blocks)
+			certs, err := secretCertificates(keyPair, secret.Type, group.allKeys)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
+			trackTenantCertificateSecret(tenant, keyPair)
+			recordCertificateExpiry(keyPair, CertificateTypeExternal, certs...)
 			for _, cert := range certs {
 				var domains []string
 				if cert.Subject.CommonName != "" {
@@ -100,7 +206,6 @@ func (c *Controller) getCustomCertificates(ctx context.Context, tenant *miniov2.
 						domains = append(domains, ip.String())
 					}
 				}
-				// Register event in case of certificate expiring
 				expiresIn := time.Until(cert.NotAfter)
 				expiresInDays := int32(expiresIn.Hours() / 24)
 				expiresInHours := int64(math.Mod(expiresIn.Hours(), 24))
@@ -108,16 +213,8 @@ func (c *Controller) getCustomCertificates(ctx context.Context, tenant *miniov2.
 				expiresInSeconds := int64(math.Mod(expiresIn.Seconds(), 60))
 				expiresInHuman := fmt.Sprintf("%v days, %v hours, %v minutes, %v seconds", expiresInDays, expiresInHours, expiresInMinutes, expiresInSeconds)
 
-				if tenant.Spec.CertExpiryAlertThreshold == nil || expiresInDays < *tenant.Spec.CertExpiryAlertThreshold {
-					if expiresInDays >= 10 && expiresInDays < 30 {
-						c.recorder.Event(tenant, corev1.EventTypeWarning, "CertificateExpiring", fmt.Sprintf("%s certificate '%s' is expiring in %d days", certType, secret.Name, expiresInDays))
-					}
-					if expiresInDays > 0 && expiresInDays < 10 {
-						c.recorder.Event(tenant, corev1.EventTypeWarning, "CertificateExpiryImminent", fmt.Sprintf("%s certificate '%s' is expiring in %d days", certType, secret.Name, expiresInDays))
-					}
-					if expiresIn <= 0 {
-						c.recorder.Event(tenant, corev1.EventTypeWarning, "CertificateExpired", fmt.Sprintf("%s certificate '%s' has expired", certType, secret.Name))
-					}
+				if expiry := checkCertificateExpiry(group.certType, secret.Name, expiresIn, threshold); expiry != nil {
+					expiring = append(expiring, *expiry)
 				}
 				if expiresIn > 0 && expiresIn < 24*time.Hour {
 					expiresInHuman = fmt.Sprintf("%v hours, %v minutes, and %v seconds", expiresInHours, expiresInMinutes, expiresInSeconds)
@@ -126,7 +223,7 @@ func (c *Controller) getCustomCertificates(ctx context.Context, tenant *miniov2.
 					expiresInHuman = "EXPIRED"
 				}
 
-				certificates = append(certificates, &miniov2.CustomCertificateConfig{
+				*group.result = append(*group.result, &miniov2.CustomCertificateConfig{
 					CertName:  secret.Name,
 					SerialNo:  cert.SerialNumber.String(),
 					Domains:   domains,
@@ -135,18 +232,45 @@ func (c *Controller) getCustomCertificates(ctx context.Context, tenant *miniov2.
 				})
 			}
 		}
-		switch certType {
-		case "Minio":
-			minioExternalServerCertificates = certificates
-		case "Client":
-			minioExternalClientCertificates = certificates
-		case "MinioCAs":
-			minioExternalCaCertificates = certificates
+	}
+	// the hash is only set when KES mounts user provided secrets, so that tenants without them are not restarted
+	for _, group := range groups {
+		if group.mountedByKES && len(group.secrets) > 0 {
+			customCertificates.KESSecretsHash = hex.EncodeToString(kesHash.Sum(nil))
+		}
+	}
+	return customCertificates, expiring, nil
+}
+
+// updateCertificatesExpiringCondition raises the CertificatesExpiring condition of the tenant when user provided
+// certificates expire within the alert threshold, an event is recorded for every certificate when the condition changes
+func (c *Controller) updateCertificatesExpiringCondition(tenant *miniov2.Tenant, expiring []expiringCertificate) {
+	condition := metav1.Condition{
+		Type:               miniov2.TenantConditionCertificatesExpiring,
+		Status:             metav1.ConditionFalse,
+		Reason:             "CertificatesValid",
+		Message:            fmt.Sprintf("No certificate expires within %d days", tenant.GetCertExpiryAlertThreshold()),
+		ObservedGeneration: tenant.Generation,
+	}
+	if len(expiring) > 0 {
+		messages := make([]string, 0, len(expiring))
+		condition.Reason = CertificateExpiring
+		for _, expiry := range expiring {
+			messages = append(messages, expiry.message)
+			if expiry.reason == CertificateExpired {
+				condition.Reason = CertificateExpired
+			}
+		}
+		sort.Strings(messages)
+		condition.Status = metav1.ConditionTrue
+		condition.Message = strings.Join(messages, ", ")
+	}
+	previous := meta.FindStatusCondition(tenant.Status.Conditions, condition.Type)
+	changed := condition.Status == metav1.ConditionTrue && (previous == nil || previous.Message != condition.Message)
+	meta.SetStatusCondition(&tenant.Status.Conditions, condition)
+	if changed {
+		for _, expiry := range expiring {
+			c.recorder.Event(tenant, corev1.EventTypeWarning, expiry.reason, expiry.message)
 		}
 	}
-	return &miniov2.CustomCertificates{
-		Client:   minioExternalClientCertificates,
-		Minio:    minioExternalServerCertificates,
-		MinioCAs: minioExternalCaCertificates,
-	}, nil
 }
@@ -0,0 +1,113 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/certs"
+)
+
+// newExternalCertSecret returns a kubernetes.io/tls secret holding a certificate valid for the duration
+func newExternalCertSecret(t *testing.T, name string, duration time.Duration) *corev1.Secret {
+	t.Helper()
+	cert, _, err := newCACertificate(name, nil, nil, time.Now(), duration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			certs.TLSCertFile: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}),
+		},
+	}
+}
+
+func Test_getCustomCertificates(t *testing.T) {
+	ctx := context.Background()
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "myminio", Namespace: "ns"},
+		Spec: miniov2.TenantSpec{
+			ExternalCertSecret: []*miniov2.LocalCertificateReference{{Name: "minio-tls", Type: "kubernetes.io/tls"}},
+			KES: &miniov2.KESConfig{
+				ExternalCertSecret: &miniov2.LocalCertificateReference{Name: "kes-tls", Type: "kubernetes.io/tls"},
+			},
+		},
+	}
+	recorder := record.NewFakeRecorder(10)
+	controller := Controller{
+		kubeClientSet: fake.NewSimpleClientset(
+			newExternalCertSecret(t, "minio-tls", 5*24*time.Hour),
+			newExternalCertSecret(t, "kes-tls", 365*24*time.Hour),
+		),
+		recorder: recorder,
+	}
+
+	customCertificates, expiring, err := controller.getCustomCertificates(ctx, tenant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(customCertificates.Minio) != 1 || len(customCertificates.KES) != 1 {
+		t.Fatalf("customCertificates = %+v, want the MinIO and KES certificates", customCertificates)
+	}
+	if len(expiring) != 1 || expiring[0].reason != CertificateExpiryImminent {
+		t.Fatalf("expiring = %v, want the MinIO certificate expiring imminently", expiring)
+	}
+	if customCertificates.KESSecretsHash == "" {
+		t.Errorf("KES secrets hash is not set")
+	}
+
+	controller.updateCertificatesExpiringCondition(tenant, expiring)
+	if !meta.IsStatusConditionTrue(tenant.Status.Conditions, miniov2.TenantConditionCertificatesExpiring) {
+		t.Errorf("conditions = %v, want CertificatesExpiring raised", tenant.Status.Conditions)
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("%d events recorded, want 1", len(recorder.Events))
+	}
+	// the event is not recorded again while the condition is unchanged
+	controller.updateCertificatesExpiringCondition(tenant, expiring)
+	if len(recorder.Events) != 1 {
+		t.Errorf("%d events recorded, want 1", len(recorder.Events))
+	}
+
+	// renewing the MinIO certificate does not change the hash of the KES pods
+	if _, err = controller.kubeClientSet.CoreV1().Secrets("ns").Update(ctx, newExternalCertSecret(t, "minio-tls", 365*24*time.Hour), metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	renewed, expiring, err := controller.getCustomCertificates(ctx, tenant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renewed.KESSecretsHash != customCertificates.KESSecretsHash {
+		t.Errorf("KES secrets hash changed from %q to %q, want unchanged", customCertificates.KESSecretsHash, renewed.KESSecretsHash)
+	}
+	controller.updateCertificatesExpiringCondition(tenant, expiring)
+	if meta.IsStatusConditionTrue(tenant.Status.Conditions, miniov2.TenantConditionCertificatesExpiring) {
+		t.Errorf("CertificatesExpiring is still raised after the renewal")
+	}
+}
@@ -0,0 +1,189 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/controller/certificates"
+)
+
+// minioDomainDNSNames returns the SANs of the certificate of a domain, the wildcard bucket subdomains are included for
+// the MinIO domains when BucketDNS is enabled
+func minioDomainDNSNames(tenant *miniov2.Tenant, domain string) []string {
+	dnsNames := []string{domain}
+	if tenant.BucketDNS() && slices.Contains(tenant.GetDomainHosts(), domain) {
+		dnsNames = append(dnsNames, "*."+domain)
+	}
+	return dnsNames
+}
+
+// minioDomainCertManagerCertificate returns the Certificate of a domain of the tenant
+func minioDomainCertManagerCertificate(tenant *miniov2.Tenant, index int, domain string) *unstructured.Unstructured {
+	return newCertManagerCertificate(tenant, tenant.MinIODomainTLSSecretName(index), tenant.MinIOPodLabels(),
+		domain, minioDomainDNSNames(tenant, domain),
+		[]string{"digital signature", "key encipherment", "server auth"},
+		certManagerPrivateKey(tenant.Spec.CertConfig, map[string]interface{}{"algorithm": "ECDSA", "size": int64(256)}))
+}
+
+// certificateCoversDNSNames returns true if the certificate of the secret holds all the DNS names
+func (c *Controller) certificateCoversDNSNames(secret *corev1.Secret, dnsNames []string) bool {
+	publicKey, _ := c.getKeyNames(secret)
+	block, _ := pem.Decode(secret.Data[publicKey])
+	if block == nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false
+	}
+	for _, dnsName := range dnsNames {
+		if !slices.Contains(cert.DNSNames, dnsName) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkMinIODomainCertificates issues the certificates of the domains of the tenant, renews them like the AutoCert
+// certificate and removes the ones of domains no longer configured
+func (c *Controller) checkMinIODomainCertificates(ctx context.Context, tenant *miniov2.Tenant) error {
+	domains := tenant.CertificateDomains()
+	for index, domain := range domains {
+		if tenant.CertManagerIssuer() {
+			if err := c.checkCertManagerCertificate(ctx, tenant, minioDomainCertManagerCertificate(tenant, index, domain), StatusWaitingMinIOCert); err != nil {
+				return err
+			}
+			continue
+		}
+		dnsNames := minioDomainDNSNames(tenant, domain)
+		secret, err := c.getCertificateSecret(ctx, tenant.Namespace, tenant.MinIODomainTLSSecretName(index))
+		if k8serrors.IsNotFound(err) {
+			if err = c.createMinIODomainCertificate(ctx, tenant, index, dnsNames); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		keyAlgorithm, keySize := certificateKeyParams(tenant.Spec.CertConfig, miniov2.CertificateKeyAlgorithmECDSA)
+		needsRenewal, err := c.tenantCertNeedsRenewal(tenant, secret, keyAlgorithm, keySize)
+		if err != nil {
+			klog.Warningf("Cannot check secret %s/%s for renewal (will be renewing): %v", secret.Namespace, secret.Name, err)
+			needsRenewal = true
+		}
+		if !needsRenewal && c.certificateCoversDNSNames(secret, dnsNames) {
+			continue
+		}
+		klog.Infof("'%s/%s' Issuing the certificate of domain %s again", tenant.Namespace, tenant.Name, domain)
+		if err = c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		if err = c.createMinIODomainCertificate(ctx, tenant, index, dnsNames); err != nil {
+			return err
+		}
+	}
+	return c.deleteStaleMinIODomainCertificates(ctx, tenant, len(domains))
+}
+
+// createMinIODomainCertificate signs a certificate for the DNS names of a domain through the internal CA or the
+// Kubernetes CSR API, and stores it in the secret of the domain
+func (c *Controller) createMinIODomainCertificate(ctx context.Context, tenant *miniov2.Tenant, index int, dnsNames []string) error {
+	privKeysBytes, csrBytes, err := generateServerCryptoData(tenant, dnsNames)
+	if err != nil {
+		klog.Errorf("Private Key and CSR generation failed with error: %v", err)
+		return err
+	}
+
+	var certBytes, caBytes []byte
+	if certificates.UseInternalCA() {
+		certBytes, caBytes, err = c.signCSRWithInternalCA(ctx, csrBytes, certificateDuration(tenant.Spec.CertConfig))
+		if err != nil {
+			c.recorder.Event(tenant, corev1.EventTypeWarning, "CertFailed", fmt.Sprintf("Certificate of domain %s failed to be signed by the internal CA: %s", dnsNames[0], err))
+			return err
+		}
+	} else {
+		csrName := tenant.MinIODomainCSRName(index)
+		// a CSR left by a previous attempt holds other DNS names or was already consumed
+		if err = c.deleteCSR(ctx, csrName); err != nil {
+			return err
+		}
+		if err = c.createCertificateSigningRequest(ctx, tenant.MinIOPodLabels(), csrName, tenant.Namespace, csrBytes, certificateDuration(tenant.Spec.CertConfig)); err != nil {
+			klog.Errorf("Unexpected error during the creation of the csr/%s: %v", csrName, err)
+			return err
+		}
+		if certBytes, err = c.fetchCertificate(ctx, csrName); err != nil {
+			c.recorder.Event(tenant, corev1.EventTypeWarning, "CSRFailed", fmt.Sprintf("Certificate of domain %s failed to be issued: %s", dnsNames[0], err))
+			return err
+		}
+	}
+	c.recorder.Event(tenant, corev1.EventTypeNormal, "CertIssued", fmt.Sprintf("Certificate of domain %s issued", dnsNames[0]))
+
+	encodedPrivKey := pem.EncodeToMemory(&pem.Block{Type: privateKeyType, Bytes: privKeysBytes})
+	return c.createSecret(ctx, tenant, tenant.MinIOPodLabels(), tenant.MinIODomainTLSSecretName(index), encodedPrivKey, certBytes, caBytes)
+}
+
+// deleteStaleMinIODomainCertificates removes the certificates of the domains from index onwards, the secrets are
+// numbered contiguously so the first missing one ends the cleanup
+func (c *Controller) deleteStaleMinIODomainCertificates(ctx context.Context, tenant *miniov2.Tenant, index int) error {
+	for ; ; index++ {
+		name := tenant.MinIODomainTLSSecretName(index)
+		found := false
+		if tenant.CertManagerIssuer() {
+			certificate := &unstructured.Unstructured{}
+			certificate.SetGroupVersionKind(certManagerCertificateGVK)
+			certificate.SetName(name)
+			certificate.SetNamespace(tenant.Namespace)
+			err := c.k8sClient.Delete(ctx, certificate)
+			if err == nil {
+				found = true
+			} else if !k8serrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+				return err
+			}
+		}
+		// the secrets written by cert-manager are not owned by the tenant but carry its labels
+		secret, err := c.getCertificateSecret(ctx, tenant.Namespace, name)
+		if err == nil && secret.Labels[miniov2.TenantLabel] == tenant.Name {
+			klog.Infof("'%s/%s' Deleting the certificate secret %s of a removed domain", tenant.Namespace, tenant.Name, name)
+			if err = c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+			found = true
+		} else if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		if !found {
+			return nil
+		}
+		if err = c.deleteCSR(ctx, tenant.MinIODomainCSRName(index)); err != nil {
+			return err
+		}
+	}
+}
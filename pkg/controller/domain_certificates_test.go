@@ -0,0 +1,76 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+func Test_checkMinIODomainCertificates(t *testing.T) {
+	ctx := context.Background()
+	autoCert := true
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "myminio", Namespace: "ns"},
+		Spec: miniov2.TenantSpec{
+			RequestAutoCert: &autoCert,
+			CertConfig:      &miniov2.CertificateConfig{DomainCertificates: true},
+			Features: &miniov2.Features{
+				BucketDNS: true,
+				Domains: &miniov2.TenantDomains{
+					Minio:   []string{"s3.example.com"},
+					Console: "console.example.com",
+				},
+			},
+		},
+	}
+	if dnsNames := minioDomainDNSNames(tenant, "s3.example.com"); !reflect.DeepEqual(dnsNames, []string{"s3.example.com", "*.s3.example.com"}) {
+		t.Errorf("DNS names of the MinIO domain = %v, want the wildcard bucket subdomains", dnsNames)
+	}
+	if dnsNames := minioDomainDNSNames(tenant, "console.example.com"); !reflect.DeepEqual(dnsNames, []string{"console.example.com"}) {
+		t.Errorf("DNS names of the Console domain = %v, want only the domain", dnsNames)
+	}
+
+	domainSecret := func(index int) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:      tenant.MinIODomainTLSSecretName(index),
+			Namespace: tenant.Namespace,
+			Labels:    tenant.MinIOPodLabels(),
+		}}
+	}
+	controller := Controller{kubeClientSet: fake.NewSimpleClientset(domainSecret(0), domainSecret(1), domainSecret(2))}
+
+	// the secrets of the domains no longer configured are removed
+	tenant.Spec.Features.Domains.Console = ""
+	if err := controller.deleteStaleMinIODomainCertificates(ctx, tenant, len(tenant.CertificateDomains())); err != nil {
+		t.Fatal(err)
+	}
+	for index, want := range []bool{true, false, false} {
+		_, err := controller.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Get(ctx, tenant.MinIODomainTLSSecretName(index), metav1.GetOptions{})
+		if exists := !k8serrors.IsNotFound(err); exists != want {
+			t.Errorf("secret of domain %d exists = %t, want %t", index, exists, want)
+		}
+	}
+}
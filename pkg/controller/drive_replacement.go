@@ -0,0 +1,317 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/minio/madmin-go/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+const (
+	// DriveReplacementBlockedReason is the event reason recorded when a drive can't be replaced safely
+	DriveReplacementBlockedReason = "DriveReplacementBlocked"
+	// DriveReplacementStartedReason is the event reason recorded when the PVC and pod of a drive are recreated
+	DriveReplacementStartedReason = "DriveReplacementStarted"
+	// DriveReplacedReason is the event reason recorded once MinIO healed a replaced drive
+	DriveReplacedReason = "DriveReplaced"
+)
+
+// erasureSetKey identifies an erasure set of the tenant
+type erasureSetKey struct {
+	pool, set int
+}
+
+// tenantDrives indexes the drives reported by MinIO by the PVC backing them, along with the size of each erasure set
+func tenantDrives(tenant *miniov2.Tenant, storageInfo madmin.StorageInfo) (map[string]madmin.Disk, map[erasureSetKey]int) {
+	drives := map[string]madmin.Disk{}
+	setSizes := map[erasureSetKey]int{}
+	for _, disk := range storageInfo.Disks {
+		if disk.PoolIndex < 0 || disk.PoolIndex >= len(tenant.Spec.Pools) {
+			continue
+		}
+		setSizes[erasureSetKey{disk.PoolIndex, disk.SetIndex}]++
+		pvc := drivePVC(tenant, &tenant.Spec.Pools[disk.PoolIndex], drivePod(disk.Endpoint), drivePath(disk))
+		if pvc != "" {
+			drives[pvc] = disk
+		}
+	}
+	return drives, setSizes
+}
+
+// syncDriveReplacements drives the replacement of the drives whose PVC or pod is annotated with
+// `operator.min.io/replace-drive=true`. A replacement only starts when every erasure set served by the pod of the drive
+// keeps its write quorum without the drives of the pod, the PVC is then deleted before the pod so the StatefulSet recreates both with a fresh volume. The replacement
+// is tracked in the tenant status until MinIO healed the fresh drive.
+func (c *Controller) syncDriveReplacements(ctx context.Context, tenant *miniov2.Tenant, adminClnt *madmin.AdminClient, pods []corev1.Pod, storageInfo madmin.StorageInfo) error {
+	pvcList, err := c.kubeClientSet.CoreV1().PersistentVolumeClaims(tenant.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", miniov2.TenantLabel, tenant.Name),
+	})
+	if err != nil {
+		return err
+	}
+	pvcs := make(map[string]*corev1.PersistentVolumeClaim, len(pvcList.Items))
+	for i := range pvcList.Items {
+		pvcs[pvcList.Items[i].Name] = &pvcList.Items[i]
+	}
+	podsByName := make(map[string]*corev1.Pod, len(pods))
+	podsByClaim := map[string]string{}
+	for i := range pods {
+		podsByName[pods[i].Name] = &pods[i]
+		for _, volume := range pods[i].Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				podsByClaim[volume.PersistentVolumeClaim.ClaimName] = pods[i].Name
+			}
+		}
+	}
+
+	// pick up the new requests, a pod requests the replacement of all its drives
+	replacements := tenant.Status.DriveReplacements
+	tracked := map[string]struct{}{}
+	for _, r := range replacements {
+		tracked[r.PVC] = struct{}{}
+	}
+	requested := map[string]struct{}{}
+	request := func(pvc string) {
+		requested[pvc] = struct{}{}
+		if _, ok := tracked[pvc]; ok {
+			return
+		}
+		tracked[pvc] = struct{}{}
+		replacements = append(replacements, miniov2.DriveReplacement{
+			Pod:   podsByClaim[pvc],
+			PVC:   pvc,
+			Phase: miniov2.DriveReplacementPending,
+		})
+	}
+	for _, pvc := range pvcList.Items {
+		if pvc.Annotations[miniov2.ReplaceDriveAnnotation] == "true" {
+			request(pvc.Name)
+		}
+	}
+	for _, pod := range pods {
+		if pod.Annotations[miniov2.ReplaceDriveAnnotation] != "true" {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim == nil {
+				continue
+			}
+			if _, ok := pvcs[volume.PersistentVolumeClaim.ClaimName]; ok {
+				request(volume.PersistentVolumeClaim.ClaimName)
+			}
+		}
+	}
+
+	drives, setSizes := tenantDrives(tenant, storageInfo)
+	// the pod of a drive is deleted along with its PVC, which takes all the drives of the pod offline in every erasure
+	// set it serves
+	podDrives := map[string][]string{}
+	for pvc, disk := range drives {
+		pod := drivePod(disk.Endpoint)
+		podDrives[pod] = append(podDrives[pod], pvc)
+	}
+	for pod := range podDrives {
+		sort.Strings(podDrives[pod])
+	}
+	// online drives per erasure set, the drives being replaced and the drives of the pods being recreated are not
+	// accounted even if MinIO still reports them
+	offline := map[string]struct{}{}
+	for _, r := range replacements {
+		switch r.Phase {
+		case miniov2.DriveReplacementPending, miniov2.DriveReplacementBlocked:
+			continue
+		case miniov2.DriveReplacementReplacing:
+			for _, pvc := range podDrives[r.Pod] {
+				offline[pvc] = struct{}{}
+			}
+		}
+		offline[r.PVC] = struct{}{}
+	}
+	online := map[erasureSetKey]int{}
+	for pvc, disk := range drives {
+		if _, ok := offline[pvc]; !ok && disk.State == madmin.DriveStateOk {
+			online[erasureSetKey{disk.PoolIndex, disk.SetIndex}]++
+		}
+	}
+
+	var healState *madmin.BgHealState
+	var remaining []miniov2.DriveReplacement
+	for _, r := range replacements {
+		disk, reported := drives[r.PVC]
+		switch r.Phase {
+		case miniov2.DriveReplacementPending, miniov2.DriveReplacementBlocked:
+			if _, ok := requested[r.PVC]; !ok {
+				// the annotation was removed before the replacement started
+				continue
+			}
+			message := ""
+			if !reported {
+				message = "MinIO doesn't report the drive backed by the PVC"
+			} else {
+				// the drives of the pod that are still online, grouped by erasure set
+				pod := drivePod(disk.Endpoint)
+				var keys []erasureSetKey
+				lost := map[erasureSetKey]int{}
+				for _, pvc := range podDrives[pod] {
+					if _, ok := offline[pvc]; ok || drives[pvc].State != madmin.DriveStateOk {
+						continue
+					}
+					key := erasureSetKey{drives[pvc].PoolIndex, drives[pvc].SetIndex}
+					if _, ok := lost[key]; !ok {
+						keys = append(keys, key)
+					}
+					lost[key]++
+				}
+				for _, key := range keys {
+					available := online[key] - lost[key]
+					writeQuorum := int(erasureSetWriteQuorum(setSizes[key], poolParity(storageInfo, key.pool)))
+					if available < writeQuorum {
+						message = fmt.Sprintf("deleting pod %s would leave erasure set %d of pool %s with %d drives online, below its write quorum of %d",
+							pod, key.set, tenant.Spec.Pools[key.pool].Name, available, writeQuorum)
+						break
+					}
+				}
+				if message == "" {
+					for _, key := range keys {
+						online[key] -= lost[key]
+					}
+					for _, pvc := range podDrives[pod] {
+						offline[pvc] = struct{}{}
+					}
+				}
+			}
+			if message != "" {
+				if r.Phase != miniov2.DriveReplacementBlocked || r.Message != message {
+					c.recorder.Event(tenant, corev1.EventTypeWarning, DriveReplacementBlockedReason, fmt.Sprintf("Can't replace drive %s: %s", r.PVC, message))
+				}
+				r.Phase = miniov2.DriveReplacementBlocked
+				r.Message = message
+				break
+			}
+			started, err := c.startedDriveReplacement(ctx, tenant, r.PVC)
+			if err != nil {
+				r.Message = err.Error()
+				break
+			}
+			if started != nil {
+				// the replacement was started since the tenant was read, the PVC must not be deleted twice
+				r = *started
+				break
+			}
+			if err := c.startDriveReplacement(ctx, tenant, &r, pvcs[r.PVC], podsByName[r.Pod]); err != nil {
+				klog.Errorf("'%s/%s' Failed to replace drive %s: %v", tenant.Namespace, tenant.Name, r.PVC, err)
+				r.Message = err.Error()
+			}
+		case miniov2.DriveReplacementReplacing:
+			pvc, ok := pvcs[r.PVC]
+			switch {
+			case ok && string(pvc.UID) != r.PVCUID:
+				r.Phase = miniov2.DriveReplacementWaitingForDrive
+				r.Message = ""
+			case !ok:
+				// a pod recreated while its old PVC was terminating stays pending, deleting it again lets the
+				// StatefulSet recreate the PVC
+				if pod, ok := podsByName[r.Pod]; ok && pod.Status.Phase == corev1.PodPending && pod.DeletionTimestamp == nil {
+					if err := c.kubeClientSet.CoreV1().Pods(tenant.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+						r.Message = err.Error()
+					}
+				}
+			}
+		case miniov2.DriveReplacementWaitingForDrive:
+			if reported && disk.State == madmin.DriveStateOk {
+				r.Phase = miniov2.DriveReplacementHealing
+			}
+		case miniov2.DriveReplacementHealing:
+			if healState == nil {
+				state, err := adminClnt.BackgroundHealStatus(ctx)
+				if err != nil {
+					klog.Infof("'%s/%s' Failed to get the heal status: %v", tenant.Namespace, tenant.Name, err)
+					remaining = append(remaining, r)
+					continue
+				}
+				healState = &state
+			}
+			var healInfo *madmin.HealingDisk
+			for _, set := range healState.Sets {
+				for _, d := range set.Disks {
+					if reported && d.Endpoint == disk.Endpoint && d.HealInfo != nil {
+						healInfo = d.HealInfo
+					}
+				}
+			}
+			if healInfo != nil {
+				r.HealedBytes = safeToInt64(healInfo.BytesDone)
+				r.TotalBytes = safeToInt64(healInfo.ObjectsTotalSize)
+			}
+			if reported && disk.State == madmin.DriveStateOk && !disk.Healing && (healInfo == nil || healInfo.Finished) {
+				c.recorder.Event(tenant, corev1.EventTypeNormal, DriveReplacedReason, fmt.Sprintf("Drive %s of pod %s was replaced and healed", r.PVC, r.Pod))
+				continue
+			}
+		}
+		remaining = append(remaining, r)
+	}
+	tenant.Status.DriveReplacements = remaining
+	return nil
+}
+
+// startedDriveReplacement re-reads the drive replacements of the tenant from the API right before a drive is replaced,
+// it returns the replacement of the PVC when it was started since the tenant was read
+func (c *Controller) startedDriveReplacement(ctx context.Context, tenant *miniov2.Tenant, pvc string) (*miniov2.DriveReplacement, error) {
+	latest, err := c.minioClientSet.MinioV2().Tenants(tenant.Namespace).Get(ctx, tenant.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range latest.Status.DriveReplacements {
+		if r.PVC == pvc && r.Phase != miniov2.DriveReplacementPending && r.Phase != miniov2.DriveReplacementBlocked {
+			return &r, nil
+		}
+	}
+	return nil, nil
+}
+
+// startDriveReplacement deletes the PVC of the drive and then its pod, the PVC is only released once the pod is gone
+// and the StatefulSet recreates the pod along with a fresh PVC
+func (c *Controller) startDriveReplacement(ctx context.Context, tenant *miniov2.Tenant, r *miniov2.DriveReplacement, pvc *corev1.PersistentVolumeClaim, pod *corev1.Pod) error {
+	if pvc != nil {
+		r.PVCUID = string(pvc.UID)
+		if err := c.kubeClientSet.CoreV1().PersistentVolumeClaims(tenant.Namespace).Delete(ctx, pvc.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	if pod != nil {
+		if err := c.kubeClientSet.CoreV1().Pods(tenant.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	now := metav1.Now()
+	r.StartedAt = &now
+	r.Phase = miniov2.DriveReplacementReplacing
+	r.Message = ""
+	klog.Infof("'%s/%s' Replacing drive %s of pod %s", tenant.Namespace, tenant.Name, r.PVC, r.Pod)
+	c.recorder.Event(tenant, corev1.EventTypeNormal, DriveReplacementStartedReason, fmt.Sprintf("Recreating PVC %s and pod %s with a fresh drive", r.PVC, r.Pod))
+	return nil
+}
@@ -0,0 +1,288 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	miniofake "github.com/minio/operator/pkg/client/clientset/versioned/fake"
+)
+
+func Test_syncDriveReplacements(t *testing.T) {
+	ctx := context.Background()
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+		},
+		Spec: miniov2.TenantSpec{
+			Mountpath: "/export",
+			Pools: []miniov2.Pool{
+				{
+					Name:                "pool-0",
+					Servers:             4,
+					VolumesPerServer:    1,
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+				},
+			},
+		},
+	}
+
+	var objects []runtime.Object
+	var pods []corev1.Pod
+	for i := 0; i < 4; i++ {
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("data0-myminio-pool-0-%d", i),
+				Namespace: tenant.Namespace,
+				UID:       types.UID(fmt.Sprintf("uid-%d", i)),
+				Labels:    map[string]string{miniov2.TenantLabel: tenant.Name},
+			},
+		}
+		if i == 0 {
+			pvc.Annotations = map[string]string{miniov2.ReplaceDriveAnnotation: "true"}
+		}
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("myminio-pool-0-%d", i),
+				Namespace: tenant.Namespace,
+				Labels:    map[string]string{miniov2.TenantLabel: tenant.Name},
+			},
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{
+					Name: "data0",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.Name},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		objects = append(objects, pvc, pod.DeepCopy())
+		pods = append(pods, pod)
+	}
+	storageInfo := func(offline int) madmin.StorageInfo {
+		var storageInfo madmin.StorageInfo
+		storageInfo.Backend.StandardSCParity = 2
+		for i := 0; i < 4; i++ {
+			state := madmin.DriveStateOk
+			if i == offline {
+				state = madmin.DriveStateOffline
+			}
+			storageInfo.Disks = append(storageInfo.Disks, madmin.Disk{
+				Endpoint:  fmt.Sprintf("https://myminio-pool-0-%d.myminio-hl.ns.svc.cluster.local:9000/export", i),
+				DrivePath: "/export",
+				State:     state,
+			})
+		}
+		return storageInfo
+	}
+
+	kubeClient := fake.NewSimpleClientset(objects...)
+	recorder := record.NewFakeRecorder(10)
+	controller := Controller{
+		kubeClientSet:  kubeClient,
+		minioClientSet: miniofake.NewSimpleClientset(tenant.DeepCopy()),
+		recorder:       recorder,
+	}
+
+	// with a drive offline the set would lose its write quorum of 3
+	if err := controller.syncDriveReplacements(ctx, tenant, nil, pods, storageInfo(3)); err != nil {
+		t.Fatal(err)
+	}
+	if len(tenant.Status.DriveReplacements) != 1 || tenant.Status.DriveReplacements[0].Phase != miniov2.DriveReplacementBlocked {
+		t.Fatalf("replacements = %+v, want a blocked replacement", tenant.Status.DriveReplacements)
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("events = %d, want 1", len(recorder.Events))
+	}
+
+	// once all drives are online the PVC and the pod are deleted
+	if err := controller.syncDriveReplacements(ctx, tenant, nil, pods, storageInfo(-1)); err != nil {
+		t.Fatal(err)
+	}
+	replacement := tenant.Status.DriveReplacements[0]
+	if replacement.Phase != miniov2.DriveReplacementReplacing || replacement.PVCUID != "uid-0" || replacement.Pod != "myminio-pool-0-0" {
+		t.Fatalf("replacement = %+v, want replacing uid-0", replacement)
+	}
+	if _, err := kubeClient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, "data0-myminio-pool-0-0", metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("PVC was not deleted: %v", err)
+	}
+	if _, err := kubeClient.CoreV1().Pods("ns").Get(ctx, "myminio-pool-0-0", metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("pod was not deleted: %v", err)
+	}
+
+	// the StatefulSet recreates the PVC, then MinIO picks up the fresh drive
+	if _, err := kubeClient.CoreV1().PersistentVolumeClaims("ns").Create(ctx, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "data0-myminio-pool-0-0",
+			Namespace: "ns",
+			UID:       "uid-fresh",
+			Labels:    map[string]string{miniov2.TenantLabel: tenant.Name},
+		},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := controller.syncDriveReplacements(ctx, tenant, nil, pods, storageInfo(0)); err != nil {
+		t.Fatal(err)
+	}
+	if phase := tenant.Status.DriveReplacements[0].Phase; phase != miniov2.DriveReplacementWaitingForDrive {
+		t.Errorf("phase = %s, want %s", phase, miniov2.DriveReplacementWaitingForDrive)
+	}
+	if err := controller.syncDriveReplacements(ctx, tenant, nil, pods, storageInfo(-1)); err != nil {
+		t.Fatal(err)
+	}
+	if phase := tenant.Status.DriveReplacements[0].Phase; phase != miniov2.DriveReplacementHealing {
+		t.Errorf("phase = %s, want %s", phase, miniov2.DriveReplacementHealing)
+	}
+
+	// a replacement started by another check since the tenant was read doesn't delete the PVC again
+	started := tenant.DeepCopy()
+	started.Status.DriveReplacements = []miniov2.DriveReplacement{{
+		Pod:    "myminio-pool-0-0",
+		PVC:    "data0-myminio-pool-0-0",
+		PVCUID: "uid-0",
+		Phase:  miniov2.DriveReplacementReplacing,
+	}}
+	kubeClient = fake.NewSimpleClientset(objects...)
+	controller.kubeClientSet = kubeClient
+	controller.minioClientSet = miniofake.NewSimpleClientset(started)
+	tenant.Status.DriveReplacements = nil
+	if err := controller.syncDriveReplacements(ctx, tenant, nil, pods, storageInfo(-1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kubeClient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, "data0-myminio-pool-0-0", metav1.GetOptions{}); err != nil {
+		t.Errorf("PVC was deleted twice: %v", err)
+	}
+	if len(tenant.Status.DriveReplacements) != 1 || tenant.Status.DriveReplacements[0].Phase != miniov2.DriveReplacementReplacing {
+		t.Errorf("replacements = %+v, want the replacement started by the other check", tenant.Status.DriveReplacements)
+	}
+}
+
+func Test_syncDriveReplacementsVolumesPerServer(t *testing.T) {
+	ctx := context.Background()
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+		},
+		Spec: miniov2.TenantSpec{
+			Mountpath: "/export",
+			Pools: []miniov2.Pool{
+				{
+					Name:                "pool-0",
+					Servers:             4,
+					VolumesPerServer:    4,
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+				},
+			},
+		},
+	}
+
+	// a single erasure set of 16 drives with a parity of 4 has a write quorum of 12, it survives the loss of one pod
+	var objects []runtime.Object
+	var pods []corev1.Pod
+	var storageInfo madmin.StorageInfo
+	storageInfo.Backend.StandardSCParity = 4
+	for i := 0; i < 4; i++ {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("myminio-pool-0-%d", i),
+				Namespace: tenant.Namespace,
+				Labels:    map[string]string{miniov2.TenantLabel: tenant.Name},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		for v := 0; v < 4; v++ {
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("data%d-myminio-pool-0-%d", v, i),
+					Namespace: tenant.Namespace,
+					UID:       types.UID(fmt.Sprintf("uid-%d-%d", v, i)),
+					Labels:    map[string]string{miniov2.TenantLabel: tenant.Name},
+				},
+			}
+			// a drive is replaced on two different pods
+			if v == 0 && i < 2 {
+				pvc.Annotations = map[string]string{miniov2.ReplaceDriveAnnotation: "true"}
+			}
+			pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+				Name: fmt.Sprintf("data%d", v),
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.Name},
+				},
+			})
+			objects = append(objects, pvc)
+			storageInfo.Disks = append(storageInfo.Disks, madmin.Disk{
+				Endpoint:  fmt.Sprintf("https://myminio-pool-0-%d.myminio-hl.ns.svc.cluster.local:9000/export%d", i, v),
+				DrivePath: fmt.Sprintf("/export%d", v),
+				State:     madmin.DriveStateOk,
+			})
+		}
+		objects = append(objects, pod.DeepCopy())
+		pods = append(pods, pod)
+	}
+
+	kubeClient := fake.NewSimpleClientset(objects...)
+	recorder := record.NewFakeRecorder(10)
+	controller := Controller{
+		kubeClientSet:  kubeClient,
+		minioClientSet: miniofake.NewSimpleClientset(tenant.DeepCopy()),
+		recorder:       recorder,
+	}
+
+	// deleting the first pod leaves 12 drives online, deleting the second one as well would leave 8
+	if err := controller.syncDriveReplacements(ctx, tenant, nil, pods, storageInfo); err != nil {
+		t.Fatal(err)
+	}
+	phases := map[string]miniov2.DriveReplacementPhase{}
+	for _, r := range tenant.Status.DriveReplacements {
+		phases[r.PVC] = r.Phase
+	}
+	if phase := phases["data0-myminio-pool-0-0"]; phase != miniov2.DriveReplacementReplacing {
+		t.Errorf("first replacement phase = %s, want %s", phase, miniov2.DriveReplacementReplacing)
+	}
+	if phase := phases["data0-myminio-pool-0-1"]; phase != miniov2.DriveReplacementBlocked {
+		t.Errorf("second replacement phase = %s, want %s", phase, miniov2.DriveReplacementBlocked)
+	}
+	if _, err := kubeClient.CoreV1().Pods("ns").Get(ctx, "myminio-pool-0-1", metav1.GetOptions{}); err != nil {
+		t.Errorf("pod of the blocked replacement was deleted: %v", err)
+	}
+
+	// the pod being recreated keeps holding the second replacement back, even while MinIO still reports its drives
+	if err := controller.syncDriveReplacements(ctx, tenant, nil, pods, storageInfo); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range tenant.Status.DriveReplacements {
+		if r.PVC == "data0-myminio-pool-0-1" && r.Phase != miniov2.DriveReplacementBlocked {
+			t.Errorf("second replacement phase = %s, want %s", r.Phase, miniov2.DriveReplacementBlocked)
+		}
+	}
+}
@@ -0,0 +1,443 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/minio/pkg/env"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/certs"
+)
+
+const (
+	// InternalCASecretName is the secret of the operator namespace holding the keys of the internal CA
+	InternalCASecretName = "minio-operator-internal-ca"
+	// InternalCATrustSecretName is the secret of the operator namespace publishing the CA bundle of the internal CA,
+	// its prefix makes every operator replica trust it
+	InternalCATrustSecretName = OperatorCATLSSecretName + "-internal"
+	// CARotationOverlapEnv is the ENV var with the time a new root CA is trusted before it signs certificates
+	CARotationOverlapEnv = "MINIO_OPERATOR_CA_ROTATION_OVERLAP"
+
+	internalCARootCert         = "root.crt"
+	internalCARootKey          = "root.key"
+	internalCANextRootCert     = "next-root.crt"
+	internalCANextRootKey      = "next-root.key"
+	internalCAIntermediateCert = "intermediate.crt"
+	internalCAIntermediateKey  = "intermediate.key"
+
+	internalCARootDuration         = 10 * 365 * 24 * time.Hour
+	internalCAIntermediateDuration = 2 * 365 * 24 * time.Hour
+	internalCALeafDuration         = 365 * 24 * time.Hour
+	defaultCARotationOverlap       = 30 * 24 * time.Hour
+)
+
+// internalCA is the CA managed by the operator: a root signing an intermediate that signs the certificates. A new root
+// is added to the bundle an overlap period before it replaces the current one, and previous roots stay in the bundle
+// until they expire, so the certificates signed by either are trusted during the rotation
+type internalCA struct {
+	root            *x509.Certificate
+	rootKey         crypto.Signer
+	nextRoot        *x509.Certificate
+	nextRootKey     crypto.Signer
+	intermediate    *x509.Certificate
+	intermediateKey crypto.Signer
+	bundle          []*x509.Certificate
+}
+
+// caRotationOverlap returns how long a new root CA is distributed before it signs certificates
+func caRotationOverlap() time.Duration {
+	overlap, err := time.ParseDuration(env.Get(CARotationOverlapEnv, defaultCARotationOverlap.String()))
+	if err != nil || overlap <= 0 {
+		return defaultCARotationOverlap
+	}
+	return overlap
+}
+
+// needsRenewal returns true once 80% of the lifetime of the certificate elapsed, like the issued certificates
+func needsRenewal(cert *x509.Certificate, now time.Time) bool {
+	return now.After(cert.NotBefore.Add(time.Duration(float64(cert.NotAfter.Sub(cert.NotBefore)) * 0.8)))
+}
+
+func newSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+// newCACertificate generates a CA certificate signed by the parent, or self-signed when parent is nil
+func newCACertificate(commonName string, parent *x509.Certificate, parentKey crypto.Signer, now time.Time, duration time.Duration) (*x509.Certificate, crypto.Signer, error) {
+	key, err := newPrivateKey(miniov2.DefaultEllipticCurve)
+	if err != nil {
+		return nil, nil, err
+	}
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"MinIO Operator"}},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(duration),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	signer := crypto.Signer(key)
+	if parent == nil {
+		parent = template
+	} else {
+		signer = parentKey
+		template.MaxPathLenZero = true
+		if template.NotAfter.After(parent.NotAfter) {
+			template.NotAfter = parent.NotAfter
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), signer)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cert, key, nil
+}
+
+func newRootCA(now time.Time) (*x509.Certificate, crypto.Signer, error) {
+	return newCACertificate(fmt.Sprintf("MinIO Operator Root CA %d", now.Unix()), nil, nil, now, internalCARootDuration)
+}
+
+// newIntermediateCA generates a new signing CA under the current root
+func (ca *internalCA) newIntermediateCA(now time.Time) (err error) {
+	ca.intermediate, ca.intermediateKey, err = newCACertificate(fmt.Sprintf("MinIO Operator Intermediate CA %d", now.Unix()), ca.root, ca.rootKey, now, internalCAIntermediateDuration)
+	return err
+}
+
+// newInternalCA generates a new root and intermediate CA
+func newInternalCA(now time.Time) (*internalCA, error) {
+	root, rootKey, err := newRootCA(now)
+	if err != nil {
+		return nil, err
+	}
+	ca := &internalCA{root: root, rootKey: rootKey, bundle: []*x509.Certificate{root}}
+	if err = ca.newIntermediateCA(now); err != nil {
+		return nil, err
+	}
+	return ca, nil
+}
+
+// rotate renews the CAs of the internal CA that need it and returns true if anything changed
+func (ca *internalCA) rotate(now time.Time, overlap time.Duration) (bool, error) {
+	changed := false
+	if ca.nextRoot == nil && needsRenewal(ca.root, now) {
+		root, key, err := newRootCA(now)
+		if err != nil {
+			return false, err
+		}
+		klog.Infof("Internal CA: distributing the new root CA %q before it signs certificates", root.Subject.CommonName)
+		ca.nextRoot, ca.nextRootKey = root, key
+		ca.bundle = append(ca.bundle, root)
+		changed = true
+	}
+	if ca.nextRoot != nil && !now.Before(ca.nextRoot.NotBefore.Add(overlap)) {
+		klog.Infof("Internal CA: root CA %q replaces %q", ca.nextRoot.Subject.CommonName, ca.root.Subject.CommonName)
+		ca.root, ca.rootKey = ca.nextRoot, ca.nextRootKey
+		ca.nextRoot, ca.nextRootKey = nil, nil
+		if err := ca.newIntermediateCA(now); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	if needsRenewal(ca.intermediate, now) || !ca.intermediate.NotAfter.After(now.Add(internalCALeafDuration)) {
+		klog.Infof("Internal CA: renewing the intermediate CA %q", ca.intermediate.Subject.CommonName)
+		if err := ca.newIntermediateCA(now); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	// previous roots are kept until the certificates they signed expire
+	bundle := ca.bundle[:0]
+	for _, root := range ca.bundle {
+		if root.NotAfter.After(now) {
+			bundle = append(bundle, root)
+		}
+	}
+	if len(bundle) != len(ca.bundle) {
+		changed = true
+	}
+	ca.bundle = bundle
+	return changed, nil
+}
+
+// bundlePEM returns the roots to trust
+func (ca *internalCA) bundlePEM() []byte {
+	var bundle []byte
+	for _, root := range ca.bundle {
+		bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: root.Raw})...)
+	}
+	return bundle
+}
+
+// sign issues a certificate for the public key from the template, valid for the duration or the default lifetime when
+// zero, the returned PEM holds the certificate followed by the intermediate CA
+func (ca *internalCA) sign(template *x509.Certificate, publicKey crypto.PublicKey, now time.Time, duration time.Duration) ([]byte, error) {
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+	template.SerialNumber = serialNumber
+	template.NotBefore = now.Add(-time.Minute)
+	if duration <= 0 {
+		duration = internalCALeafDuration
+	}
+	template.NotAfter = now.Add(duration)
+	if template.NotAfter.After(ca.intermediate.NotAfter) {
+		template.NotAfter = ca.intermediate.NotAfter
+	}
+	template.BasicConstraintsValid = true
+	template.IsCA = false
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.intermediate, publicKey, ca.intermediateKey)
+	if err != nil {
+		return nil, err
+	}
+	chain := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return append(chain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.intermediate.Raw})...), nil
+}
+
+// signCSR issues a server and client certificate for the names of the CSR
+func (ca *internalCA) signCSR(csrBytes []byte, now time.Time, duration time.Duration) ([]byte, error) {
+	csr, err := x509.ParseCertificateRequest(csrBytes)
+	if err != nil {
+		return nil, err
+	}
+	if err = csr.CheckSignature(); err != nil {
+		return nil, err
+	}
+	return ca.sign(&x509.Certificate{
+		Subject:     csr.Subject,
+		DNSNames:    csr.DNSNames,
+		IPAddresses: csr.IPAddresses,
+		URIs:        csr.URIs,
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}, csr.PublicKey, now, duration)
+}
+
+func encodeCAKeyPair(data map[string][]byte, certKey, keyKey string, cert *x509.Certificate, key crypto.Signer) error {
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	data[certKey] = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	data[keyKey] = pem.EncodeToMemory(&pem.Block{Type: privateKeyType, Bytes: keyBytes})
+	return nil
+}
+
+func decodeCAKeyPair(data map[string][]byte, certKey, keyKey string) (*x509.Certificate, crypto.Signer, error) {
+	certBlock, _ := pem.Decode(data[certKey])
+	keyBlock, _ := pem.Decode(data[keyKey])
+	if certBlock == nil || keyBlock == nil {
+		return nil, nil, fmt.Errorf("missing '%s' or '%s'", certKey, keyKey)
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("'%s' is not a signing key", keyKey)
+	}
+	return cert, signer, nil
+}
+
+// data returns the content of the secret persisting the internal CA
+func (ca *internalCA) data() (map[string][]byte, error) {
+	data := map[string][]byte{certs.CAPublicCertFile: ca.bundlePEM()}
+	if err := encodeCAKeyPair(data, internalCARootCert, internalCARootKey, ca.root, ca.rootKey); err != nil {
+		return nil, err
+	}
+	if err := encodeCAKeyPair(data, internalCAIntermediateCert, internalCAIntermediateKey, ca.intermediate, ca.intermediateKey); err != nil {
+		return nil, err
+	}
+	if ca.nextRoot != nil {
+		if err := encodeCAKeyPair(data, internalCANextRootCert, internalCANextRootKey, ca.nextRoot, ca.nextRootKey); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// parseInternalCA reads the internal CA persisted in the secret
+func parseInternalCA(data map[string][]byte) (ca *internalCA, err error) {
+	ca = &internalCA{}
+	if ca.root, ca.rootKey, err = decodeCAKeyPair(data, internalCARootCert, internalCARootKey); err != nil {
+		return nil, err
+	}
+	if ca.intermediate, ca.intermediateKey, err = decodeCAKeyPair(data, internalCAIntermediateCert, internalCAIntermediateKey); err != nil {
+		return nil, err
+	}
+	if _, ok := data[internalCANextRootCert]; ok {
+		if ca.nextRoot, ca.nextRootKey, err = decodeCAKeyPair(data, internalCANextRootCert, internalCANextRootKey); err != nil {
+			return nil, err
+		}
+	}
+	rest := data[certs.CAPublicCertFile]
+	for len(rest) > 0 {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		root, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		ca.bundle = append(ca.bundle, root)
+	}
+	if len(ca.bundle) == 0 {
+		ca.bundle = []*x509.Certificate{ca.root}
+	}
+	return ca, nil
+}
+
+// getInternalCA loads the internal CA from the operator namespace, creating or rotating it when needed, and
+// publishes its bundle
+func (c *Controller) getInternalCA(ctx context.Context) (*internalCA, error) {
+	namespace := miniov2.GetNSFromFile()
+	now := time.Now().UTC()
+	secret, err := c.kubeClientSet.CoreV1().Secrets(namespace).Get(ctx, InternalCASecretName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		klog.Infof("Creating the internal CA in secret '%s/%s'", namespace, InternalCASecretName)
+		ca, err := newInternalCA(now)
+		if err != nil {
+			return nil, err
+		}
+		data, err := ca.data()
+		if err != nil {
+			return nil, err
+		}
+		_, err = c.kubeClientSet.CoreV1().Secrets(namespace).Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: InternalCASecretName, Namespace: namespace},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       data,
+		}, metav1.CreateOptions{})
+		if k8serrors.IsAlreadyExists(err) {
+			// another worker created it first
+			return c.getInternalCA(ctx)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return ca, c.publishInternalCABundle(ctx, ca)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := parseInternalCA(secret.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unreadable internal CA in secret '%s/%s': %v", namespace, InternalCASecretName, err)
+	}
+	changed, err := ca.rotate(now, caRotationOverlap())
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		if secret.Data, err = ca.data(); err != nil {
+			return nil, err
+		}
+		if _, err = c.kubeClientSet.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			return nil, err
+		}
+	}
+	return ca, c.publishInternalCABundle(ctx, ca)
+}
+
+// publishInternalCABundle stores the CA bundle in the trust secret of the operator namespace and trusts it right away
+func (c *Controller) publishInternalCABundle(ctx context.Context, ca *internalCA) error {
+	namespace := miniov2.GetNSFromFile()
+	bundle := ca.bundlePEM()
+	published := false
+	secret, err := c.kubeClientSet.CoreV1().Secrets(namespace).Get(ctx, InternalCATrustSecretName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		published = true
+		_, err = c.kubeClientSet.CoreV1().Secrets(namespace).Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: InternalCATrustSecretName, Namespace: namespace},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{certs.CAPublicCertFile: bundle},
+		}, metav1.CreateOptions{})
+	} else if err == nil && !bytes.Equal(secret.Data[certs.CAPublicCertFile], bundle) {
+		published = true
+		secret.Data = map[string][]byte{certs.CAPublicCertFile: bundle}
+		_, err = c.kubeClientSet.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	}
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+	if published {
+		// the CA bundles of the tenants carry the new root
+		c.enqueueCABundleTenants()
+	}
+	return c.rebuildTrust(ctx)
+}
+
+// signCSRWithInternalCA signs the CSR with the internal CA, returning the certificate chain and the CA bundle
+func (c *Controller) signCSRWithInternalCA(ctx context.Context, csrBytes []byte, duration time.Duration) ([]byte, []byte, error) {
+	ca, err := c.getInternalCA(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	certBytes, err := ca.signCSR(csrBytes, time.Now().UTC(), duration)
+	if err != nil {
+		return nil, nil, err
+	}
+	return certBytes, ca.bundlePEM(), nil
+}
+
+// recurrentInternalCARotation rotates the internal CA on schedule, so a new root is distributed for the whole overlap
+// period before it signs certificates
+func (c *Controller) recurrentInternalCARotation(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		if _, err := c.getInternalCA(ctx); err != nil {
+			klog.Errorf("Unable to rotate the internal CA: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
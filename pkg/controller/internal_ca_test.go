@@ -0,0 +1,147 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/certs"
+)
+
+// verifyInternalCACertificate checks the certificate chain against the roots of the bundle
+func verifyInternalCACertificate(t *testing.T, chain, bundle []byte, now time.Time) {
+	t.Helper()
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(bundle) {
+		t.Fatal("empty CA bundle")
+	}
+	leafBlock, rest := pem.Decode(chain)
+	intermediateBlock, _ := pem.Decode(rest)
+	if leafBlock == nil || intermediateBlock == nil {
+		t.Fatal("certificate is not followed by the intermediate CA")
+	}
+	leaf, err := x509.ParseCertificate(leafBlock.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	intermediates := x509.NewCertPool()
+	intermediates.AppendCertsFromPEM(rest)
+	if _, err = leaf.Verify(x509.VerifyOptions{
+		DNSName:       "sts",
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+	}); err != nil {
+		t.Errorf("certificate does not verify against the bundle: %v", err)
+	}
+}
+
+func Test_internalCA(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ca, err := newInternalCA(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, csrBytes, err := generateServiceCSRCryptoData("sts", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain, err := ca.signCSR(csrBytes, now, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyInternalCACertificate(t, chain, ca.bundlePEM(), now)
+
+	// the CA survives a round trip through its secret
+	data, err := ca.data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ca, err = parseInternalCA(data); err != nil {
+		t.Fatal(err)
+	}
+	if changed, err := ca.rotate(now.Add(time.Hour), defaultCARotationOverlap); err != nil || changed {
+		t.Errorf("rotate() = %v, %v, want a new CA left untouched", changed, err)
+	}
+
+	// the intermediate CA is renewed before it can't cover the lifetime of a certificate
+	later := now.Add(internalCAIntermediateDuration - internalCALeafDuration + time.Hour)
+	intermediate := ca.intermediate
+	if changed, err := ca.rotate(later, defaultCARotationOverlap); err != nil || !changed || ca.intermediate.Equal(intermediate) {
+		t.Errorf("rotate() = %v, %v, want a new intermediate CA", changed, err)
+	}
+
+	// a new root is distributed during the overlap before it signs certificates
+	renewal := now.Add(internalCARootDuration * 8 / 10).Add(time.Hour)
+	root := ca.root
+	if changed, err := ca.rotate(renewal, defaultCARotationOverlap); err != nil || !changed {
+		t.Fatalf("rotate() = %v, %v, want a new root", changed, err)
+	}
+	if ca.nextRoot == nil || !ca.root.Equal(root) || len(ca.bundle) != 2 {
+		t.Fatalf("new root signs certificates before the overlap elapsed")
+	}
+	if _, err = ca.rotate(renewal.Add(defaultCARotationOverlap), defaultCARotationOverlap); err != nil {
+		t.Fatal(err)
+	}
+	if ca.nextRoot != nil || ca.root.Equal(root) {
+		t.Fatalf("new root was not promoted after the overlap")
+	}
+	promoted := renewal.Add(defaultCARotationOverlap)
+	if chain, err = ca.signCSR(csrBytes, promoted, 0); err != nil {
+		t.Fatal(err)
+	}
+	// the bundle holds both roots until the previous one expires
+	verifyInternalCACertificate(t, chain, ca.bundlePEM(), promoted)
+	if _, err = ca.rotate(now.Add(internalCARootDuration+time.Hour), defaultCARotationOverlap); err != nil {
+		t.Fatal(err)
+	}
+	if len(ca.bundle) != 1 || !ca.bundle[0].Equal(ca.root) {
+		t.Errorf("expired root is still in the bundle")
+	}
+}
+
+func Test_getInternalCA(t *testing.T) {
+	ctx := context.Background()
+	controller := Controller{kubeClientSet: fake.NewSimpleClientset()}
+	ca, err := controller.getInternalCA(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	namespace := miniov2.GetNSFromFile()
+	trust, err := controller.kubeClientSet.CoreV1().Secrets(namespace).Get(ctx, InternalCATrustSecretName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := trust.Data[internalCARootKey]; ok || string(trust.Data[certs.CAPublicCertFile]) != string(ca.bundlePEM()) {
+		t.Errorf("trust secret = %v, want only the CA bundle", trust.Data)
+	}
+	again, err := controller.getInternalCA(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.root.Equal(ca.root) || !again.intermediate.Equal(ca.intermediate) {
+		t.Errorf("internal CA was generated again instead of loaded from its secret")
+	}
+}
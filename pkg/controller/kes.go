@@ -47,15 +47,15 @@ import (
 )
 
 func generateKESCryptoData(tenant *miniov2.Tenant) ([]byte, []byte, error) {
-	privateKey, err := newPrivateKey(miniov2.DefaultEllipticCurve)
+	privateKey, err := newCertificateKey(certificateKeyParams(tenant.Spec.CertConfig, miniov2.CertificateKeyAlgorithmECDSA))
 	if err != nil {
-		klog.Errorf("Unexpected error during the ECDSA Key generation: %v", err)
+		klog.Errorf("Unexpected error during the Key generation: %v", err)
 		return nil, nil, err
 	}
 
 	privKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
 	if err != nil {
-		klog.Errorf("Unexpected error during encoding the ECDSA Private Key: %v", err)
+		klog.Errorf("Unexpected error during encoding the Private Key: %v", err)
 		return nil, nil, err
 	}
 
@@ -68,14 +68,21 @@ func generateKESCryptoData(tenant *miniov2.Tenant) ([]byte, []byte, error) {
 			Value:    []byte(host),
 		})
 	}
+	ipAddresses, err := certificateIPAddresses(tenant.Spec.CertConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	uris, err := certificateURIs(tenant.Spec.CertConfig)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	csrTemplate := x509.CertificateRequest{
-		Subject: pkix.Name{
-			CommonName:   fmt.Sprintf("system:node:%s", tenant.KESWildCardName()),
-			Organization: tenant.Spec.CertConfig.OrganizationName,
-		},
-		SignatureAlgorithm: x509.ECDSAWithSHA512,
+		Subject:            certificateSubject(tenant.Spec.CertConfig, fmt.Sprintf("system:node:%s", tenant.KESWildCardName())),
+		SignatureAlgorithm: csrSignatureAlgorithm(privateKey),
 		DNSNames:           tenant.KESHosts(),
+		IPAddresses:        ipAddresses,
+		URIs:               uris,
 		Extensions:         csrExtensions,
 	}
 
@@ -97,26 +104,37 @@ func (c *Controller) createKESCSR(ctx context.Context, tenant *miniov2.Tenant) e
 		return err
 	}
 
-	err = c.createCertificateSigningRequest(ctx, tenant.KESPodLabels(), tenant.KESCSRName(), tenant.Namespace, csrBytes)
-	if err != nil {
-		klog.Errorf("Unexpected error during the creation of the csr/%s: %v", tenant.KESCSRName(), err)
-		return err
-	}
-	c.recorder.Event(tenant, corev1.EventTypeNormal, "CSRCreated", "KES CSR Created")
+	var certbytes, caBytes []byte
+	if certificates.UseInternalCA() {
+		certbytes, caBytes, err = c.signCSRWithInternalCA(ctx, csrBytes, certificateDuration(tenant.Spec.CertConfig))
+		if err != nil {
+			klog.Errorf("Unexpected error signing the KES certificate with the internal CA: %v", err)
+			c.recorder.Event(tenant, corev1.EventTypeWarning, "CertFailed", fmt.Sprintf("KES certificate failed to be signed by the internal CA: %s", err))
+			return err
+		}
+		c.recorder.Event(tenant, corev1.EventTypeNormal, "CertIssued", "KES certificate signed by the internal CA")
+	} else {
+		err = c.createCertificateSigningRequest(ctx, tenant.KESPodLabels(), tenant.KESCSRName(), tenant.Namespace, csrBytes, certificateDuration(tenant.Spec.CertConfig))
+		if err != nil {
+			klog.Errorf("Unexpected error during the creation of the csr/%s: %v", tenant.KESCSRName(), err)
+			return err
+		}
+		c.recorder.Event(tenant, corev1.EventTypeNormal, "CSRCreated", "KES CSR Created")
 
-	// fetch certificate from CSR
-	certbytes, err := c.fetchCertificate(ctx, tenant.KESCSRName())
-	if err != nil {
-		klog.Errorf("Unexpected error during the creation of the csr/%s: %v", tenant.KESCSRName(), err)
-		c.recorder.Event(tenant, corev1.EventTypeWarning, "CSRFailed", fmt.Sprintf("KES CSR Failed to create: %s", err))
-		return err
+		// fetch certificate from CSR
+		certbytes, err = c.fetchCertificate(ctx, tenant.KESCSRName())
+		if err != nil {
+			klog.Errorf("Unexpected error during the creation of the csr/%s: %v", tenant.KESCSRName(), err)
+			c.recorder.Event(tenant, corev1.EventTypeWarning, "CSRFailed", fmt.Sprintf("KES CSR Failed to create: %s", err))
+			return err
+		}
 	}
 
-	// PEM encode private ECDSA key
+	// PEM encode private key
 	encodedPrivKey := pem.EncodeToMemory(&pem.Block{Type: privateKeyType, Bytes: privKeysBytes})
 
 	// Create secret for KES Statefulset to use
-	err = c.createSecret(ctx, tenant, tenant.KESPodLabels(), tenant.KESTLSSecretName(), encodedPrivKey, certbytes)
+	err = c.createSecret(ctx, tenant, tenant.KESPodLabels(), tenant.KESTLSSecretName(), encodedPrivKey, certbytes, caBytes)
 	if err != nil {
 		klog.Errorf("Unexpected error during the creation of the secret/%s: %v", tenant.KESTLSSecretName(), err)
 		return err
@@ -153,6 +171,17 @@ func kesStatefulSetMatchesSpec(tenant *miniov2.Tenant, existingStatefulSet *apps
 }
 
 func (c *Controller) checkKESCertificatesStatus(ctx context.Context, tenant *miniov2.Tenant, nsName types.NamespacedName) (err error) {
+	if tenant.CertManagerIssuer() {
+		if !tenant.ExternalClientCert() {
+			if err = c.checkCertManagerCertificate(ctx, tenant, minioClientCertManagerCertificate(tenant), StatusWaitingMinIOClientCert); err != nil {
+				return err
+			}
+		}
+		if !tenant.KESExternalCert() {
+			return c.checkCertManagerCertificate(ctx, tenant, kesCertManagerCertificate(tenant), StatusWaitingKESCert)
+		}
+		return nil
+	}
 	if !tenant.ExternalClientCert() {
 		if err = c.checkAndCreateMinIOClientCertificates(ctx, nsName, tenant); err != nil {
 			return err
@@ -160,8 +189,18 @@ func (c *Controller) checkKESCertificatesStatus(ctx context.Context, tenant *min
 	}
 	// if KES is enabled and user didn't provide KES server certificates generate them
 	if !tenant.KESExternalCert() {
-		_, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Get(ctx, tenant.KESTLSSecretName(), metav1.GetOptions{})
-		if err != nil {
+		tlsSecret, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Get(ctx, tenant.KESTLSSecretName(), metav1.GetOptions{})
+		if err == nil {
+			keyAlgorithm, keySize := certificateKeyParams(tenant.Spec.CertConfig, miniov2.CertificateKeyAlgorithmECDSA)
+			needsRenewal, err := c.tenantCertNeedsRenewal(tenant, tlsSecret, keyAlgorithm, keySize)
+			if err != nil {
+				klog.Warningf("Cannot check secret %s/%s for renewal (will be renewing): %v", tlsSecret.Namespace, tlsSecret.Name, err)
+				needsRenewal = true
+			}
+			if needsRenewal {
+				return c.recreateKESCertsOnTenant(ctx, tenant, nsName)
+			}
+		} else {
 			if k8serrors.IsNotFound(err) {
 				if err = c.checkAndCreateKESCSR(ctx, nsName, tenant); err != nil {
 					return err
@@ -182,6 +221,17 @@ func (c *Controller) checkKESCertificatesStatus(ctx context.Context, tenant *min
 	return nil
 }
 
+func (c *Controller) recreateKESCertsOnTenant(ctx context.Context, tenant *miniov2.Tenant, nsName types.NamespacedName) error {
+	klog.V(2).Infof("Deleting the KES TLS secret and CSR of the certificate to renew on tenant %s", tenant.Name)
+	if err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Delete(ctx, tenant.KESTLSSecretName(), metav1.DeleteOptions{}); err != nil {
+		return err
+	}
+	if err := c.deleteCSR(ctx, tenant.KESCSRName()); err != nil {
+		return err
+	}
+	return c.checkAndCreateKESCSR(ctx, nsName, tenant)
+}
+
 func (c *Controller) checkKESStatus(ctx context.Context, tenant *miniov2.Tenant, totalAvailableReplicas int32, cOpts metav1.CreateOptions, uOpts metav1.UpdateOptions, nsName types.NamespacedName) error {
 	if tenant.HasKESEnabled() {
 		if err := c.checkKESCertificatesStatus(ctx, tenant, nsName); err != nil {
@@ -277,24 +327,34 @@ func (c *Controller) checkKESStatus(ctx context.Context, tenant *miniov2.Tenant,
 }
 
 func (c *Controller) checkAndCreateMinIOClientCertificates(ctx context.Context, nsName types.NamespacedName, tenant *miniov2.Tenant) error {
-	var err error
-	_, err = c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Get(ctx, tenant.MinIOClientTLSSecretName(), metav1.GetOptions{})
-	if err != nil {
-		if k8serrors.IsNotFound(err) {
-			if tenant, err = c.updateTenantStatus(ctx, tenant, StatusWaitingMinIOClientCert, 0); err != nil {
-				return err
-			}
-			klog.V(2).Infof("Creating a new Client Certificate for MinIO, cluster %q", nsName)
-			if err = c.createMinIOClientCertificates(ctx, tenant); err != nil {
-				// we want to re-queue this tenant so we can re-check for the health at a later stage
-				c.recorder.Event(tenant, corev1.EventTypeWarning, "CertFailed", fmt.Sprintf("KES MinIO Client Certificate failed to create: %s", err))
-				return err
-			}
-			return errors.New("waiting for minio client cert")
+	tlsSecret, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Get(ctx, tenant.MinIOClientTLSSecretName(), metav1.GetOptions{})
+	if err == nil {
+		keyAlgorithm, keySize := certificateKeyParams(tenant.Spec.CertConfig, miniov2.CertificateKeyAlgorithmEd25519)
+		needsRenewal, err := c.tenantCertNeedsRenewal(tenant, tlsSecret, keyAlgorithm, keySize)
+		if err != nil {
+			klog.Warningf("Cannot check secret %s/%s for renewal (will be renewing): %v", tlsSecret.Namespace, tlsSecret.Name, err)
+			needsRenewal = true
+		}
+		if !needsRenewal {
+			return nil
 		}
+		klog.V(2).Infof("Deleting the MinIO client certificate to renew on tenant %s", tenant.Name)
+		if err = c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Delete(ctx, tlsSecret.Name, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+	} else if !k8serrors.IsNotFound(err) {
 		return err
 	}
-	return nil
+	if tenant, err = c.updateTenantStatus(ctx, tenant, StatusWaitingMinIOClientCert, 0); err != nil {
+		return err
+	}
+	klog.V(2).Infof("Creating a new Client Certificate for MinIO, cluster %q", nsName)
+	if err = c.createMinIOClientCertificates(ctx, tenant); err != nil {
+		// we want to re-queue this tenant so we can re-check for the health at a later stage
+		c.recorder.Event(tenant, corev1.EventTypeWarning, "CertFailed", fmt.Sprintf("KES MinIO Client Certificate failed to create: %s", err))
+		return err
+	}
+	return errors.New("waiting for minio client cert")
 }
 
 func (c *Controller) checkAndCreateKESCSR(ctx context.Context, nsName types.NamespacedName, tenant *miniov2.Tenant) error {
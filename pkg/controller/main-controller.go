@@ -16,12 +16,14 @@ package controller
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"maps"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	kubeinformers "k8s.io/client-go/informers"
@@ -34,6 +36,7 @@ import (
 
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/operator/pkg/controller/certificates"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
@@ -73,6 +76,8 @@ import (
 	minioscheme "github.com/minio/operator/pkg/client/clientset/versioned/scheme"
 	informers "github.com/minio/operator/pkg/client/informers/externalversions/minio.min.io/v2"
 	stsInformers "github.com/minio/operator/pkg/client/informers/externalversions/sts.min.io/v1beta1"
+	listers "github.com/minio/operator/pkg/client/listers/minio.min.io/v2"
+	stsListers "github.com/minio/operator/pkg/client/listers/sts.min.io/v1beta1"
 	"github.com/minio/operator/pkg/resources/statefulsets"
 )
 
@@ -155,6 +160,9 @@ type Controller struct {
 	// tenantsSynced returns true if the StatefulSet shared informer
 	// has synced at least once.
 	tenantsSynced cache.InformerSynced
+	// tenantLister is able to list/get Tenants from a shared informer's
+	// store.
+	tenantLister listers.TenantLister
 	// serviceLister is able to list/get Services from a shared informer's
 	// store.
 	serviceLister corelisters.ServiceLister
@@ -183,8 +191,14 @@ type Controller struct {
 	// STS API server instance
 	sts *http.Server
 
-	// Client transport
-	transport *http.Transport
+	// Metrics server instance
+	metrics *http.Server
+
+	// Client transport and the sources of its trusted certificates
+	trust trustStore
+
+	// Certificate managers of the operator listeners by service name, see operatorServingCertificates
+	servingCertsManagers sync.Map
 
 	// monitor pods in the cluster to update the health information
 	podInformer cache.SharedIndexInformer
@@ -195,7 +209,12 @@ type Controller struct {
 	// time, and makes it easy to ensure we are never processing the same item
 	// simultaneously in two different workers.
 	healthCheckQueue queue.RateLimitingInterface
+	// healthSchedule tracks when each tenant is due for its next health check
+	healthSchedule *tenantHealthSchedule
 
+	// policyBindingLister is able to list/get PolicyBindings from a shared
+	// informer's store.
+	policyBindingLister stsListers.PolicyBindingLister
 	// policyBindingListerSynced returns true if the PolicyBinding shared informer
 	// has synced at least once.
 	policyBindingListerSynced cache.InformerSynced
@@ -236,6 +255,8 @@ func NewController(
 	deploymentInformer := kubeInformerFactory.Apps().V1().Deployments()
 	serviceInformer := kubeInformerFactory.Core().V1().Services()
 	secretInformer := kubeInformerFactoryInOperatorNamespace.Core().V1().Secrets()
+	configMapInformer := kubeInformerFactoryInOperatorNamespace.Core().V1().ConfigMaps()
+	namespaceInformer := kubeInformerFactory.Core().V1().Namespaces()
 
 	// Create event broadcaster
 	// Add minio-controller types to the default Kubernetes Scheme so Events can be
@@ -269,15 +290,18 @@ func NewController(
 		deploymentLister:          deploymentInformer.Lister(),
 		deploymentListerSynced:    deploymentInformer.Informer().HasSynced,
 		tenantsSynced:             tenantInformer.Informer().HasSynced,
+		tenantLister:              tenantInformer.Lister(),
 		serviceLister:             serviceInformer.Lister(),
 		serviceListerSynced:       serviceInformer.Informer().HasSynced,
 		secretLister:              secretInformer.Lister(),
 		secretListerSynced:        secretInformer.Informer().HasSynced,
 		workqueue:                 queue.NewRateLimitingQueueWithConfig(MinIOControllerRateLimiter(), queue.RateLimitingQueueConfig{Name: "Tenants"}),
 		healthCheckQueue:          queue.NewRateLimitingQueueWithConfig(MinIOControllerRateLimiter(), queue.RateLimitingQueueConfig{Name: "TenantsHealth"}),
+		healthSchedule:            newTenantHealthSchedule(),
 		recorder:                  recorder,
 		hostsTemplate:             hostsTemplate,
 		operatorVersion:           operatorVersion,
+		policyBindingLister:       policyBindingInformer.Lister(),
 		policyBindingListerSynced: policyBindingInformer.Informer().HasSynced,
 	}
 
@@ -287,6 +311,9 @@ func NewController(
 	// Initialize STS API server handlers
 	controller.sts = configureSTSServer(controller)
 
+	// Initialize metrics server handlers
+	controller.metrics = configureMetricsServer(tenantInformer.Lister(), http.HandlerFunc(controller.serveTrust))
+
 	klog.Info("Setting up event handlers")
 	// Set up an event handler for when Tenant resources change
 	tenantInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -370,6 +397,54 @@ func NewController(
 			}
 			controller.handleSecret(newObj, oldObj)
 		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			controller.handleSecret(obj, nil)
+		},
+	})
+
+	configMapInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			controller.handleTrustConfigMap(obj, nil)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if newObj.(*corev1.ConfigMap).ResourceVersion == oldObj.(*corev1.ConfigMap).ResourceVersion {
+				return
+			}
+			controller.handleTrustConfigMap(newObj, oldObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			controller.handleTrustConfigMap(obj, nil)
+		},
+	})
+
+	// the CA bundles of the tenants follow the namespaces of the PolicyBindings and the namespace selectors
+	policyBindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.handlePolicyBinding,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			controller.handlePolicyBinding(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			controller.handlePolicyBinding(obj)
+		},
+	})
+
+	namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.handleCABundleNamespace,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if equality.Semantic.DeepEqual(oldObj.(*corev1.Namespace).Labels, newObj.(*corev1.Namespace).Labels) {
+				return
+			}
+			controller.handleCABundleNamespace(newObj)
+		},
 	})
 
 	return controller
@@ -406,9 +481,13 @@ func (c *Controller) startSTSAPIServer(ctx context.Context, notificationChannel
 			Type: STSServerNotification,
 			Err:  err,
 		}
+		return
 	}
-	serverCertsManager = certsManager
-	c.sts.TLSConfig = c.createTLSConfig(serverCertsManager)
+	// the certificate is swapped by reloadOperatorCertificate when the secret changes
+	c.servingCertsManagers.Store("sts", certsManager)
+	c.sts.TLSConfig = c.createTLSConfig(certsManager)
+	// the client certificates are verified by AssumeRoleWithCertificate, so the CAs can change without a restart
+	c.sts.TLSConfig.ClientAuth = tls.RequestClientCert
 
 	if err := c.sts.ListenAndServeTLS("", ""); !errors.Is(err, http.ErrServerClosed) {
 		// only notify on server failure, on http.ErrServerClosed the channel should be already closed
@@ -448,6 +527,12 @@ func leaderRun(ctx context.Context, c *Controller, threadiness int, notification
 	// Launch a goroutine to monitor all Tenants
 	go c.recurrentTenantStatusMonitor(ctx)
 	go c.StartPodInformer(ctx)
+	// keep the internal CA rotated even when no certificate gets issued
+	if certificates.UseInternalCA() {
+		go c.recurrentInternalCARotation(ctx)
+	}
+	// renew the certificates of the operator listeners, every replica reloads them from the secrets
+	go c.recurrentOperatorCertificateRenewal(ctx)
 
 	// 2) we need to make sure we have STS API certificates (if enabled)
 	if IsSTSEnabled() {
@@ -458,7 +543,12 @@ func leaderRun(ctx context.Context, c *Controller, threadiness int, notification
 			} else {
 				klog.Infof("STS Autocert is disabled, skipping certificate generation.")
 			}
+			if err := c.checkAndCreateSTSInjectionWebhook(ctx); err != nil {
+				klog.Errorf("Unable to configure the STS injection webhook: %v", err)
+			}
 		}()
+	} else if err := c.checkAndCreateSTSInjectionWebhook(ctx); err != nil {
+		klog.Errorf("Unable to remove the STS injection webhook: %v", err)
 	}
 
 	for {
@@ -513,6 +603,12 @@ func (c *Controller) Start(ctx context.Context, cancel context.CancelFunc, threa
 		panic(err.Error())
 	}
 
+	// metrics are served by every replica, the leader state tells them apart
+	setLeader(false)
+	go c.startMetricsServer()
+	// every replica talks to the tenants through the transport, e.g. to serve STS
+	go c.recurrentTrustRebuild(ctx)
+
 	if IsSTSEnabled() {
 		// runSTS starts the STS API even if the pod is not the leader
 		klog.Info("Waiting for STS API to start")
@@ -536,10 +632,12 @@ func (c *Controller) Start(ctx context.Context, cancel context.CancelFunc, threa
 		RetryPeriod:     5 * time.Second,
 		Callbacks: leaderelection.LeaderCallbacks{
 			OnStartedLeading: func(ctx context.Context) {
+				setLeader(true)
 				// start the controller + API code
 				leaderRun(ctx, c, threadiness, notificationChannel)
 			},
 			OnStoppedLeading: func() {
+				setLeader(false)
 				klog.Infof("leader lost, removing any leader labels that I '%s' might have", c.podName)
 				p := []patchAnnotation{{
 					Op:   "remove",
@@ -602,6 +700,7 @@ func (c *Controller) Stop() {
 	tctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	_ = c.us.Shutdown(tctx)
 	_ = c.sts.Shutdown(tctx)
+	_ = c.metrics.Shutdown(tctx)
 	cancel()
 
 	klog.Info("Stopping the minio controller")
@@ -732,10 +831,19 @@ func (c *Controller) updateServer(
 	return nil
 }
 
-// syncHandler compares the actual state with the desired, and attempts to
+// syncHandler reconciles the tenant and records the duration and errors of each step
+func (c *Controller) syncHandler(key string) (Result, error) {
+	namespace, tenantName := key2NamespaceName(key)
+	rt := newReconcileTracker(namespace, tenantName)
+	result, err := c.syncTenant(key, rt)
+	rt.Finish(err)
+	return result, err
+}
+
+// syncTenant compares the actual state with the desired, and attempts to
 // converge the two. It then updates the Status block of the Tenant resource
 // with the current status of the resource.
-func (c *Controller) syncHandler(key string) (Result, error) {
+func (c *Controller) syncTenant(key string, rt *reconcileTracker) (Result, error) {
 	ctx := context.Background()
 	cOpts := metav1.CreateOptions{}
 	uOpts := metav1.UpdateOptions{}
@@ -754,6 +862,7 @@ func (c *Controller) syncHandler(key string) (Result, error) {
 		// The Tenant resource may no longer exist, in which case we stop processing.
 		if k8serrors.IsNotFound(err) {
 			runtime.HandleError(fmt.Errorf("Tenant '%s' in work queue no longer exists", key))
+			rt.Forget()
 			// Try to delete PrometheusConfig.
 			// Can't use the tenant. That's nil for sure
 			err = c.deletePrometheusAddlConfig(ctx, &miniov2.Tenant{
@@ -766,6 +875,10 @@ func (c *Controller) syncHandler(key string) (Result, error) {
 				// Just output the error. Will not retry.
 				runtime.HandleError(fmt.Errorf("DeletePrometheusAddlConfig '%s/%s' error:%s", namespace, tenantName, err.Error()))
 			}
+			// the replicas of the CA bundle in other namespaces are not garbage collected
+			if err = c.deleteCABundles(ctx, namespace, tenantName, nil); err != nil {
+				runtime.HandleError(fmt.Errorf("DeleteCABundles '%s/%s' error:%s", namespace, tenantName, err.Error()))
+			}
 			return WrapResult(Result{}, nil)
 		}
 		// will retry after 5sec
@@ -816,8 +929,11 @@ func (c *Controller) syncHandler(key string) (Result, error) {
 
 	tenant.EnsureDefaults()
 
-	// Validate the MinIO Tenant
-	if err = tenant.Validate(); err != nil {
+	// Validate the MinIO Tenant, pools strictly spread across zones must survive the loss of a zone with the configured parity
+	if err = tenant.Validate(); err == nil {
+		err = tenant.ValidatePlacement(string(tenantConfiguration[miniov2.MinIOStorageClassStandard]))
+	}
+	if err != nil {
 		klog.V(2).Infof(err.Error())
 		var err2 error
 		if _, err2 = c.updateTenantStatus(ctx, tenant, err.Error(), 0); err2 != nil {
@@ -855,9 +971,10 @@ func (c *Controller) syncHandler(key string) (Result, error) {
 		}
 	}
 
+	rt.Step("certificates")
 	// Custom certificates
-	if customCertificates, err := c.getCustomCertificates(ctx, tenant); err == nil {
-		if newTenant, err := c.updateCustomCertificatesStatus(ctx, tenant, customCertificates); err != nil {
+	if customCertificates, expiring, err := c.getCustomCertificates(ctx, tenant); err == nil {
+		if newTenant, err := c.updateCustomCertificatesStatus(ctx, tenant, customCertificates, expiring); err != nil {
 			klog.V(2).Infof(err.Error())
 		} else {
 			// Only change tenant if there was no error, otherwise tenant is being deleted
@@ -874,7 +991,18 @@ func (c *Controller) syncHandler(key string) (Result, error) {
 		// will retry after 5sec
 		return WrapResult(Result{RequeueAfter: time.Second * 5}, nil)
 	}
+	// the certificates of the domains must exist before the pods mount them
+	if err = c.checkMinIODomainCertificates(ctx, tenant); err != nil {
+		klog.V(2).Infof("Error when consolidating the domain certificates: %v", err)
+		return WrapResult(Result{RequeueAfter: time.Second * 5}, nil)
+	}
+	// publish the CAs of the certificates to the namespaces consuming the tenant
+	if tenant, err = c.syncCABundle(ctx, tenant, tenantConfiguration); err != nil {
+		klog.V(2).Infof("Error when publishing the CA bundle: %v", err)
+		return WrapResult(Result{RequeueAfter: time.Second * 5}, nil)
+	}
 
+	rt.Step("services")
 	// validate services
 	// Check MinIO S3 Endpoint Service
 	err = c.checkMinIOSvc(ctx, tenant, nsName)
@@ -915,6 +1043,7 @@ func (c *Controller) syncHandler(key string) (Result, error) {
 			}
 		}
 	}
+	rt.Step("service-account")
 	// Create Tenant Services Accoutns for Tenant
 	err = c.checkAndCreateServiceAccount(ctx, tenant)
 	if err != nil {
@@ -934,12 +1063,14 @@ func (c *Controller) syncHandler(key string) (Result, error) {
 	var totalAvailableReplicas int32
 	var images []string
 
+	rt.Step("kes")
 	err = c.checkKESStatus(ctx, tenant, totalAvailableReplicas, cOpts, uOpts, nsName)
 	if err != nil {
 		klog.V(2).Infof("Error checking KES state %v", err)
 		return WrapResult(Result{}, err)
 	}
 
+	rt.Step("pools")
 	// consolidate the status of all pools. this is meant to cover for legacy tenants
 	// this status value is zero only for new tenants or legacy tenants
 	if len(tenant.Status.Pools) == 0 {
@@ -1108,6 +1239,7 @@ func (c *Controller) syncHandler(key string) (Result, error) {
 		}
 	}
 
+	rt.Step("update")
 	// compare all the images across all pools, they should always be the same.
 	compareImage := ""
 	for i, image := range images {
@@ -1205,6 +1337,7 @@ func (c *Controller) syncHandler(key string) (Result, error) {
 
 	}
 
+	rt.Step("statefulsets")
 	// This loop will take care of updating the statefulset for each pool
 	for i, pool := range tenant.Spec.Pools {
 		// Get the StatefulSet with the name specified in Tenant.status.pools[i].SSName
@@ -1314,24 +1447,30 @@ func (c *Controller) syncHandler(key string) (Result, error) {
 		}
 	}
 
+	rt.Step("pdb")
+	// Keep the PodDisruptionBudgets in line with the layout of the pools
+	if err = c.syncPodDisruptionBudgets(ctx, tenant, tenantConfiguration); err != nil {
+		return WrapResult(Result{}, err)
+	}
+
+	rt.Step("pvc-expansion")
 	// Handle PVC expansion
 	err = ExpandPVCs(ctx, c.kubeClientSet, tenant, namespace)
 	if err != nil {
 		return WrapResult(Result{}, err)
 	}
+	// Grow the PVCs of the pools running out of capacity
+	if tenant, err = c.autoscalePoolStorage(ctx, tenant); err != nil {
+		return WrapResult(Result{}, err)
+	}
 
-	if tenant.HasPrometheusOperatorEnabled() {
-		err := c.checkAndCreatePrometheusAddlConfig(ctx, tenant, string(tenantConfiguration["accesskey"]), string(tenantConfiguration["secretkey"]))
-		if err != nil {
-			return WrapResult(Result{}, err)
-		}
-	} else {
-		err := c.deletePrometheusAddlConfig(ctx, tenant)
-		if err != nil {
-			return WrapResult(Result{}, err)
-		}
+	// A rotation of the root credentials that doesn't complete in time stops holding the sync back, a server that
+	// doesn't come back keeps MinIO unhealthy so this is checked first
+	if tenant, err = c.expireRootCredentialsRotation(ctx, tenant); err != nil {
+		return WrapResult(Result{}, err)
 	}
 
+	rt.Step("health")
 	// Stay in this state until minio is ready
 	if tenant.Status.HealthStatus != miniov2.HealthStatusGreen {
 		c.updateTenantStatus(ctx, tenant, StatusWaitingMinIOIsHealthy, 0)
@@ -1340,6 +1479,36 @@ func (c *Controller) syncHandler(key string) (Result, error) {
 		return WrapResult(Result{RequeueAfter: time.Second * 5}, nil)
 	}
 
+	rt.Step("root-credentials")
+	// Rotate the root credentials once MinIO is healthy
+	var rotatingRootCredentials bool
+	if tenant, rotatingRootCredentials, err = c.syncRootCredentials(ctx, tenant, tenantConfiguration); err != nil {
+		return WrapResult(Result{}, err)
+	}
+
+	rt.Step("pool-template")
+	// Append a pool from the template when the tenant runs out of capacity, the update of the spec
+	// triggers a new sync that deploys the pool
+	var expanded bool
+	if tenant, expanded, err = c.expandPoolsFromTemplate(ctx, tenant); err != nil {
+		return WrapResult(Result{}, err)
+	}
+	if expanded {
+		return WrapResult(Result{}, nil)
+	}
+
+	// The steps below use the root credentials MinIO runs with, they wait for the rotation in progress
+	if rotatingRootCredentials {
+		return WrapResult(Result{RequeueAfter: rootCredentialsRequeueInterval}, nil)
+	}
+
+	rt.Step("prometheus")
+	// The Prometheus metrics user can only be provisioned once MinIO is ready
+	if err = c.syncPrometheusOperatorConfig(ctx, tenant, tenantConfiguration); err != nil {
+		return WrapResult(Result{}, err)
+	}
+
+	rt.Step("users")
 	// Ensure we are only provisioning users one time
 	if !tenant.Status.ProvisionedUsers && len(tenant.Spec.Users) > 0 {
 		if err := c.createUsers(ctx, tenant, tenantConfiguration); err != nil {
@@ -1351,6 +1520,7 @@ func (c *Controller) syncHandler(key string) (Result, error) {
 		c.recorder.Event(tenant, corev1.EventTypeNormal, "UsersCreated", "Users created")
 	}
 
+	rt.Step("buckets")
 	// Ensure we are only creating the bucket
 	if len(tenant.Spec.Buckets) > 0 {
 		if create, err := c.createBuckets(ctx, tenant, tenantConfiguration); err != nil {
@@ -1363,6 +1533,7 @@ func (c *Controller) syncHandler(key string) (Result, error) {
 		}
 	}
 
+	rt.Step("status")
 	// Finally, we update the status block of the Tenant resource to reflect the
 	// current state of the world
 	tenant, err = c.updateTenantStatus(ctx, tenant, StatusInitialized, totalAvailableReplicas)
@@ -1435,30 +1606,21 @@ func (c *Controller) handleObject(obj interface{}) {
 }
 
 func (c *Controller) handleSecret(obj interface{}, oldObj interface{}) {
-	ns := miniov2.GetNSFromFile()
 	var secret *corev1.Secret
 	var ok bool
 	if secret, ok = obj.(*corev1.Secret); !ok {
 		runtime.HandleError(fmt.Errorf("error decoding object, invalid type"))
 		return
 	}
-	// Observe secrets in the Operator namespace
-	if secret.Namespace == ns {
-		// a secret with prefix "operator-ca-tls" changed, reload all trusted CA certificates
-		if strings.HasPrefix(secret.Name, OperatorCATLSSecretName) {
-			klog.Infof("Secret '%s/%s' changed", secret.Namespace, secret.Name)
-			var oldSecret *corev1.Secret
-			if oldObj != nil {
-				if oldCasted, ok := oldObj.(*corev1.Secret); ok {
-					oldSecret = oldCasted
-				}
-			}
-			// Add new certificates to Transport Certs if any changed
-			if !c.TrustTLSCertificatesInSecretIfChanged(secret, oldSecret) {
-				klog.Infof("No new certificate was added from secret '%s/%s'", secret.Name, secret.Name)
-			}
+	oldSecret, _ := oldObj.(*corev1.Secret)
+	// a trusted secret of the Operator namespace changed, rebuild the trust store so removed certificates are dropped
+	if isTrustSource(secret, true) || (oldSecret != nil && isTrustSource(oldSecret, true)) {
+		klog.Infof("Secret '%s/%s' changed, rebuilding the trust store", secret.Namespace, secret.Name)
+		if err := c.rebuildTrust(context.Background()); err != nil {
+			klog.Errorf("Unable to rebuild the trust store: %v", err)
 		}
 	}
+	c.reloadOperatorCertificate(secret)
 }
 
 // MinIOControllerRateLimiter is a no-arg constructor for a default rate limiter for a workqueue for our controller.
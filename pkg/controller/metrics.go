@@ -0,0 +1,424 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	queue "k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	listers "github.com/minio/operator/pkg/client/listers/minio.min.io/v2"
+	"github.com/minio/operator/pkg/common"
+)
+
+const metricsNamespace = "minio_operator"
+
+// Certificate types reported by the certificate expiry metric
+const (
+	// CertificateTypeIssued is a certificate issued by the operator through the Kubernetes CSR API
+	CertificateTypeIssued = "issued"
+	// CertificateTypeTrusted is a certificate added to the operator trust store
+	CertificateTypeTrusted = "trusted"
+	// CertificateTypeExternal is a certificate provided by the user for a tenant
+	CertificateTypeExternal = "external"
+	// CertificateTypeServing is a certificate served by the operator itself, e.g. by the STS API
+	CertificateTypeServing = "serving"
+)
+
+var (
+	workqueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "depth",
+		Help:      "Current depth of the workqueue",
+	}, []string{"name"})
+	workqueueAdds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "adds_total",
+		Help:      "Total number of adds handled by the workqueue",
+	}, []string{"name"})
+	workqueueLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "queue_duration_seconds",
+		Help:      "How long an item stays in the workqueue before being processed",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+	}, []string{"name"})
+	workqueueWorkDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "work_duration_seconds",
+		Help:      "How long processing an item from the workqueue takes",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+	}, []string{"name"})
+	workqueueUnfinishedWork = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "unfinished_work_seconds",
+		Help:      "Seconds of work in progress that hasn't been observed by work_duration yet",
+	}, []string{"name"})
+	workqueueLongestRunningProcessor = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "longest_running_processor_seconds",
+		Help:      "Seconds the longest running processor of the workqueue has been running",
+	}, []string{"name"})
+	workqueueRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workqueue",
+		Name:      "retries_total",
+		Help:      "Total number of retries handled by the workqueue",
+	}, []string{"name"})
+
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_duration_seconds",
+		Help:      "Duration of each step of a tenant reconciliation, the `total` step covers the whole reconciliation",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 4, 10),
+	}, []string{"namespace", "tenant", "step"})
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_errors_total",
+		Help:      "Total number of tenant reconciliations that failed, by the step that failed",
+	}, []string{"namespace", "tenant", "step"})
+
+	healthCheckDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "health_check_duration_seconds",
+		Help:      "Duration of the tenant health checks",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 4, 8),
+	}, []string{"namespace", "tenant"})
+	healthCheckFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "health_check_failures_total",
+		Help:      "Total number of tenant health checks that failed, by reason",
+	}, []string{"namespace", "tenant", "reason"})
+
+	artifactFetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "artifact_fetch_duration_seconds",
+		Help:      "Duration of the MinIO artifact fetches used for in-place updates",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+	}, []string{"result"})
+	artifactFetchBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "artifact_fetch_bytes_total",
+		Help:      "Total number of bytes downloaded when fetching MinIO artifacts",
+	})
+
+	certificateExpiry = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "certificate_expiry_timestamp_seconds",
+		Help:      "Expiry of the earliest expiring certificate in a secret issued or trusted by the operator",
+	}, []string{"namespace", "secret", "type"})
+
+	leader = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "leader",
+		Help:      "Whether this operator instance holds the leader lease",
+	})
+	isLeader atomic.Bool
+
+	// tenantCertificateSecrets keeps the secrets whose certificate expiry is recorded for each tenant, by
+	// `namespace/tenant`, so the series of a deleted tenant are removed without touching the other tenants
+	tenantCertificateSecrets   = map[string]map[string]bool{}
+	tenantCertificateSecretsMu sync.Mutex
+)
+
+func init() {
+	queue.SetProvider(workqueueMetricsProvider{})
+}
+
+// workqueueMetricsProvider exposes the client-go workqueue metrics, the provider must be set before any queue is created
+type workqueueMetricsProvider struct{}
+
+func (workqueueMetricsProvider) NewDepthMetric(name string) queue.GaugeMetric {
+	return workqueueDepth.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewAddsMetric(name string) queue.CounterMetric {
+	return workqueueAdds.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewLatencyMetric(name string) queue.HistogramMetric {
+	return workqueueLatency.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewWorkDurationMetric(name string) queue.HistogramMetric {
+	return workqueueWorkDuration.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) queue.SettableGaugeMetric {
+	return workqueueUnfinishedWork.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) queue.SettableGaugeMetric {
+	return workqueueLongestRunningProcessor.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewRetriesMetric(name string) queue.CounterMetric {
+	return workqueueRetries.WithLabelValues(name)
+}
+
+// setLeader records whether this instance holds the leader lease
+func setLeader(isLeaderNow bool) {
+	isLeader.Store(isLeaderNow)
+	if isLeaderNow {
+		leader.Set(1)
+	} else {
+		leader.Set(0)
+	}
+}
+
+// reconcileTracker times the steps of a tenant reconciliation, errors are accounted to the step that was running
+type reconcileTracker struct {
+	namespace string
+	tenant    string
+	step      string
+	start     time.Time
+	stepStart time.Time
+	forgotten bool
+}
+
+func newReconcileTracker(namespace, tenant string) *reconcileTracker {
+	now := time.Now()
+	return &reconcileTracker{
+		namespace: namespace,
+		tenant:    tenant,
+		step:      "setup",
+		start:     now,
+		stepStart: now,
+	}
+}
+
+// Step records the duration of the running step and starts the next one
+func (r *reconcileTracker) Step(step string) {
+	reconcileDuration.WithLabelValues(r.namespace, r.tenant, r.step).Observe(time.Since(r.stepStart).Seconds())
+	r.step, r.stepStart = step, time.Now()
+}
+
+// Forget drops every series of a tenant that no longer exists
+func (r *reconcileTracker) Forget() {
+	r.forgotten = true
+	deleteTenantMetrics(r.namespace, r.tenant)
+}
+
+// Finish records the last step and the whole reconciliation
+func (r *reconcileTracker) Finish(err error) {
+	if r.forgotten {
+		return
+	}
+	reconcileDuration.WithLabelValues(r.namespace, r.tenant, r.step).Observe(time.Since(r.stepStart).Seconds())
+	reconcileDuration.WithLabelValues(r.namespace, r.tenant, "total").Observe(time.Since(r.start).Seconds())
+	if err != nil {
+		reconcileErrors.WithLabelValues(r.namespace, r.tenant, r.step).Inc()
+	}
+}
+
+// deleteTenantMetrics removes the series of a deleted tenant, the certificate expiry of the secrets still used by
+// another tenant of the namespace is kept
+func deleteTenantMetrics(namespace, tenant string) {
+	tenantLabels := prometheus.Labels{"namespace": namespace, "tenant": tenant}
+	reconcileDuration.DeletePartialMatch(tenantLabels)
+	reconcileErrors.DeletePartialMatch(tenantLabels)
+	healthCheckDuration.DeletePartialMatch(tenantLabels)
+	healthCheckFailures.DeletePartialMatch(tenantLabels)
+
+	tenantCertificateSecretsMu.Lock()
+	defer tenantCertificateSecretsMu.Unlock()
+	key := namespace + "/" + tenant
+	secrets := tenantCertificateSecrets[key]
+	delete(tenantCertificateSecrets, key)
+	for secret := range secrets {
+		shared := false
+		for otherKey, otherSecrets := range tenantCertificateSecrets {
+			if strings.HasPrefix(otherKey, namespace+"/") && otherSecrets[secret] {
+				shared = true
+				break
+			}
+		}
+		if !shared {
+			certificateExpiry.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "secret": secret})
+		}
+	}
+}
+
+// trackTenantCertificateSecret records that the certificate expiry of a secret belongs to the tenant
+func trackTenantCertificateSecret(tenant *miniov2.Tenant, secret *corev1.Secret) {
+	tenantCertificateSecretsMu.Lock()
+	defer tenantCertificateSecretsMu.Unlock()
+	key := tenant.Namespace + "/" + tenant.Name
+	if tenantCertificateSecrets[key] == nil {
+		tenantCertificateSecrets[key] = map[string]bool{}
+	}
+	tenantCertificateSecrets[key][secret.Name] = true
+}
+
+// recordCertificateExpiry records the earliest expiry of the certificates held by a secret
+func recordCertificateExpiry(secret *corev1.Secret, certType string, certs ...*x509.Certificate) {
+	var notAfter time.Time
+	for _, cert := range certs {
+		if notAfter.IsZero() || cert.NotAfter.Before(notAfter) {
+			notAfter = cert.NotAfter
+		}
+	}
+	if notAfter.IsZero() {
+		return
+	}
+	certificateExpiry.WithLabelValues(secret.Namespace, secret.Name, certType).Set(float64(notAfter.Unix()))
+}
+
+// recordPEMCertificateExpiry records the earliest expiry of the PEM encoded certificates held by a secret
+func recordPEMCertificateExpiry(secret *corev1.Secret, certType string, pemData []byte) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, pemData = pem.Decode(pemData)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+	recordCertificateExpiry(secret, certType, certs...)
+}
+
+// tenantStatusCollector exposes the status of every tenant, only the leader reports them so the series are not
+// duplicated across the operator replicas
+type tenantStatusCollector struct {
+	tenantLister listers.TenantLister
+	health       *prometheus.Desc
+	drives       *prometheus.Desc
+	usage        *prometheus.Desc
+}
+
+func newTenantStatusCollector(tenantLister listers.TenantLister) *tenantStatusCollector {
+	return &tenantStatusCollector{
+		tenantLister: tenantLister,
+		health: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "tenant", "health_status"),
+			"Health status of the tenant, the series matching the current status is set to 1",
+			[]string{"namespace", "tenant", "status"}, nil),
+		drives: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "tenant", "drives"),
+			"Number of tenant drives by state",
+			[]string{"namespace", "tenant", "state"}, nil),
+		usage: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "tenant", "usage_bytes"),
+			"Capacity and usage of the tenant in bytes",
+			[]string{"namespace", "tenant", "type"}, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (tc *tenantStatusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- tc.health
+	ch <- tc.drives
+	ch <- tc.usage
+}
+
+// Collect implements prometheus.Collector
+func (tc *tenantStatusCollector) Collect(ch chan<- prometheus.Metric) {
+	if !isLeader.Load() {
+		return
+	}
+	tenants, err := tc.tenantLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Unable to list tenants for metrics: %v", err)
+		return
+	}
+	for _, t := range tenants {
+		for _, status := range []miniov2.HealthStatus{miniov2.HealthStatusGreen, miniov2.HealthStatusYellow, miniov2.HealthStatusRed} {
+			value := 0.0
+			if t.Status.HealthStatus == status {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(tc.health, prometheus.GaugeValue, value, t.Namespace, t.Name, string(status))
+		}
+		ch <- prometheus.MustNewConstMetric(tc.drives, prometheus.GaugeValue, float64(t.Status.DrivesOnline), t.Namespace, t.Name, "online")
+		ch <- prometheus.MustNewConstMetric(tc.drives, prometheus.GaugeValue, float64(t.Status.DrivesOffline), t.Namespace, t.Name, "offline")
+		ch <- prometheus.MustNewConstMetric(tc.drives, prometheus.GaugeValue, float64(t.Status.DrivesHealing), t.Namespace, t.Name, "healing")
+		ch <- prometheus.MustNewConstMetric(tc.usage, prometheus.GaugeValue, float64(t.Status.Usage.RawCapacity), t.Namespace, t.Name, "raw_capacity")
+		ch <- prometheus.MustNewConstMetric(tc.usage, prometheus.GaugeValue, float64(t.Status.Usage.RawUsage), t.Namespace, t.Name, "raw_usage")
+		ch <- prometheus.MustNewConstMetric(tc.usage, prometheus.GaugeValue, float64(t.Status.Usage.Capacity), t.Namespace, t.Name, "capacity")
+		ch <- prometheus.MustNewConstMetric(tc.usage, prometheus.GaugeValue, float64(t.Status.Usage.Usage), t.Namespace, t.Name, "usage")
+	}
+}
+
+// newMetricsRegistry returns a registry holding the operator metrics along with the Go runtime and process metrics
+func newMetricsRegistry(tenantLister listers.TenantLister) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		workqueueDepth,
+		workqueueAdds,
+		workqueueLatency,
+		workqueueWorkDuration,
+		workqueueUnfinishedWork,
+		workqueueLongestRunningProcessor,
+		workqueueRetries,
+		reconcileDuration,
+		reconcileErrors,
+		healthCheckDuration,
+		healthCheckFailures,
+		artifactFetchDuration,
+		artifactFetchBytes,
+		certificateExpiry,
+		leader,
+		newTenantStatusCollector(tenantLister),
+	)
+	return registry
+}
+
+func configureMetricsServer(tenantLister listers.TenantLister, trustHandler http.Handler) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle(common.MetricsEndpoint, promhttp.HandlerFor(newMetricsRegistry(tenantLister), promhttp.HandlerOpts{}))
+	mux.Handle(common.TrustDebugEndpoint, trustHandler)
+
+	return &http.Server{
+		Addr:           ":" + common.MetricsServerPort,
+		Handler:        mux,
+		ReadTimeout:    time.Minute,
+		WriteTimeout:   time.Minute,
+		MaxHeaderBytes: 1 << 20,
+	}
+}
+
+// startMetricsServer serves the operator metrics on every replica
+func (c *Controller) startMetricsServer() {
+	klog.Infof("Starting metrics server")
+	if err := c.metrics.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		klog.Errorf("Metrics server stopped: %v", err)
+	}
+}
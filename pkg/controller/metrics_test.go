@@ -0,0 +1,83 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+func Test_reconcileTracker(t *testing.T) {
+	rt := newReconcileTracker("metrics-ns", "metrics-tenant")
+	rt.Step("certificates")
+	rt.Step("services")
+	rt.Finish(errors.New("service failure"))
+
+	if got := testutil.ToFloat64(reconcileErrors.WithLabelValues("metrics-ns", "metrics-tenant", "services")); got != 1 {
+		t.Fatalf("expected the error to be accounted to the services step, got %v", got)
+	}
+	if got := testutil.ToFloat64(reconcileErrors.WithLabelValues("metrics-ns", "metrics-tenant", "certificates")); got != 0 {
+		t.Fatalf("expected no error on the certificates step, got %v", got)
+	}
+	if got := testutil.CollectAndCount(reconcileDuration); got != 4 {
+		t.Fatalf("expected setup, certificates, services and total durations, got %d series", got)
+	}
+
+	// series of deleted tenants are dropped
+	rt = newReconcileTracker("metrics-ns", "metrics-tenant")
+	rt.Forget()
+	rt.Finish(nil)
+	if got := testutil.CollectAndCount(reconcileDuration); got != 0 {
+		t.Fatalf("expected the tenant series to be deleted, got %d series", got)
+	}
+}
+
+func Test_deleteTenantMetricsCertificateExpiry(t *testing.T) {
+	cert := &x509.Certificate{NotAfter: time.Now().Add(24 * time.Hour)}
+	tenantA := &miniov2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", Namespace: "expiry-ns"}}
+	tenantB := &miniov2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "tenant-b", Namespace: "expiry-ns"}}
+	record := func(tenant *miniov2.Tenant, name string) {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: tenant.Namespace}}
+		trackTenantCertificateSecret(tenant, secret)
+		recordCertificateExpiry(secret, CertificateTypeExternal, cert)
+	}
+	record(tenantA, "tenant-a-tls")
+	record(tenantA, "shared-ca")
+	record(tenantB, "tenant-b-tls")
+	record(tenantB, "shared-ca")
+
+	// other tests record series too, only the difference is checked
+	series := testutil.CollectAndCount(certificateExpiry)
+
+	// only the series of the secrets no other tenant of the namespace uses are removed
+	deleteTenantMetrics(tenantA.Namespace, tenantA.Name)
+	if got := testutil.CollectAndCount(certificateExpiry); got != series-1 {
+		t.Fatalf("expected only the tenant-a-tls series to be deleted, got %d series out of %d", got, series)
+	}
+	deleteTenantMetrics(tenantB.Namespace, tenantB.Name)
+	if got := testutil.CollectAndCount(certificateExpiry); got != series-3 {
+		t.Fatalf("expected every series of the namespace to be deleted, got %d series out of %d", got, series)
+	}
+}
@@ -16,7 +16,6 @@ package controller
 
 import (
 	"context"
-	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
@@ -25,7 +24,6 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"net"
 	"time"
 
 	"github.com/minio/operator/pkg/controller/certificates"
@@ -106,6 +104,10 @@ func (c *Controller) recreateMinIOCertsOnTenant(ctx context.Context, tenant *min
 // checkMinIOCertificatesStatus checks for the current status of MinIO and it's service
 func (c *Controller) checkMinIOCertificatesStatus(ctx context.Context, tenant *miniov2.Tenant, nsName types.NamespacedName) error {
 	if tenant.AutoCert() {
+		if tenant.CertManagerIssuer() {
+			// cert-manager renews the certificate on its own
+			return c.checkCertManagerCertificate(ctx, tenant, minioCertManagerCertificate(tenant, c.hostsTemplate), StatusWaitingMinIOCert)
+		}
 		// check if there's already a TLS secret for MinIO
 		tlsSecret, err := c.getCertificateSecret(ctx, tenant.Namespace, tenant.MinIOTLSSecretName())
 		if err != nil {
@@ -122,7 +124,8 @@ func (c *Controller) checkMinIOCertificatesStatus(ctx context.Context, tenant *m
 			}
 		}
 
-		needsRenewal, err := c.certNeedsRenewal(tlsSecret)
+		keyAlgorithm, keySize := certificateKeyParams(tenant.Spec.CertConfig, miniov2.CertificateKeyAlgorithmECDSA)
+		needsRenewal, err := c.tenantCertNeedsRenewal(tenant, tlsSecret, keyAlgorithm, keySize)
 		if err != nil {
 			klog.Warningf("Cannot check secret %s/%s for renewal (will be renewing): %v", tlsSecret.Namespace, tlsSecret.Name, err)
 			needsRenewal = true
@@ -136,9 +139,16 @@ func (c *Controller) checkMinIOCertificatesStatus(ctx context.Context, tenant *m
 	return nil
 }
 
+// tenantCertNeedsRenewal is certNeedsRenewal for a secret of the tenant, the expiry recorded for it is removed along
+// with the tenant
+func (c *Controller) tenantCertNeedsRenewal(tenant *miniov2.Tenant, tlsSecret *corev1.Secret, keyAlgorithm string, keySize int) (bool, error) {
+	trackTenantCertificateSecret(tenant, tlsSecret)
+	return c.certNeedsRenewal(tlsSecret, keyAlgorithm, keySize)
+}
+
 // certNeedsRenewal - returns true if the TLS certificate from given secret has expired or is
-// about to expire shortly.
-func (c *Controller) certNeedsRenewal(tlsSecret *corev1.Secret) (bool, error) {
+// about to expire shortly, or if its key doesn't have the expected algorithm and size.
+func (c *Controller) certNeedsRenewal(tlsSecret *corev1.Secret, keyAlgorithm string, keySize int) (bool, error) {
 	var certPublicKey []byte
 	var certPrivateKey []byte
 
@@ -167,6 +177,12 @@ func (c *Controller) certNeedsRenewal(tlsSecret *corev1.Secret) (bool, error) {
 			return false, err
 		}
 	}
+	recordCertificateExpiry(tlsSecret, CertificateTypeIssued, leaf)
+
+	if !certificateKeyMatches(leaf.PublicKey, keyAlgorithm, keySize) {
+		klog.V(2).Infof("TLS Certificate key of %s/%s doesn't match %s %d", tlsSecret.Namespace, tlsSecret.Name, keyAlgorithm, keySize)
+		return true, nil
+	}
 
 	// Renew the certificate when 80% of the time between the creation and expiration date
 	// has elapsed so this can work with short lived certifcates as well.
@@ -179,37 +195,45 @@ func (c *Controller) certNeedsRenewal(tlsSecret *corev1.Secret) (bool, error) {
 	return false, nil
 }
 
-func generateMinIOCryptoData(tenant *miniov2.Tenant, hostsTemplate string) ([]byte, []byte, error) {
+// minioCertificateDNSNames returns the Subject Alternative Names of the certificate generated for MinIO
+func minioCertificateDNSNames(tenant *miniov2.Tenant, hostsTemplate string) []string {
 	var dnsNames []string
+	hosts := tenant.AllMinIOHosts()
+	if hostsTemplate != "" {
+		hosts = tenant.TemplatedMinIOHosts(hostsTemplate)
+	}
+
+	if isEqual(tenant.Spec.CertConfig.DNSNames, hosts) {
+		dnsNames = tenant.Spec.CertConfig.DNSNames
+	} else {
+		dnsNames = append(tenant.Spec.CertConfig.DNSNames, hosts...)
+	}
+	return append(dnsNames, tenant.MinIOBucketBaseWildcardDomain())
+}
+
+func generateMinIOCryptoData(tenant *miniov2.Tenant, hostsTemplate string) ([]byte, []byte, error) {
+	return generateServerCryptoData(tenant, minioCertificateDNSNames(tenant, hostsTemplate))
+}
+
+// generateServerCryptoData returns a private key and a CSR of a MinIO server certificate for the DNS names
+func generateServerCryptoData(tenant *miniov2.Tenant, dnsNames []string) ([]byte, []byte, error) {
 	var csrExtensions []pkix.Extension
 
 	klog.V(0).Infof("Generating private key")
-	privateKey, err := newPrivateKey(miniov2.DefaultEllipticCurve)
+	privateKey, err := newCertificateKey(certificateKeyParams(tenant.Spec.CertConfig, miniov2.CertificateKeyAlgorithmECDSA))
 	if err != nil {
-		klog.Errorf("Unexpected error during the ECDSA Key generation: %v", err)
+		klog.Errorf("Unexpected error during the Key generation: %v", err)
 		return nil, nil, err
 	}
 
 	privKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
 	if err != nil {
-		klog.Errorf("Unexpected error during encoding the ECDSA Private Key: %v", err)
+		klog.Errorf("Unexpected error during encoding the Private Key: %v", err)
 		return nil, nil, err
 	}
 
 	klog.V(0).Infof("Generating CSR with CN=%s", tenant.Spec.CertConfig.CommonName)
 
-	hosts := tenant.AllMinIOHosts()
-	if hostsTemplate != "" {
-		hosts = tenant.TemplatedMinIOHosts(hostsTemplate)
-	}
-
-	if isEqual(tenant.Spec.CertConfig.DNSNames, hosts) {
-		dnsNames = tenant.Spec.CertConfig.DNSNames
-	} else {
-		dnsNames = append(tenant.Spec.CertConfig.DNSNames, hosts...)
-	}
-	dnsNames = append(dnsNames, tenant.MinIOBucketBaseWildcardDomain())
-
 	for _, dnsName := range dnsNames {
 		csrExtensions = append(csrExtensions, pkix.Extension{
 			Id:       nil,
@@ -217,14 +241,21 @@ func generateMinIOCryptoData(tenant *miniov2.Tenant, hostsTemplate string) ([]by
 			Value:    []byte(dnsName),
 		})
 	}
+	ipAddresses, err := certificateIPAddresses(tenant.Spec.CertConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	uris, err := certificateURIs(tenant.Spec.CertConfig)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	csrTemplate := x509.CertificateRequest{
-		Subject: pkix.Name{
-			CommonName:   fmt.Sprintf("system:node:%s", tenant.Spec.CertConfig.CommonName),
-			Organization: tenant.Spec.CertConfig.OrganizationName,
-		},
-		SignatureAlgorithm: x509.ECDSAWithSHA512,
+		Subject:            certificateSubject(tenant.Spec.CertConfig, fmt.Sprintf("system:node:%s", tenant.Spec.CertConfig.CommonName)),
+		SignatureAlgorithm: csrSignatureAlgorithm(privateKey),
 		DNSNames:           dnsNames,
+		IPAddresses:        ipAddresses,
+		URIs:               uris,
 		Extensions:         csrExtensions,
 	}
 
@@ -246,26 +277,37 @@ func (c *Controller) createMinIOCSR(ctx context.Context, tenant *miniov2.Tenant)
 		return err
 	}
 
-	err = c.createCertificateSigningRequest(ctx, tenant.MinIOPodLabels(), tenant.MinIOCSRName(), tenant.Namespace, csrBytes)
-	if err != nil {
-		klog.Errorf("Unexpected error during the creation of the csr/%s: %v", tenant.MinIOCSRName(), err)
-		return err
-	}
-	c.recorder.Event(tenant, corev1.EventTypeNormal, "CSRCreated", "MinIO CSR Created")
+	var certbytes, caBytes []byte
+	if certificates.UseInternalCA() {
+		certbytes, caBytes, err = c.signCSRWithInternalCA(ctx, csrBytes, certificateDuration(tenant.Spec.CertConfig))
+		if err != nil {
+			klog.Errorf("Unexpected error signing the MinIO certificate with the internal CA: %v", err)
+			c.recorder.Event(tenant, corev1.EventTypeWarning, "CertFailed", fmt.Sprintf("MinIO certificate failed to be signed by the internal CA: %s", err))
+			return err
+		}
+		c.recorder.Event(tenant, corev1.EventTypeNormal, "CertIssued", "MinIO certificate signed by the internal CA")
+	} else {
+		err = c.createCertificateSigningRequest(ctx, tenant.MinIOPodLabels(), tenant.MinIOCSRName(), tenant.Namespace, csrBytes, certificateDuration(tenant.Spec.CertConfig))
+		if err != nil {
+			klog.Errorf("Unexpected error during the creation of the csr/%s: %v", tenant.MinIOCSRName(), err)
+			return err
+		}
+		c.recorder.Event(tenant, corev1.EventTypeNormal, "CSRCreated", "MinIO CSR Created")
 
-	// fetch certificate from CSR
-	certbytes, err := c.fetchCertificate(ctx, tenant.MinIOCSRName())
-	if err != nil {
-		klog.Errorf("Unexpected error during the creation of the csr/%s: %v", tenant.MinIOCSRName(), err)
-		c.recorder.Event(tenant, corev1.EventTypeWarning, "CSRFailed", fmt.Sprintf("MinIO CSR Failed to create: %s", err))
-		return err
+		// fetch certificate from CSR
+		certbytes, err = c.fetchCertificate(ctx, tenant.MinIOCSRName())
+		if err != nil {
+			klog.Errorf("Unexpected error during the creation of the csr/%s: %v", tenant.MinIOCSRName(), err)
+			c.recorder.Event(tenant, corev1.EventTypeWarning, "CSRFailed", fmt.Sprintf("MinIO CSR Failed to create: %s", err))
+			return err
+		}
 	}
 
-	// PEM encode private ECDSA key
+	// PEM encode private key
 	encodedPrivKey := pem.EncodeToMemory(&pem.Block{Type: privateKeyType, Bytes: privKeysBytes})
 
 	// Create secret for MinIO Statefulset to use
-	err = c.createSecret(ctx, tenant, tenant.MinIOPodLabels(), tenant.MinIOTLSSecretName(), encodedPrivKey, certbytes)
+	err = c.createSecret(ctx, tenant, tenant.MinIOPodLabels(), tenant.MinIOTLSSecretName(), encodedPrivKey, certbytes, caBytes)
 	if err != nil {
 		klog.Errorf("Unexpected error during the creation of the secret/%s: %v", tenant.MinIOTLSSecretName(), err)
 		return err
@@ -276,10 +318,12 @@ func (c *Controller) createMinIOCSR(ctx context.Context, tenant *miniov2.Tenant)
 
 // createMinIOClientCertificates handles all the steps required to create the MinIO <-> KES mTLS certificates
 func (c *Controller) createMinIOClientCertificates(ctx context.Context, tenant *miniov2.Tenant) error {
-	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	config := tenant.Spec.CertConfig
+	privateKey, err := newCertificateKey(certificateKeyParams(config, miniov2.CertificateKeyAlgorithmEd25519))
 	if err != nil {
 		return err
 	}
+	publicKey := privateKey.Public()
 
 	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
 	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
@@ -287,37 +331,61 @@ func (c *Controller) createMinIOClientCertificates(ctx context.Context, tenant *
 		return err
 	}
 
+	ipAddresses, err := certificateIPAddresses(config)
+	if err != nil {
+		return err
+	}
+	uris, err := certificateURIs(config)
+	if err != nil {
+		return err
+	}
+	duration := certificateDuration(config)
+	if duration <= 0 {
+		duration = 8760 * time.Hour
+	}
+
 	template := x509.Certificate{
 		SerialNumber: serialNumber,
-		Subject: pkix.Name{
-			CommonName: tenant.MinIOFQDNServiceName(),
-		},
-		NotBefore: time.Now().UTC(),
-		NotAfter:  time.Now().UTC().Add(8760 * time.Hour),
-		KeyUsage:  x509.KeyUsageDigitalSignature,
+		Subject:      certificateSubject(config, tenant.MinIOFQDNServiceName()),
+		NotBefore:    time.Now().UTC(),
+		NotAfter:     time.Now().UTC().Add(duration),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
 		ExtKeyUsage: []x509.ExtKeyUsage{
 			x509.ExtKeyUsageServerAuth,
 			x509.ExtKeyUsageClientAuth,
 		},
 		DNSNames:              tenant.MinIOHosts(),
-		IPAddresses:           []net.IP{},
+		IPAddresses:           ipAddresses,
+		URIs:                  uris,
 		BasicConstraintsValid: true,
 	}
 
-	certBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, publicKey, privateKey)
-	if err != nil {
-		return err
-	}
 	privBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
 	if err != nil {
 		return err
 	}
-
 	keyPem := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privBytes})
-	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+
+	var certPem, caPem []byte
+	if certificates.UseInternalCA() {
+		ca, err := c.getInternalCA(ctx)
+		if err != nil {
+			return err
+		}
+		if certPem, err = ca.sign(&template, publicKey, time.Now().UTC(), certificateDuration(config)); err != nil {
+			return err
+		}
+		caPem = ca.bundlePEM()
+	} else {
+		certBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, publicKey, privateKey)
+		if err != nil {
+			return err
+		}
+		certPem = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+	}
 
 	// Create secret for KES StatefulSet to use
-	err = c.createSecret(ctx, tenant, tenant.MinIOPodLabels(), tenant.MinIOClientTLSSecretName(), keyPem, certPem)
+	err = c.createSecret(ctx, tenant, tenant.MinIOPodLabels(), tenant.MinIOClientTLSSecretName(), keyPem, certPem, caPem)
 	if err != nil {
 		klog.Errorf("Unexpected error during the creation of the secret/%s: %v", tenant.MinIOClientTLSSecretName(), err)
 		return err
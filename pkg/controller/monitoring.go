@@ -17,6 +17,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/minio/madmin-go/v3"
@@ -24,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/klog/v2"
 
@@ -39,18 +41,96 @@ const (
 	HealthReduceAvailabilityMessage = "Reduced Availability"
 )
 
-// recurrentTenantStatusMonitor loop that checks every N minutes for tenants health
+const (
+	// healthScheduleResolution is how often the schedule is checked for tenants due for a health check
+	healthScheduleResolution = 15 * time.Second
+	// healthCheckMaxBackoff caps how long an unreachable tenant waits for its next health check
+	healthCheckMaxBackoff = 30 * time.Minute
+)
+
+// tenantHealthState is the schedule of the health checks of a single tenant
+type tenantHealthState struct {
+	next     time.Time
+	failures int
+	running  bool
+}
+
+// tenantHealthSchedule tracks when each tenant is due for its next health check, so a tenant is never checked twice
+// at the same time and unreachable tenants back off exponentially
+type tenantHealthSchedule struct {
+	mu      sync.Mutex
+	tenants map[string]*tenantHealthState
+}
+
+func newTenantHealthSchedule() *tenantHealthSchedule {
+	return &tenantHealthSchedule{tenants: map[string]*tenantHealthState{}}
+}
+
+// start marks the tenant as being checked, it returns false if the tenant is not due yet or is being checked already
+func (s *tenantHealthSchedule) start(key string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.tenants[key]
+	if !ok {
+		state = &tenantHealthState{}
+		s.tenants[key] = state
+	}
+	if state.running || now.Before(state.next) {
+		return false
+	}
+	state.running = true
+	return true
+}
+
+// finish schedules the next health check of the tenant, after the interval when the check succeeded and with an
+// exponential backoff when it failed
+func (s *tenantHealthSchedule) finish(key string, now time.Time, interval time.Duration, failed bool) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.tenants[key]
+	if !ok {
+		// the tenant was removed while being checked
+		return 0
+	}
+	state.running = false
+	delay := interval
+	if failed {
+		state.failures++
+		maxBackoff := max(interval, healthCheckMaxBackoff)
+		for i := 1; i < state.failures && delay < maxBackoff; i++ {
+			delay *= 2
+		}
+		delay = min(delay, maxBackoff)
+	} else {
+		state.failures = 0
+	}
+	state.next = now.Add(delay)
+	return delay
+}
+
+// retain drops the schedule of the tenants that no longer exist
+func (s *tenantHealthSchedule) retain(keys map[string]struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.tenants {
+		if _, ok := keys[key]; !ok {
+			delete(s.tenants, key)
+		}
+	}
+}
+
+// recurrentTenantStatusMonitor loop that checks tenants health when they are due, each tenant is checked on its own
+// interval by a bounded number of workers
 func (c *Controller) recurrentTenantStatusMonitor(ctx context.Context) {
-	// How often will this function run
-	interval := miniov2.GetMonitoringInterval()
-	ticker := time.NewTicker(time.Duration(interval) * time.Minute)
+	workers := make(chan struct{}, miniov2.GetMonitoringWorkers())
+	ticker := time.NewTicker(healthScheduleResolution)
 	defer func() {
 		klog.Info("recurrent pod status monitor closed")
 	}()
 	for {
 		select {
 		case <-ticker.C:
-			if err := c.tenantsHealthMonitor(); err != nil {
+			if err := c.tenantsHealthMonitor(ctx, workers); err != nil {
 				klog.Infof("%v", err)
 			}
 		case <-ctx.Done():
@@ -60,28 +140,78 @@ func (c *Controller) recurrentTenantStatusMonitor(ctx context.Context) {
 	}
 }
 
-func (c *Controller) tenantsHealthMonitor() error {
-	// list all tenants and get their cluster health
-	tenants, err := c.minioClientSet.MinioV2().Tenants("").List(context.Background(), metav1.ListOptions{})
+// tenantsHealthMonitor dispatches the health check of the tenants that are due, without waiting for them to finish
+func (c *Controller) tenantsHealthMonitor(ctx context.Context, workers chan struct{}) error {
+	tenants, err := c.tenantLister.List(labels.Everything())
 	if err != nil {
 		return err
 	}
-	for _, t := range tenants.Items {
-		tenant, err := c.updateHealthStatusForTenant(&t)
-		if err != nil {
-			klog.Errorf("%v", err)
-			return err
-		}
-		// Add tenant to the health check queue until is green again
-		if tenant != nil && tenant.Status.HealthStatus != miniov2.HealthStatusGreen {
-			key := fmt.Sprintf("%s/%s", tenant.GetNamespace(), tenant.GetName())
-			c.healthCheckQueue.Add(key)
+	now := time.Now()
+	keys := make(map[string]struct{}, len(tenants))
+	for _, t := range tenants {
+		key := fmt.Sprintf("%s/%s", t.Namespace, t.Name)
+		keys[key] = struct{}{}
+		if !c.healthSchedule.start(key, now) {
+			continue
 		}
+		// NEVER modify objects from the store
+		tenant := t.DeepCopy()
+		go func() {
+			select {
+			case workers <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-workers }()
+			c.checkTenantHealth(ctx, key, tenant)
+		}()
 	}
+	c.healthSchedule.retain(keys)
 	return nil
 }
 
-func (c *Controller) updateHealthStatusForTenant(tenant *miniov2.Tenant) (*miniov2.Tenant, error) {
+// checkTenantHealth refreshes the health of a tenant within the monitoring deadline and schedules its next check
+func (c *Controller) checkTenantHealth(ctx context.Context, key string, tenant *miniov2.Tenant) {
+	tenant.EnsureDefaults()
+	interval := tenant.HealthCheckInterval()
+
+	hctx, cancel := context.WithTimeout(ctx, miniov2.GetMonitoringTimeout())
+	defer cancel()
+	// the schedule holds the tenant until the check finishes, so the drives and pods are only ever recovered by a
+	// single check at a time
+	tenant, err := c.updateHealthStatusForTenant(hctx, tenant, true)
+	failed := err != nil || (tenant != nil && tenant.Status.HealthStaleSince != nil)
+	delay := c.healthSchedule.finish(key, time.Now(), interval, failed)
+	if err != nil {
+		klog.Errorf("'%s' health check failed, next attempt in %s: %v", key, delay, err)
+		return
+	}
+	// Add tenant to the health check queue until is green again
+	if tenant != nil && tenant.Status.HealthStaleSince == nil && tenant.Status.HealthStatus != miniov2.HealthStatusGreen {
+		c.healthCheckQueue.Add(key)
+	}
+}
+
+// markHealthStale records since when the health of the tenant could not be refreshed, the last known health is kept
+func (c *Controller) markHealthStale(tenant *miniov2.Tenant) *miniov2.Tenant {
+	if tenant.Status.HealthStaleSince != nil {
+		return tenant
+	}
+	now := metav1.Now()
+	tenant.Status.HealthStaleSince = &now
+	tenantUpdate, err := c.updatePoolStatus(context.Background(), tenant)
+	if err != nil {
+		klog.Infof("'%s/%s' Can't update tenant status: %v", tenant.Namespace, tenant.Name, err)
+		return tenant
+	}
+	return tenantUpdate
+}
+
+// updateHealthStatusForTenant refreshes the health, drives and usage of the tenant, all the requests to MinIO are bound
+// to the deadline of the context. When MinIO can't be reached the status is flagged as stale. The drive replacements and
+// the recovery of the pods on lost nodes delete PVCs and pods, they only run when remediate is set by the caller holding
+// the tenant in the health schedule.
+func (c *Controller) updateHealthStatusForTenant(ctx context.Context, tenant *miniov2.Tenant, remediate bool) (*miniov2.Tenant, error) {
 	// don't get the tenant cluster health if it doesn't have at least 1 pool initialized
 	oneInitialized := false
 	for _, pool := range tenant.Status.Pools {
@@ -94,34 +224,46 @@ func (c *Controller) updateHealthStatusForTenant(tenant *miniov2.Tenant) (*minio
 		return tenant, nil
 	}
 
-	tenantConfiguration, err := c.getTenantCredentials(context.Background(), tenant)
+	namespace, name := tenant.Namespace, tenant.Name
+	start := time.Now()
+	defer func() {
+		healthCheckDuration.WithLabelValues(namespace, name).Observe(time.Since(start).Seconds())
+	}()
+
+	tenantConfiguration, err := c.getTenantCredentials(ctx, tenant)
 	if err != nil {
+		healthCheckFailures.WithLabelValues(namespace, name, "credentials").Inc()
+		c.markHealthStale(tenant)
 		return nil, err
 	}
 
 	adminClnt, err := tenant.NewMinIOAdmin(tenantConfiguration, c.getTransport())
 	if err != nil {
+		healthCheckFailures.WithLabelValues(namespace, name, "admin_client").Inc()
 		klog.Errorf("Error instantiating adminClnt '%s/%s': %v", tenant.Namespace, tenant.Name, err)
+		c.markHealthStale(tenant)
 		return nil, err
 	}
 
 	aClnt, err := madmin.NewAnonymousClient(tenant.MinIOServerHostAddress(), tenant.TLS())
 	if err != nil {
+		healthCheckFailures.WithLabelValues(namespace, name, "admin_client").Inc()
 		// show the error and continue
 		klog.Infof("'%s/%s': %v", tenant.Namespace, tenant.Name, err)
-		return tenant, nil
+		return c.markHealthStale(tenant), nil
 	}
 	aClnt.SetCustomTransport(c.getTransport())
 
-	hctx, hcancel := context.WithTimeout(context.Background(), 60*time.Second)
+	hctx, hcancel := context.WithTimeout(ctx, 60*time.Second)
 	defer hcancel()
 
 	// get cluster health for tenant
 	healthResult, err := aClnt.Healthy(hctx, madmin.HealthOpts{})
 	if err != nil {
+		healthCheckFailures.WithLabelValues(namespace, name, "cluster_health").Inc()
 		// show the error and continue
 		klog.Infof("'%s/%s' Failed to get cluster health: %v", tenant.Namespace, tenant.Name, err)
-		return tenant, nil
+		return c.markHealthStale(tenant), nil
 	}
 
 	tenant.Status.DrivesHealing = int32(healthResult.HealingDrives)
@@ -136,10 +278,11 @@ func (c *Controller) updateHealthStatusForTenant(tenant *miniov2.Tenant) (*minio
 	}
 
 	// check all the tenant pods, if at least 1 is not running, we go yellow
-	tenantPods, err := c.kubeClientSet.CoreV1().Pods(tenant.Namespace).List(context.Background(), metav1.ListOptions{
+	tenantPods, err := c.kubeClientSet.CoreV1().Pods(tenant.Namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: fmt.Sprintf("%s=%s", miniov2.TenantLabel, tenant.Name),
 	})
 	if err != nil {
+		healthCheckFailures.WithLabelValues(namespace, name, "pods").Inc()
 		return nil, err
 	}
 
@@ -160,13 +303,14 @@ func (c *Controller) updateHealthStatusForTenant(tenant *miniov2.Tenant) (*minio
 		tenant = tenantUpdate
 	}
 
-	srvInfoCtx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	srvInfoCtx, cancel := context.WithTimeout(ctx, 120*time.Second)
 	defer cancel()
 	storageInfo, err := adminClnt.StorageInfo(srvInfoCtx)
 	if err != nil {
+		healthCheckFailures.WithLabelValues(namespace, name, "storage_info").Inc()
 		// show the error and continue
 		klog.Infof("'%s/%s' Failed to get storage info: %v", tenant.Namespace, tenant.Name, err)
-		return tenant, nil
+		return c.markHealthStale(tenant), nil
 	}
 
 	// Raw capacity: Total amount of physical disk space reserved for MinIO
@@ -236,6 +380,26 @@ bailout:
 	tenant.Status.DrivesOnline = onlineDisks
 	tenant.Status.DrivesOffline = offlineDisks
 
+	// break the health down per pool and erasure set so unhealthy drives can be traced to their PVC
+	updatePoolsHealth(tenant, storageInfo)
+
+	// MinIO answered all the health requests, the status is current again
+	tenant.Status.HealthStaleSince = nil
+
+	if err := c.updateCapacityTrend(ctx, tenant); err != nil {
+		klog.Infof("'%s/%s' Can't update usage history: %v", tenant.Namespace, tenant.Name, err)
+	}
+
+	if remediate {
+		if err := c.syncDriveReplacements(ctx, tenant, adminClnt, tenantPods.Items, storageInfo); err != nil {
+			klog.Infof("'%s/%s' Can't sync drive replacements: %v", tenant.Namespace, tenant.Name, err)
+		}
+
+		if err := c.recoverFailedNodes(ctx, tenant, adminClnt, tenantPods.Items); err != nil {
+			klog.Infof("'%s/%s' Can't recover pods from lost nodes: %v", tenant.Namespace, tenant.Name, err)
+		}
+	}
+
 	if tenant.Status.DrivesOffline > 0 || tenant.Status.DrivesHealing > 0 {
 		tenant.Status.HealthStatus = miniov2.HealthStatusYellow
 		if tenant.Status.DrivesHealing > 0 {
@@ -260,10 +424,11 @@ bailout:
 	}
 
 	// Store the usage reported by the tiers
-	tiersStatsCtx, cancelTiers := context.WithTimeout(context.Background(), 60*time.Second)
+	tiersStatsCtx, cancelTiers := context.WithTimeout(ctx, 60*time.Second)
 	defer cancelTiers()
 	tInfos, err := adminClnt.TierStats(tiersStatsCtx)
 	if err != nil {
+		healthCheckFailures.WithLabelValues(namespace, name, "tier_stats").Inc()
 		klog.Infof("'%s/%s' Can't retrieve tenant tiers: %v", tenant.Namespace, tenant.Name, err)
 	}
 	if tInfos != nil {
@@ -281,6 +446,18 @@ bailout:
 		}
 	}
 
+	// Store the per bucket usage for chargeback
+	dataUsageCtx, cancelDataUsage := context.WithTimeout(ctx, 60*time.Second)
+	defer cancelDataUsage()
+	dataUsage, err := adminClnt.DataUsageInfo(dataUsageCtx)
+	if err != nil {
+		healthCheckFailures.WithLabelValues(namespace, name, "data_usage").Inc()
+		klog.Infof("'%s/%s' Can't retrieve bucket usage: %v", tenant.Namespace, tenant.Name, err)
+	} else if err = c.updateBucketUsageReport(ctx, tenant, dataUsage); err != nil {
+		klog.Errorf("'%s/%s' Can't update bucket usage report: %v", tenant.Namespace, tenant.Name, err)
+		c.recorder.Event(tenant, corev1.EventTypeWarning, BucketUsageReportFailedReason, fmt.Sprintf("Can't update the bucket usage report: %s", err))
+	}
+
 	return tenant, nil
 }
 
@@ -314,14 +491,18 @@ func (c *Controller) syncHealthCheckHandler(key string) (Result, error) {
 
 	tenant.EnsureDefaults()
 
-	tenant, err = c.updateHealthStatusForTenant(tenant)
+	ctx, cancel := context.WithTimeout(context.Background(), miniov2.GetMonitoringTimeout())
+	defer cancel()
+	// the scheduled check of the tenant may be running, only the health is refreshed
+	tenant, err = c.updateHealthStatusForTenant(ctx, tenant, false)
 	if err != nil {
 		klog.Errorf("%v", err)
 		return WrapResult(Result{}, err)
 	}
 
-	// Add tenant to the health check queue again until is green again
-	if tenant != nil && tenant.Status.HealthStatus != miniov2.HealthStatusGreen {
+	// Add tenant to the health check queue again until is green again, unreachable tenants are left to the
+	// backoff of the recurrent monitor
+	if tenant != nil && tenant.Status.HealthStaleSince == nil && tenant.Status.HealthStatus != miniov2.HealthStatusGreen {
 		c.healthCheckQueue.AddAfter(key, 1*time.Second)
 	}
 
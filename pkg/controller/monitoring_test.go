@@ -0,0 +1,66 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_tenantHealthSchedule(t *testing.T) {
+	const key = "ns/tenant"
+	interval := 5 * time.Minute
+	now := time.Now()
+	s := newTenantHealthSchedule()
+
+	if !s.start(key, now) {
+		t.Fatal("a new tenant must be due right away")
+	}
+	if s.start(key, now) {
+		t.Fatal("a tenant being checked must not be started twice")
+	}
+	if delay := s.finish(key, now, interval, false); delay != interval {
+		t.Errorf("delay after a successful check = %s, want %s", delay, interval)
+	}
+	if s.start(key, now.Add(interval-time.Second)) {
+		t.Error("tenant started before its interval elapsed")
+	}
+
+	// failed checks back off exponentially up to the cap
+	now = now.Add(interval)
+	for _, want := range []time.Duration{interval, 2 * interval, 4 * interval, healthCheckMaxBackoff, healthCheckMaxBackoff} {
+		if !s.start(key, now) {
+			t.Fatalf("tenant not due at %s", now)
+		}
+		delay := s.finish(key, now, interval, true)
+		if delay != want {
+			t.Errorf("backoff = %s, want %s", delay, want)
+		}
+		now = now.Add(delay)
+	}
+
+	// a successful check resets the backoff
+	s.start(key, now)
+	if delay := s.finish(key, now, interval, false); delay != interval {
+		t.Errorf("delay after recovery = %s, want %s", delay, interval)
+	}
+
+	s.retain(map[string]struct{}{})
+	if !s.start(key, now) {
+		t.Error("a removed tenant must start from scratch")
+	}
+}
@@ -0,0 +1,298 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+const (
+	// NodeLostReason is the event reason recorded when the PVCs of a pod on a lost node are about to be released
+	NodeLostReason = "NodeLost"
+	// NodeFailureRecoveryRefusedReason is the event reason recorded when releasing the PVCs of pods on lost nodes isn't safe
+	NodeFailureRecoveryRefusedReason = "NodeFailureRecoveryRefused"
+	// PVCReleasedReason is the event reason recorded when the PVC of a pod on a lost node is released
+	PVCReleasedReason = "PVCReleased"
+
+	// selectedNodeAnnotation is set by the scheduler on PVCs provisioned for a node
+	selectedNodeAnnotation = "volume.kubernetes.io/selected-node"
+)
+
+// nodeFailureGracePeriod returns how long a node has to be lost before the PVCs of its pods are released
+func nodeFailureGracePeriod(recovery *miniov2.NodeFailureRecovery) time.Duration {
+	if recovery.GracePeriodMinutes != nil {
+		return time.Duration(*recovery.GracePeriodMinutes) * time.Minute
+	}
+	return miniov2.DefaultNodeFailureGracePeriodMinutes * time.Minute
+}
+
+// podFailedSince returns since when the node of a pod is lost, either `NotReady` or deleted. The deletion time of a
+// node isn't known, so the time the pod stopped being scheduled or ready is used instead.
+func podFailedSince(pod *corev1.Pod, node *corev1.Node) (time.Time, bool) {
+	if node != nil {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue {
+				return condition.LastTransitionTime.Time, true
+			}
+		}
+		return time.Time{}, false
+	}
+	for _, conditionType := range []corev1.PodConditionType{corev1.PodScheduled, corev1.PodReady} {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == conditionType && condition.Status == corev1.ConditionFalse {
+				return condition.LastTransitionTime.Time, true
+			}
+		}
+	}
+	return pod.CreationTimestamp.Time, true
+}
+
+// podClaims returns the PVCs of a pod that exist
+func (c *Controller) podClaims(ctx context.Context, pod *corev1.Pod) ([]*corev1.PersistentVolumeClaim, error) {
+	var claims []*corev1.PersistentVolumeClaim
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		pvc, err := c.kubeClientSet.CoreV1().PersistentVolumeClaims(pod.Namespace).Get(ctx, volume.PersistentVolumeClaim.ClaimName, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		claims = append(claims, pvc)
+	}
+	return claims, nil
+}
+
+// releasedClaims returns whether a pod waiting to be scheduled uses PVCs being deleted
+func releasedClaims(pod *corev1.Pod, claims []*corev1.PersistentVolumeClaim) bool {
+	if pod.Spec.NodeName != "" || pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, pvc := range claims {
+		if pvc.DeletionTimestamp != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// podNodeName returns the node a pod is bound to. A pod that can't be scheduled anymore is bound to the node of its
+// local volumes, found from the node selected for the PVC or from the node affinity of the persistent volume.
+func (c *Controller) podNodeName(ctx context.Context, pod *corev1.Pod, claims []*corev1.PersistentVolumeClaim) (string, error) {
+	if pod.Spec.NodeName != "" {
+		return pod.Spec.NodeName, nil
+	}
+	for _, pvc := range claims {
+		if node := pvc.Annotations[selectedNodeAnnotation]; node != "" {
+			return node, nil
+		}
+		if pvc.Spec.VolumeName == "" {
+			continue
+		}
+		pv, err := c.kubeClientSet.CoreV1().PersistentVolumes().Get(ctx, pvc.Spec.VolumeName, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+			continue
+		}
+		for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+			for _, expression := range term.MatchExpressions {
+				if expression.Key == corev1.LabelHostname && expression.Operator == corev1.NodeSelectorOpIn && len(expression.Values) == 1 {
+					return expression.Values[0], nil
+				}
+			}
+		}
+	}
+	return "", nil
+}
+
+// storageInfoClient is the subset of the MinIO admin API used to check the quorum before releasing PVCs
+type storageInfoClient interface {
+	StorageInfo(ctx context.Context) (madmin.StorageInfo, error)
+}
+
+// nodeRecoveryRefusal returns why the PVCs of the pods of a pool on lost nodes can't be released, or an empty string
+// when MinIO keeps its quorum. The tenant must keep its write quorum, and every erasure set of the pool must keep its
+// write quorum without the drives of the affected pods and lose no more of them than its parity.
+func nodeRecoveryRefusal(tenant *miniov2.Tenant, poolIndex int, storageInfo madmin.StorageInfo, affected map[string]struct{}) string {
+	var online int32
+	for _, disk := range storageInfo.Disks {
+		if disk.State == madmin.DriveStateOk {
+			online++
+		}
+	}
+	if tenant.Status.WriteQuorum == 0 || online < tenant.Status.WriteQuorum {
+		return fmt.Sprintf("the tenant has %d drives online, below its write quorum of %d", online, tenant.Status.WriteQuorum)
+	}
+
+	setSizes, lostDrives, setOnline := map[int]int{}, map[int]int{}, map[int]int{}
+	for _, disk := range storageInfo.Disks {
+		if disk.PoolIndex != poolIndex {
+			continue
+		}
+		setSizes[disk.SetIndex]++
+		if _, ok := affected[drivePod(disk.Endpoint)]; ok {
+			lostDrives[disk.SetIndex]++
+		} else if disk.State == madmin.DriveStateOk {
+			setOnline[disk.SetIndex]++
+		}
+	}
+	parity := poolParity(storageInfo, poolIndex)
+	sets := make([]int, 0, len(setSizes))
+	for set := range setSizes {
+		sets = append(sets, set)
+	}
+	sort.Ints(sets)
+	for _, set := range sets {
+		if lostDrives[set] > parity {
+			return fmt.Sprintf("the pods on lost nodes hold %d drives of erasure set %d, more than its parity of %d", lostDrives[set], set, parity)
+		}
+		if writeQuorum := int(erasureSetWriteQuorum(setSizes[set], parity)); setOnline[set] < writeQuorum {
+			return fmt.Sprintf("erasure set %d has %d drives online without the pods on lost nodes, below its write quorum of %d", set, setOnline[set], writeQuorum)
+		}
+	}
+	return ""
+}
+
+// recoverFailedNodes releases the PVCs of the pods of the pools with `nodeFailureRecovery` once their node is lost for
+// longer than the grace period, and deletes the pods so the StatefulSet reschedules them with fresh PVCs on a healthy
+// node. Nothing is released while the tenant is below its write quorum or when the pods on lost nodes hold more drives
+// of an erasure set than its parity, MinIO couldn't heal the fresh drives. The quorum is checked against the storage
+// info MinIO reports right before the PVCs are released.
+func (c *Controller) recoverFailedNodes(ctx context.Context, tenant *miniov2.Tenant, adminClnt storageInfoClient, pods []corev1.Pod) error {
+	nodes := map[string]*corev1.Node{}
+	getNode := func(name string) (*corev1.Node, error) {
+		if node, ok := nodes[name]; ok {
+			return node, nil
+		}
+		node, err := c.kubeClientSet.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			node, err = nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		nodes[name] = node
+		return node, nil
+	}
+
+	for pi := range tenant.Spec.Pools {
+		pool := &tenant.Spec.Pools[pi]
+		if pool.NodeFailureRecovery == nil {
+			continue
+		}
+		gracePeriod := nodeFailureGracePeriod(pool.NodeFailureRecovery)
+
+		type failedPod struct {
+			pod    *corev1.Pod
+			node   string
+			since  time.Time
+			claims []*corev1.PersistentVolumeClaim
+		}
+		var failed []failedPod
+		affected := map[string]struct{}{}
+		for i := range pods {
+			pod := &pods[i]
+			if pod.Labels[miniov2.PoolLabel] != pool.Name {
+				continue
+			}
+			claims, err := c.podClaims(ctx, pod)
+			if err != nil {
+				return err
+			}
+			if releasedClaims(pod, claims) {
+				// the pod was recreated before its released PVCs were gone, deleting it again lets the StatefulSet
+				// recreate the PVCs
+				if err := c.kubeClientSet.CoreV1().Pods(tenant.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+					return err
+				}
+				continue
+			}
+			nodeName, err := c.podNodeName(ctx, pod, claims)
+			if err != nil {
+				return err
+			}
+			if nodeName == "" {
+				continue
+			}
+			node, err := getNode(nodeName)
+			if err != nil {
+				return err
+			}
+			since, lost := podFailedSince(pod, node)
+			if !lost {
+				continue
+			}
+			affected[pod.Name] = struct{}{}
+			if time.Since(since) >= gracePeriod {
+				failed = append(failed, failedPod{pod: pod, node: nodeName, since: since, claims: claims})
+			}
+		}
+		if len(failed) == 0 {
+			continue
+		}
+
+		// every pod on a lost node counts, including the ones still in their grace period
+		storageInfo, err := adminClnt.StorageInfo(ctx)
+		if err != nil {
+			return err
+		}
+		if refusal := nodeRecoveryRefusal(tenant, pi, storageInfo, affected); refusal != "" {
+			klog.Warningf("'%s/%s' Not releasing the PVCs of pool %s: %s", tenant.Namespace, tenant.Name, pool.Name, refusal)
+			c.recorder.Event(tenant, corev1.EventTypeWarning, NodeFailureRecoveryRefusedReason,
+				fmt.Sprintf("Not releasing the PVCs of the %d pods of pool %s on lost nodes: %s", len(affected), pool.Name, refusal))
+			continue
+		}
+
+		for _, f := range failed {
+			klog.Infof("'%s/%s' Node %s of pod %s is lost since %s, releasing its PVCs", tenant.Namespace, tenant.Name, f.node, f.pod.Name, f.since.Format(time.RFC3339))
+			c.recorder.Event(tenant, corev1.EventTypeWarning, NodeLostReason,
+				fmt.Sprintf("Node %s of pod %s is lost since %s, releasing its PVCs", f.node, f.pod.Name, f.since.Format(time.RFC3339)))
+			for _, pvc := range f.claims {
+				if err := c.kubeClientSet.CoreV1().PersistentVolumeClaims(tenant.Namespace).Delete(ctx, pvc.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+					return err
+				}
+				c.recorder.Event(tenant, corev1.EventTypeNormal, PVCReleasedReason, fmt.Sprintf("Released PVC %s of pod %s", pvc.Name, f.pod.Name))
+			}
+			// the kubelet of a lost node never confirms the deletion of the pod
+			gracePeriodSeconds := int64(0)
+			if err := c.kubeClientSet.CoreV1().Pods(tenant.Namespace).Delete(ctx, f.pod.Name, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriodSeconds}); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+type fakeStorageInfoClient struct {
+	storageInfo madmin.StorageInfo
+}
+
+func (f *fakeStorageInfoClient) StorageInfo(_ context.Context) (madmin.StorageInfo, error) {
+	return f.storageInfo, nil
+}
+
+func Test_recoverFailedNodes(t *testing.T) {
+	ctx := context.Background()
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+		},
+		Spec: miniov2.TenantSpec{
+			Pools: []miniov2.Pool{
+				{
+					Name:                "pool-0",
+					Servers:             4,
+					VolumesPerServer:    1,
+					NodeFailureRecovery: &miniov2.NodeFailureRecovery{},
+				},
+			},
+		},
+		Status: miniov2.TenantStatus{
+			DrivesOnline: 3,
+			WriteQuorum:  3,
+		},
+	}
+	node := func(name string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{
+					Type:               corev1.NodeReady,
+					Status:             ready,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+				}},
+			},
+		}
+	}
+	var storageInfo madmin.StorageInfo
+	storageInfo.Backend.StandardSCParity = 2
+
+	// run builds the pods of the pool, the first lost pods are on a lost node and the drives of the next offline pods
+	// are reported offline by MinIO
+	run := func(lostPods, offlinePods int) (*fake.Clientset, *record.FakeRecorder) {
+		objects := []runtime.Object{node("healthy", corev1.ConditionTrue), node("lost", corev1.ConditionUnknown)}
+		var pods []corev1.Pod
+		storageInfo.Disks = nil
+		for i := 0; i < 4; i++ {
+			nodeName := "healthy"
+			if i < lostPods {
+				nodeName = "lost"
+			}
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("data0-myminio-pool-0-%d", i),
+					Namespace: tenant.Namespace,
+				},
+			}
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("myminio-pool-0-%d", i),
+					Namespace: tenant.Namespace,
+					Labels:    map[string]string{miniov2.PoolLabel: "pool-0"},
+				},
+				Spec: corev1.PodSpec{
+					NodeName: nodeName,
+					Volumes: []corev1.Volume{{
+						Name: "data0",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.Name},
+						},
+					}},
+				},
+			}
+			objects = append(objects, pvc, pod.DeepCopy())
+			pods = append(pods, pod)
+			state := madmin.DriveStateOk
+			if i < lostPods+offlinePods {
+				state = madmin.DriveStateOffline
+			}
+			storageInfo.Disks = append(storageInfo.Disks, madmin.Disk{
+				Endpoint: fmt.Sprintf("https://myminio-pool-0-%d.myminio-hl.ns.svc.cluster.local:9000/export", i),
+				State:    state,
+			})
+		}
+		kubeClient := fake.NewSimpleClientset(objects...)
+		recorder := record.NewFakeRecorder(10)
+		controller := Controller{
+			kubeClientSet: kubeClient,
+			recorder:      recorder,
+		}
+		if err := controller.recoverFailedNodes(ctx, tenant, &fakeStorageInfoClient{storageInfo: storageInfo}, pods); err != nil {
+			t.Fatal(err)
+		}
+		return kubeClient, recorder
+	}
+
+	// a pod on a node lost past the grace period is rescheduled with a fresh PVC
+	kubeClient, recorder := run(1, 0)
+	if _, err := kubeClient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, "data0-myminio-pool-0-0", metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("PVC was not released: %v", err)
+	}
+	if _, err := kubeClient.CoreV1().Pods("ns").Get(ctx, "myminio-pool-0-0", metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("pod was not deleted: %v", err)
+	}
+	if _, err := kubeClient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, "data0-myminio-pool-0-1", metav1.GetOptions{}); err != nil {
+		t.Errorf("PVC on a healthy node was released: %v", err)
+	}
+	if len(recorder.Events) != 2 {
+		t.Errorf("events = %d, want 2", len(recorder.Events))
+	}
+
+	// more pods on lost nodes than the parity of the erasure set
+	kubeClient, recorder = run(3, 0)
+	if _, err := kubeClient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, "data0-myminio-pool-0-0", metav1.GetOptions{}); err != nil {
+		t.Errorf("PVC was released beyond the parity: %v", err)
+	}
+	if event := <-recorder.Events; len(recorder.Events) != 0 || !strings.HasPrefix(event, "Warning "+NodeFailureRecoveryRefusedReason) {
+		t.Errorf("event = %s, want a single refusal", event)
+	}
+
+	// MinIO reports another drive of the erasure set offline right before the PVCs are released
+	kubeClient, recorder = run(1, 1)
+	if _, err := kubeClient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, "data0-myminio-pool-0-0", metav1.GetOptions{}); err != nil {
+		t.Errorf("PVC was released below the write quorum: %v", err)
+	}
+	if event := <-recorder.Events; len(recorder.Events) != 0 || !strings.HasPrefix(event, "Warning "+NodeFailureRecoveryRefusedReason) {
+		t.Errorf("event = %s, want a single refusal", event)
+	}
+
+	// the grace period isn't over yet
+	tenant.Spec.Pools[0].NodeFailureRecovery.GracePeriodMinutes = func(m int32) *int32 { return &m }(120)
+	kubeClient, _ = run(1, 0)
+	if _, err := kubeClient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, "data0-myminio-pool-0-0", metav1.GetOptions{}); err != nil {
+		t.Errorf("PVC was released during the grace period: %v", err)
+	}
+}
@@ -17,23 +17,14 @@
 package controller
 
 import (
-	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"encoding/pem"
-	"fmt"
 	"net"
 	"net/http"
-	"slices"
-	"strings"
 	"time"
 
-	"github.com/minio/operator/pkg/certs"
-
 	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
-	xcerts "github.com/minio/pkg/certs"
-	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
@@ -44,12 +35,12 @@ const (
 	CertPasswordEnv = "OPERATOR_CERT_PASSWD"
 	// OperatorCATLSSecretName is the name of the secret for the operator CA
 	OperatorCATLSSecretName = "operator-ca-tls"
+	// TrustedCALabel marks the secrets and config maps of the operator namespace holding CA certificates to trust
+	TrustedCALabel = "operator.min.io/trusted-ca"
 	// DefaultDeploymentName is the default name of the operator deployment
 	DefaultDeploymentName = "minio-operator"
 )
 
-var serverCertsManager *xcerts.Manager
-
 func (c *Controller) fetchUserCredentials(ctx context.Context, tenant *miniov2.Tenant) []*v1.Secret {
 	var userCredentials []*v1.Secret
 	for _, credential := range tenant.Spec.Users {
@@ -62,23 +53,29 @@ func (c *Controller) fetchUserCredentials(ctx context.Context, tenant *miniov2.T
 }
 
 // getTransport returns a *http.Transport with the collection of the trusted CA certificates
-// returns a cached transport if already available
+// returns a cached transport if already available, see rebuildTrust
 func (c *Controller) getTransport() *http.Transport {
-	if c.transport != nil {
-		return c.transport
-	}
-	c.transport = c.createTransport()
-	return c.transport
+	c.trust.mu.RLock()
+	transport := c.trust.transport
+	c.trust.mu.RUnlock()
+	if transport != nil {
+		return transport
+	}
+	if err := c.rebuildTrust(context.Background()); err != nil {
+		klog.Errorf("Unable to build the trust store: %v", err)
+	}
+	c.trust.mu.RLock()
+	defer c.trust.mu.RUnlock()
+	return c.trust.transport
 }
 
-// createTransport returns a *http.Transport with the collection of the trusted CA certificates
-func (c *Controller) createTransport() *http.Transport {
-	rootCAs := c.fetchTransportCACertificates()
+// createTransport returns a *http.Transport trusting the CA certificates of rootCAs
+func (c *Controller) createTransport(rootCAs *x509.CertPool) *http.Transport {
 	dialer := &net.Dialer{
 		Timeout:   15 * time.Second,
 		KeepAlive: 15 * time.Second,
 	}
-	c.transport = &http.Transport{
+	return &http.Transport{
 		Proxy:                 http.ProxyFromEnvironment,
 		DialContext:           dialer.DialContext,
 		MaxIdleConnsPerHost:   1024,
@@ -98,146 +95,6 @@ func (c *Controller) createTransport() *http.Transport {
 			RootCAs:    rootCAs,
 		},
 	}
-
-	return c.transport
-}
-
-// fetchTransportCACertificates retrieves a *x509.CertPool with all CA that operator will trust
-func (c *Controller) fetchTransportCACertificates() (pool *x509.CertPool) {
-	rootCAs := miniov2.MustGetSystemCertPool()
-	// Default kubernetes CA certificate
-	rootCAs.AppendCertsFromPEM(miniov2.GetPodCAFromFile())
-
-	// Append all external Certificate Authorities added to Operator, secrets with prefix "operator-ca-tls"
-	secretsAvailableAtOperatorNS, _ := c.kubeClientSet.CoreV1().Secrets(miniov2.GetNSFromFile()).List(context.Background(), metav1.ListOptions{})
-	for _, secret := range secretsAvailableAtOperatorNS.Items {
-		// Check if secret starts with "operator-ca-tls-"
-		if strings.HasPrefix(secret.Name, OperatorCATLSSecretName) {
-			operatorCATLSCert, err := c.kubeClientSet.CoreV1().Secrets(miniov2.GetNSFromFile()).Get(context.Background(), secret.Name, metav1.GetOptions{})
-			if err == nil && operatorCATLSCert != nil {
-				if newPublicCert, err := getFileFromSecretDataField(operatorCATLSCert.Data, certs.PublicCertFile); err == nil {
-					rootCAs.AppendCertsFromPEM(newPublicCert)
-				}
-				if newTLSCert, err := getFileFromSecretDataField(operatorCATLSCert.Data, certs.TLSCertFile); err == nil {
-					rootCAs.AppendCertsFromPEM(newTLSCert)
-				}
-				if newCACert, err := getFileFromSecretDataField(operatorCATLSCert.Data, certs.CAPublicCertFile); err == nil {
-					rootCAs.AppendCertsFromPEM(newCACert)
-				}
-			}
-		}
-	}
-
-	return rootCAs
-}
-
-// getFileFromSecretDataField Get the value of a secret field
-// limiting the field key name to public TLS certificate file names
-func getFileFromSecretDataField(secretData map[string][]byte, key string) ([]byte, error) {
-	keys := []string{
-		certs.TLSCertFile,
-		certs.CAPublicCertFile,
-		certs.PublicCertFile,
-	}
-	if slices.Contains(keys, key) {
-		data, ok := secretData[key]
-		if ok {
-			return data, nil
-		}
-	} else {
-		return nil, fmt.Errorf("unknow TLS key '%s'", key)
-	}
-	return nil, fmt.Errorf("key '%s' not found in secret", key)
-}
-
-// TrustTLSCertificatesInSecretIfChanged Compares old and new secret content and trusts TLS certificates if field
-// content is different, looks for the fields public.crt, tls.crt and ca.crt
-func (c *Controller) TrustTLSCertificatesInSecretIfChanged(newSecret *corev1.Secret, oldSecret *corev1.Secret) bool {
-	added := false
-	if oldSecret == nil {
-		// secret did not exist before, we trust all certs in it
-		if c.trustPEMInSecretField(newSecret, certs.PublicCertFile) {
-			added = true
-		}
-		if c.trustPEMInSecretField(newSecret, certs.TLSCertFile) {
-			added = true
-		}
-		if c.trustPEMInSecretField(newSecret, certs.CAPublicCertFile) {
-			added = true
-		}
-	} else {
-		// compare to add to trust only certs that changed
-		if c.trustIfChanged(newSecret, oldSecret, certs.PublicCertFile) {
-			added = true
-		}
-		if c.trustIfChanged(newSecret, oldSecret, certs.TLSCertFile) {
-			added = true
-		}
-		if c.trustIfChanged(newSecret, oldSecret, certs.CAPublicCertFile) {
-			added = true
-		}
-	}
-	return added
-}
-
-func (c *Controller) trustIfChanged(newSecret *corev1.Secret, oldSecret *corev1.Secret, fieldToCompare string) bool {
-	if newPublicCert, err := getFileFromSecretDataField(newSecret.Data, fieldToCompare); err == nil {
-		if oldPublicCert, err := getFileFromSecretDataField(oldSecret.Data, fieldToCompare); err == nil {
-			newPublicCert = bytes.TrimSpace(newPublicCert)
-			oldPublicCert = bytes.TrimSpace(oldPublicCert)
-			// add to trust only if cert changed
-			if !bytes.Equal(oldPublicCert, newPublicCert) {
-				if err := c.addTLSCertificatesToTrustInTransport(newPublicCert); err == nil {
-					klog.Infof("Added certificates in field '%s' of '%s/%s' secret to trusted RootCA's", fieldToCompare, newSecret.Namespace, newSecret.Name)
-					return true
-				}
-				klog.Errorf("Failed adding certs in field '%s' of '%s/%s' secret: %v", fieldToCompare, newSecret.Namespace, newSecret.Name, err)
-			}
-		} else {
-			// If field was not present in old secret but is in new secret then is an addition, we trust it
-			if err := c.addTLSCertificatesToTrustInTransport(newPublicCert); err == nil {
-				klog.Infof("Added certificates in field '%s' of '%s/%s' secret to trusted RootCA's", fieldToCompare, newSecret.Namespace, newSecret.Name)
-				return true
-			}
-			klog.Errorf("Failed adding certificates in field %s of '%s/%s' secret: %v", fieldToCompare, newSecret.Namespace, newSecret.Name, err)
-		}
-	}
-	return false
-}
-
-func (c *Controller) trustPEMInSecretField(secret *corev1.Secret, fieldToCompare string) bool {
-	newPublicCert, err := getFileFromSecretDataField(secret.Data, fieldToCompare)
-	if err == nil {
-		if err := c.addTLSCertificatesToTrustInTransport(newPublicCert); err == nil {
-			klog.Infof("Added certificates in field '%s' of '%s/%s' secret to trusted RootCA's", fieldToCompare, secret.Namespace, secret.Name)
-			return true
-		}
-		klog.Errorf("Failed adding certificates in field '%s' of '%s/%s' secret: %v", fieldToCompare, secret.Namespace, secret.Name, err)
-	}
-	return false
-}
-
-func (c *Controller) addTLSCertificatesToTrustInTransport(certificateData []byte) error {
-	var x509Certs []*x509.Certificate
-	current := certificateData
-	// A single PEM file could contain more than one certificates, keeping track of the index to help debugging
-	certIndex := 1
-	for len(current) > 0 {
-		var pemBlock *pem.Block
-		if pemBlock, current = pem.Decode(current); pemBlock == nil {
-			return fmt.Errorf("invalid PEM in file in index %d", certIndex)
-		}
-		x509Cert, err := x509.ParseCertificate(pemBlock.Bytes)
-		if err != nil {
-			return fmt.Errorf("error parsing x509 certificate from PEM in index, %d: %v", certIndex, err)
-		}
-		x509Certs = append(x509Certs, x509Cert)
-		certIndex++
-	}
-	for _, cert := range x509Certs {
-		c.getTransport().TLSClientConfig.RootCAs.AddCert(cert)
-	}
-	return nil
 }
 
 func (c *Controller) createUsers(ctx context.Context, tenant *miniov2.Tenant, tenantConfiguration map[string][]byte) (err error) {
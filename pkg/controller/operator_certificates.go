@@ -0,0 +1,169 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/certs"
+	xcerts "github.com/minio/pkg/certs"
+)
+
+const operatorCertificateRenewalInterval = 10 * time.Minute
+
+// operatorServingCertificate is a certificate served by a listener of the operator
+type operatorServingCertificate struct {
+	serviceName string
+	secretName  string
+	// enabled returns true when the listener serving the certificate runs
+	enabled func() bool
+	// autoCert returns true when the operator issues the certificate
+	autoCert func() bool
+}
+
+// operatorServingCertificates lists the certificates of the operator TLS listeners, the STS listener also serves the
+// STS injection webhook. The upgrade server listens on plain HTTP.
+var operatorServingCertificates = []operatorServingCertificate{
+	{serviceName: "sts", secretName: STSTLSSecretName, enabled: IsSTSEnabled, autoCert: IsSTSAutocertEnabled},
+}
+
+// recurrentOperatorCertificateRenewal renews the serving certificates issued by the operator before they expire, the
+// replicas pick the renewed secrets up through reloadOperatorCertificate
+func (c *Controller) recurrentOperatorCertificateRenewal(ctx context.Context) {
+	ticker := time.NewTicker(operatorCertificateRenewalInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		for _, cert := range operatorServingCertificates {
+			if err := c.renewOperatorCertificate(ctx, cert); err != nil {
+				klog.Errorf("Unable to renew the %s certificate: %v", cert.serviceName, err)
+			}
+		}
+	}
+}
+
+// renewOperatorCertificate issues the certificate again through the internal CA or the CSR API when it is about to
+// expire, certificates provided by the user are left untouched
+func (c *Controller) renewOperatorCertificate(ctx context.Context, cert operatorServingCertificate) error {
+	if !cert.enabled() || !cert.autoCert() {
+		return nil
+	}
+	namespace := miniov2.GetNSFromFile()
+	secret, err := c.getCertificateSecret(ctx, namespace, cert.secretName)
+	if k8serrors.IsNotFound(err) {
+		// issued when the listener starts
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	operatorDeployment, err := c.kubeClientSet.AppsV1().Deployments(namespace).Get(ctx, DefaultDeploymentName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(secret, operatorDeployment) {
+		return nil
+	}
+	keyAlgorithm, keySize := certificateKeyParams(operatorCertificateConfig(), miniov2.CertificateKeyAlgorithmECDSA)
+	needsRenewal, err := c.certNeedsRenewal(secret, keyAlgorithm, keySize)
+	if err != nil {
+		klog.Warningf("Cannot check secret %s/%s for renewal (will be renewing): %v", secret.Namespace, secret.Name, err)
+		needsRenewal = true
+	}
+	if !needsRenewal {
+		return nil
+	}
+
+	klog.Infof("Renewing the %s certificate stored in '%s/%s'", cert.serviceName, secret.Namespace, secret.Name)
+	csrName := getCSRName(cert.serviceName)
+	// a CSR left by the previous issuance was already consumed
+	if err = c.deleteCSR(ctx, csrName); err != nil {
+		return err
+	}
+	privateKey, certBytes, caBytes, err := c.issueServiceCertificate(ctx, cert.serviceName, csrName)
+	if err != nil {
+		return err
+	}
+	if err = c.deleteCSR(ctx, csrName); err != nil {
+		klog.Infof(err.Error())
+	}
+	// the secret is updated in place, so the listeners never miss it
+	secret.Type = corev1.SecretTypeOpaque
+	secret.Data = map[string][]byte{
+		certs.PrivateKeyFile: privateKey,
+		certs.PublicCertFile: certBytes,
+	}
+	if len(caBytes) > 0 {
+		secret.Data[certs.CAPublicCertFile] = caBytes
+	}
+	_, err = c.kubeClientSet.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	return err
+}
+
+// reloadOperatorCertificate swaps the certificate served by the listener of the secret when it changes, without
+// restarting the listener
+func (c *Controller) reloadOperatorCertificate(secret *corev1.Secret) {
+	if secret.Namespace != miniov2.GetNSFromFile() {
+		return
+	}
+	for _, cert := range operatorServingCertificates {
+		if cert.secretName != secret.Name || !cert.enabled() {
+			continue
+		}
+		publicCertKey, privateKeyKey := c.getKeyNames(secret)
+		// the private key may be encrypted, it is checked by the certificate manager when reloading
+		block, _ := pem.Decode(secret.Data[publicCertKey])
+		if block == nil || len(secret.Data[privateKeyKey]) == 0 {
+			klog.Errorf("Not reloading the %s certificate, '%s/%s' holds no key pair", cert.serviceName, secret.Namespace, secret.Name)
+			return
+		}
+		leaf, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			klog.Errorf("Not reloading the %s certificate: %v", cert.serviceName, err)
+			return
+		}
+		recordCertificateExpiry(secret, CertificateTypeServing, leaf)
+
+		manager, ok := c.servingCertsManagers.Load(cert.serviceName)
+		if !ok {
+			// the listener loads the secret when it starts
+			return
+		}
+		current, err := os.ReadFile(miniov2.GetPublicCertFilePath(cert.serviceName))
+		if err == nil && bytes.Equal(current, secret.Data[publicCertKey]) {
+			return
+		}
+		c.writeCertSecretToFile(secret, cert.serviceName)
+		manager.(*xcerts.Manager).ReloadCerts()
+		klog.Infof("Reloaded the %s certificate from '%s/%s', expires on %s", cert.serviceName, secret.Namespace, secret.Name, leaf.NotAfter)
+	}
+}
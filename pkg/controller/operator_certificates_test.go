@@ -0,0 +1,83 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/certs"
+	xcerts "github.com/minio/pkg/certs"
+)
+
+func newServingCertificateSecret(t *testing.T, commonName string) (*corev1.Secret, *x509.Certificate) {
+	t.Helper()
+	cert, key, err := newCACertificate(commonName, nil, nil, time.Now(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: STSTLSSecretName, Namespace: miniov2.GetNSFromFile()},
+		Data: map[string][]byte{
+			certs.PublicCertFile: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}),
+			certs.PrivateKeyFile: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}),
+		},
+	}, cert
+}
+
+func Test_reloadOperatorCertificate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	controller := &Controller{}
+
+	secret, _ := newServingCertificateSecret(t, "sts")
+	publicCertPath, privateKeyPath := controller.writeCertSecretToFile(secret, "sts")
+	manager, err := xcerts.NewManager(ctx, publicCertPath, privateKeyPath, LoadX509KeyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	controller.servingCertsManagers.Store("sts", manager)
+
+	renewed, renewedCert := newServingCertificateSecret(t, "sts-renewed")
+	controller.reloadOperatorCertificate(renewed)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		served, err := manager.GetCertificate(&tls.ClientHelloInfo{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if served.Leaf.Equal(renewedCert) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the renewed certificate is not served, got %s", served.Leaf.Subject)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -0,0 +1,128 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/resources/poddisruptionbudgets"
+)
+
+// PodDisruptionBudgetBlocksDrainsReason is the event reason recorded when the PodDisruptionBudget of a pool doesn't let
+// any of its pods be evicted
+const PodDisruptionBudgetBlocksDrainsReason = "PodDisruptionBudgetBlocksDrains"
+
+// poolMaxUnavailable returns the number of servers of a pool that can be down at the same time without any of its
+// erasure sets losing write quorum
+func poolMaxUnavailable(pool *miniov2.Pool, tenantConfiguration map[string][]byte) int32 {
+	return int32(pool.ServerFaultTolerance(string(tenantConfiguration[miniov2.MinIOStorageClassStandard])))
+}
+
+// syncPodDisruptionBudgets creates or updates the PodDisruptionBudgets of the pools and KES so a drain never evicts
+// more pods than the erasure sets tolerate, and removes the ones of pools that are gone or disabled
+func (c *Controller) syncPodDisruptionBudgets(ctx context.Context, tenant *miniov2.Tenant, tenantConfiguration map[string][]byte) error {
+	var expected []*policyv1.PodDisruptionBudget
+	// pools whose parity doesn't tolerate the loss of a whole server, their PodDisruptionBudget blocks every drain
+	blocking := map[string]string{}
+	for i := range tenant.Spec.Pools {
+		pool := &tenant.Spec.Pools[i]
+		if poddisruptionbudgets.Enabled(pool.PodDisruptionBudget) {
+			computed := poolMaxUnavailable(pool, tenantConfiguration)
+			pdb := poddisruptionbudgets.NewForPool(tenant, pool, computed)
+			if computed == 0 && (pool.PodDisruptionBudget == nil || pool.PodDisruptionBudget.MaxUnavailable == nil) {
+				blocking[pdb.Name] = pool.Name
+			}
+			expected = append(expected, pdb)
+		}
+	}
+	if tenant.HasKESEnabled() && poddisruptionbudgets.Enabled(tenant.Spec.KES.PodDisruptionBudget) {
+		expected = append(expected, poddisruptionbudgets.NewForKES(tenant))
+	}
+
+	names := map[string]struct{}{}
+	for _, pdb := range expected {
+		names[pdb.Name] = struct{}{}
+		existing, err := c.kubeClientSet.PolicyV1().PodDisruptionBudgets(tenant.Namespace).Get(ctx, pdb.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			klog.Infof("'%s/%s' Creating PodDisruptionBudget %s", tenant.Namespace, tenant.Name, pdb.Name)
+			if _, err = c.kubeClientSet.PolicyV1().PodDisruptionBudgets(tenant.Namespace).Create(ctx, pdb, metav1.CreateOptions{}); err != nil {
+				return err
+			}
+			c.warnBlockedDrains(tenant, blocking[pdb.Name])
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !metav1.IsControlledBy(existing, tenant) {
+			c.recorder.Event(tenant, corev1.EventTypeWarning, ErrResourceExists, fmt.Sprintf(MessageResourceExists, existing.Name))
+			continue
+		}
+		if !equality.Semantic.DeepEqual(existing.Spec.MaxUnavailable, pdb.Spec.MaxUnavailable) ||
+			!equality.Semantic.DeepEqual(existing.Spec.Selector, pdb.Spec.Selector) ||
+			!equality.Semantic.DeepDerivative(pdb.Labels, existing.Labels) {
+			klog.Infof("'%s/%s' Updating PodDisruptionBudget %s", tenant.Namespace, tenant.Name, pdb.Name)
+			existing.Spec.MaxUnavailable = pdb.Spec.MaxUnavailable
+			existing.Spec.MinAvailable = nil
+			existing.Spec.Selector = pdb.Spec.Selector
+			existing.Labels = pdb.Labels
+			if _, err = c.kubeClientSet.PolicyV1().PodDisruptionBudgets(tenant.Namespace).Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+			c.warnBlockedDrains(tenant, blocking[pdb.Name])
+		}
+	}
+
+	pdbs, err := c.kubeClientSet.PolicyV1().PodDisruptionBudgets(tenant.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", miniov2.TenantLabel, tenant.Name),
+	})
+	if err != nil {
+		return err
+	}
+	for i := range pdbs.Items {
+		pdb := &pdbs.Items[i]
+		if _, ok := names[pdb.Name]; ok || !metav1.IsControlledBy(pdb, tenant) {
+			continue
+		}
+		klog.Infof("'%s/%s' Deleting PodDisruptionBudget %s", tenant.Namespace, tenant.Name, pdb.Name)
+		if err := c.kubeClientSet.PolicyV1().PodDisruptionBudgets(tenant.Namespace).Delete(ctx, pdb.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// warnBlockedDrains records a warning when the PodDisruptionBudget of the pool doesn't let any of its pods be evicted,
+// so the node drains it blocks can be told apart from a stuck drain
+func (c *Controller) warnBlockedDrains(tenant *miniov2.Tenant, pool string) {
+	if pool == "" {
+		return
+	}
+	message := fmt.Sprintf("Pool %s can't lose a server without an erasure set losing write quorum, its PodDisruptionBudget blocks the drain of its nodes. "+
+		"Set podDisruptionBudget.maxUnavailable or disable the PodDisruptionBudget of the pool to allow drains", pool)
+	klog.Warningf("'%s/%s' %s", tenant.Namespace, tenant.Name, message)
+	c.recorder.Event(tenant, corev1.EventTypeWarning, PodDisruptionBudgetBlocksDrainsReason, message)
+}
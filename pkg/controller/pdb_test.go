@@ -0,0 +1,156 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+func Test_poolMaxUnavailable(t *testing.T) {
+	tests := []struct {
+		name             string
+		servers          int32
+		volumesPerServer int32
+		storageClass     string
+		want             int32
+	}{
+		{name: "4x4", servers: 4, volumesPerServer: 4, want: 1},
+		{name: "4x1", servers: 4, volumesPerServer: 1, want: 1},
+		{name: "16x1", servers: 16, volumesPerServer: 1, want: 4},
+		{name: "8x2 EC:2", servers: 8, volumesPerServer: 2, storageClass: "EC:2", want: 1},
+		{name: "6x1", servers: 6, volumesPerServer: 1, want: 2},
+		{name: "single server", servers: 1, volumesPerServer: 4, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configuration := map[string][]byte{}
+			if tt.storageClass != "" {
+				configuration[miniov2.MinIOStorageClassStandard] = []byte(tt.storageClass)
+			}
+			pool := &miniov2.Pool{Servers: tt.servers, VolumesPerServer: tt.volumesPerServer}
+			if got := poolMaxUnavailable(pool, configuration); got != tt.want {
+				t.Errorf("poolMaxUnavailable() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_syncPodDisruptionBudgets(t *testing.T) {
+	ctx := context.Background()
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+		},
+		Spec: miniov2.TenantSpec{
+			Pools: []miniov2.Pool{
+				{Name: "pool-0", Servers: 16, VolumesPerServer: 1},
+				{Name: "pool-1", Servers: 4, VolumesPerServer: 4},
+			},
+		},
+	}
+	kubeClient := fake.NewSimpleClientset()
+	controller := Controller{
+		kubeClientSet: kubeClient,
+		recorder:      record.NewFakeRecorder(10),
+	}
+	if err := controller.syncPodDisruptionBudgets(ctx, tenant, nil); err != nil {
+		t.Fatal(err)
+	}
+	pdb, err := kubeClient.PolicyV1().PodDisruptionBudgets("ns").Get(ctx, "myminio-pool-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pdb.Spec.MaxUnavailable.IntValue() != 4 || pdb.Spec.Selector.MatchLabels[miniov2.PoolLabel] != "pool-0" {
+		t.Errorf("pdb = %+v, want maxUnavailable 4 for pool-0", pdb.Spec)
+	}
+
+	// an override is applied and the PDB of a disabled pool is removed
+	override := intstr.FromString("25%")
+	disabled := false
+	tenant.Spec.Pools[0].PodDisruptionBudget = &miniov2.PodDisruptionBudgetConfig{MaxUnavailable: &override}
+	tenant.Spec.Pools[1].PodDisruptionBudget = &miniov2.PodDisruptionBudgetConfig{Enabled: &disabled}
+	if err := controller.syncPodDisruptionBudgets(ctx, tenant, nil); err != nil {
+		t.Fatal(err)
+	}
+	if pdb, err = kubeClient.PolicyV1().PodDisruptionBudgets("ns").Get(ctx, "myminio-pool-0", metav1.GetOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if pdb.Spec.MaxUnavailable.String() != "25%" {
+		t.Errorf("maxUnavailable = %s, want 25%%", pdb.Spec.MaxUnavailable.String())
+	}
+	if _, err = kubeClient.PolicyV1().PodDisruptionBudgets("ns").Get(ctx, "myminio-pool-1", metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("PDB of the disabled pool was not removed: %v", err)
+	}
+}
+
+func Test_syncPodDisruptionBudgetsBlockedDrains(t *testing.T) {
+	ctx := context.Background()
+	// 2 servers of 4 drives at EC:2 can't lose a whole server
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+		},
+		Spec: miniov2.TenantSpec{
+			Pools: []miniov2.Pool{
+				{Name: "pool-0", Servers: 2, VolumesPerServer: 4},
+			},
+		},
+	}
+	tenantConfiguration := map[string][]byte{miniov2.MinIOStorageClassStandard: []byte("EC:2")}
+	kubeClient := fake.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10)
+	controller := Controller{
+		kubeClientSet: kubeClient,
+		recorder:      recorder,
+	}
+	if err := controller.syncPodDisruptionBudgets(ctx, tenant, tenantConfiguration); err != nil {
+		t.Fatal(err)
+	}
+	pdb, err := kubeClient.PolicyV1().PodDisruptionBudgets("ns").Get(ctx, "myminio-pool-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pdb.Spec.MaxUnavailable.IntValue() != 0 {
+		t.Errorf("maxUnavailable = %s, want 0", pdb.Spec.MaxUnavailable.String())
+	}
+	if len(recorder.Events) != 1 {
+		t.Fatalf("events = %d, want the blocked drains warning", len(recorder.Events))
+	}
+	if event := <-recorder.Events; !strings.Contains(event, PodDisruptionBudgetBlocksDrainsReason) {
+		t.Errorf("event = %q, want %s", event, PodDisruptionBudgetBlocksDrainsReason)
+	}
+
+	// the warning isn't repeated while the PodDisruptionBudget is unchanged
+	if err := controller.syncPodDisruptionBudgets(ctx, tenant, tenantConfiguration); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("events = %d, want 0", len(recorder.Events))
+	}
+}
@@ -0,0 +1,192 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/minio/madmin-go/v3"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+// drivePod returns the pod serving a drive from its endpoint, the pod name is the first label of the host
+func drivePod(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	return strings.SplitN(u.Hostname(), ".", 2)[0]
+}
+
+// drivePath returns the path of a drive in the pod
+func drivePath(disk madmin.Disk) string {
+	if disk.DrivePath != "" {
+		return disk.DrivePath
+	}
+	if u, err := url.Parse(disk.Endpoint); err == nil && u.Path != "" {
+		return u.Path
+	}
+	return disk.Endpoint
+}
+
+// drivePVC returns the PVC backing a drive, volumes are mounted at `<mountpath><index>` when a pool has more than
+// one volume per server and claims are named `<template><index>-<pod>` by the StatefulSet
+func drivePVC(tenant *miniov2.Tenant, pool *miniov2.Pool, pod, drivePath string) string {
+	if pod == "" {
+		return ""
+	}
+	volumeIndex := 0
+	if pool.VolumesPerServer > 1 {
+		suffix := strings.TrimPrefix(path.Clean(drivePath), path.Clean(tenant.Spec.Mountpath))
+		digits := suffix
+		if i := strings.IndexFunc(suffix, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+			digits = suffix[:i]
+		}
+		index, err := strconv.Atoi(digits)
+		if err != nil {
+			return ""
+		}
+		volumeIndex = index
+	}
+	claimName := miniov2.MinIOVolumeName
+	if pool.VolumeClaimTemplate != nil {
+		claimName = pool.VolumeClaimTemplate.Name
+	}
+	return claimName + strconv.Itoa(volumeIndex) + "-" + pod
+}
+
+// erasureSetWriteQuorum returns the number of drives needed to write to an erasure set
+func erasureSetWriteQuorum(drives, parity int) int32 {
+	return int32(miniov2.ErasureSetWriteQuorum(drives, parity))
+}
+
+// poolParity returns the parity of the standard storage class of a pool, per-pool parity is not always returned so the
+// standard parity is assumed
+func poolParity(storageInfo madmin.StorageInfo, poolIndex int) int {
+	if poolIndex < len(storageInfo.Backend.StandardSCParities) {
+		return storageInfo.Backend.StandardSCParities[poolIndex]
+	}
+	return storageInfo.Backend.StandardSCParity
+}
+
+// updatePoolsHealth breaks down the storage info per pool and erasure set, and collects the drives that are not online
+// or are healing. MinIO reports pools in the order they are declared in the tenant.
+func updatePoolsHealth(tenant *miniov2.Tenant, storageInfo madmin.StorageInfo) {
+	type erasureSet struct {
+		online, offline, healing int32
+	}
+	nrOfPools := len(tenant.Spec.Pools)
+	sets := make([]map[int]*erasureSet, nrOfPools)
+	rawCapacities := make([]uint64, nrOfPools)
+	rawUsages := make([]uint64, nrOfPools)
+	var unhealthyDrives []miniov2.UnhealthyDrive
+
+	for _, disk := range storageInfo.Disks {
+		pi := disk.PoolIndex
+		if pi < 0 || pi >= nrOfPools {
+			continue
+		}
+		if sets[pi] == nil {
+			sets[pi] = map[int]*erasureSet{}
+		}
+		set, ok := sets[pi][disk.SetIndex]
+		if !ok {
+			set = &erasureSet{}
+			sets[pi][disk.SetIndex] = set
+		}
+		rawCapacities[pi] += disk.AvailableSpace
+		rawUsages[pi] += disk.UsedSpace
+
+		state := disk.State
+		if disk.State == madmin.DriveStateOk {
+			set.online++
+			if !disk.Healing {
+				continue
+			}
+			set.healing++
+			state = "healing"
+		} else {
+			set.offline++
+		}
+
+		pool := &tenant.Spec.Pools[pi]
+		pod := drivePod(disk.Endpoint)
+		path := drivePath(disk)
+		unhealthyDrives = append(unhealthyDrives, miniov2.UnhealthyDrive{
+			Pool:  pool.Name,
+			Pod:   pod,
+			Path:  path,
+			PVC:   drivePVC(tenant, pool, pod, path),
+			State: state,
+		})
+	}
+
+	for pi := range tenant.Spec.Pools {
+		ssName := tenant.PoolStatefulsetName(&tenant.Spec.Pools[pi])
+		var poolStatus *miniov2.PoolStatus
+		for i := range tenant.Status.Pools {
+			if tenant.Status.Pools[i].SSName == ssName {
+				poolStatus = &tenant.Status.Pools[i]
+				break
+			}
+		}
+		if poolStatus == nil {
+			continue
+		}
+
+		parity := poolParity(storageInfo, pi)
+		if pi < len(storageInfo.Backend.StandardSCData) {
+			poolEfficiency := float64(storageInfo.Backend.StandardSCData[pi]) / float64(storageInfo.Backend.StandardSCData[pi]+parity)
+			poolStatus.Capacity = safeToInt64(uint64(poolEfficiency * float64(rawCapacities[pi])))
+			poolStatus.Usage = safeToInt64(uint64(poolEfficiency * float64(rawUsages[pi])))
+		}
+
+		poolStatus.DrivesOnline, poolStatus.DrivesOffline, poolStatus.DrivesHealing = 0, 0, 0
+		poolStatus.ErasureSets = nil
+		for si := 0; si < len(sets[pi]); si++ {
+			set, ok := sets[pi][si]
+			if !ok {
+				continue
+			}
+			poolStatus.DrivesOnline += set.online
+			poolStatus.DrivesOffline += set.offline
+			poolStatus.DrivesHealing += set.healing
+
+			setStatus := miniov2.ErasureSetStatus{
+				Index:         int32(si),
+				DrivesOnline:  set.online,
+				DrivesOffline: set.offline,
+				DrivesHealing: set.healing,
+				WriteQuorum:   erasureSetWriteQuorum(int(set.online+set.offline), parity),
+				HealthStatus:  miniov2.HealthStatusGreen,
+			}
+			if set.offline > 0 || set.healing > 0 {
+				setStatus.HealthStatus = miniov2.HealthStatusYellow
+			}
+			if setStatus.DrivesOnline < setStatus.WriteQuorum {
+				setStatus.HealthStatus = miniov2.HealthStatusRed
+			}
+			poolStatus.ErasureSets = append(poolStatus.ErasureSets, setStatus)
+		}
+	}
+
+	tenant.Status.UnhealthyDrives = unhealthyDrives
+}
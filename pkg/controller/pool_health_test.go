@@ -0,0 +1,116 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_updatePoolsHealth(t *testing.T) {
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+		},
+		Spec: miniov2.TenantSpec{
+			Mountpath: miniov2.MinIOVolumeMountPath,
+			Pools: []miniov2.Pool{
+				{Name: "pool-0", Servers: 2, VolumesPerServer: 2},
+				{Name: "pool-1", Servers: 4, VolumesPerServer: 1},
+			},
+		},
+		Status: miniov2.TenantStatus{
+			Pools: []miniov2.PoolStatus{
+				{SSName: "myminio-pool-0", State: miniov2.PoolInitialized},
+				{SSName: "myminio-pool-1", State: miniov2.PoolInitialized},
+			},
+		},
+	}
+
+	var disks []madmin.Disk
+	for server := 0; server < 2; server++ {
+		for volume := 0; volume < 2; volume++ {
+			disks = append(disks, madmin.Disk{
+				Endpoint:       fmt.Sprintf("https://myminio-pool-0-%d.myminio-hl.ns.svc.cluster.local:9000/export%d/data", server, volume),
+				DrivePath:      fmt.Sprintf("/export%d/data", volume),
+				State:          madmin.DriveStateOk,
+				PoolIndex:      0,
+				SetIndex:       0,
+				AvailableSpace: 100,
+				UsedSpace:      10,
+			})
+		}
+	}
+	for server := 0; server < 4; server++ {
+		disks = append(disks, madmin.Disk{
+			Endpoint:       fmt.Sprintf("https://myminio-pool-1-%d.myminio-hl.ns.svc.cluster.local:9000/export", server),
+			DrivePath:      "/export",
+			State:          madmin.DriveStateOk,
+			PoolIndex:      1,
+			SetIndex:       0,
+			AvailableSpace: 100,
+			UsedSpace:      10,
+		})
+	}
+	disks[1].Healing = true
+	disks[5].State = madmin.DriveStateOffline
+	disks[6].State = madmin.DriveStateUnformatted
+
+	storageInfo := madmin.StorageInfo{Disks: disks}
+	storageInfo.Backend.StandardSCData = []int{2, 2}
+	storageInfo.Backend.StandardSCParity = 2
+
+	updatePoolsHealth(tenant, storageInfo)
+
+	pool0 := tenant.Status.Pools[0]
+	if pool0.Capacity != 200 || pool0.Usage != 20 {
+		t.Errorf("pool-0 capacity/usage = %d/%d, want 200/20", pool0.Capacity, pool0.Usage)
+	}
+	if pool0.DrivesOnline != 4 || pool0.DrivesOffline != 0 || pool0.DrivesHealing != 1 {
+		t.Errorf("pool-0 drives = %d/%d/%d, want 4/0/1", pool0.DrivesOnline, pool0.DrivesOffline, pool0.DrivesHealing)
+	}
+	wantSets0 := []miniov2.ErasureSetStatus{
+		{Index: 0, DrivesOnline: 4, DrivesHealing: 1, WriteQuorum: 3, HealthStatus: miniov2.HealthStatusYellow},
+	}
+	if !reflect.DeepEqual(pool0.ErasureSets, wantSets0) {
+		t.Errorf("pool-0 erasure sets = %+v, want %+v", pool0.ErasureSets, wantSets0)
+	}
+
+	pool1 := tenant.Status.Pools[1]
+	if pool1.DrivesOnline != 2 || pool1.DrivesOffline != 2 {
+		t.Errorf("pool-1 drives = %d/%d, want 2/2", pool1.DrivesOnline, pool1.DrivesOffline)
+	}
+	if len(pool1.ErasureSets) != 1 || pool1.ErasureSets[0].HealthStatus != miniov2.HealthStatusRed {
+		t.Errorf("pool-1 erasure sets = %+v, want a single red set", pool1.ErasureSets)
+	}
+
+	wantDrives := []miniov2.UnhealthyDrive{
+		{Pool: "pool-0", Pod: "myminio-pool-0-0", Path: "/export1/data", PVC: "export1-myminio-pool-0-0", State: "healing"},
+		{Pool: "pool-1", Pod: "myminio-pool-1-1", Path: "/export", PVC: "export0-myminio-pool-1-1", State: madmin.DriveStateOffline},
+		{Pool: "pool-1", Pod: "myminio-pool-1-2", Path: "/export", PVC: "export0-myminio-pool-1-2", State: madmin.DriveStateUnformatted},
+	}
+	if !reflect.DeepEqual(tenant.Status.UnhealthyDrives, wantDrives) {
+		t.Errorf("unhealthy drives = %+v, want %+v", tenant.Status.UnhealthyDrives, wantDrives)
+	}
+}
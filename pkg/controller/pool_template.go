@@ -0,0 +1,127 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+const (
+	// PoolExpansionPendingReason is the event reason recorded when a pool from the template waits for approval
+	PoolExpansionPendingReason = "PoolExpansionPending"
+	// PoolExpandedReason is the event reason recorded when a pool from the template is appended to the tenant
+	PoolExpandedReason = "PoolExpanded"
+)
+
+// nextTemplatePoolName returns the name of the next pool generated from the pool template, the first `<prefix>-<index>`
+// not used by a pool of the spec or the status, so pools being decommissioned are never reused
+func nextTemplatePoolName(tenant *miniov2.Tenant) string {
+	prefix := tenant.Spec.PoolTemplate.Pool.Name
+	used := map[string]struct{}{}
+	for i := range tenant.Spec.Pools {
+		used[tenant.PoolStatefulsetName(&tenant.Spec.Pools[i])] = struct{}{}
+	}
+	for _, poolStatus := range tenant.Status.Pools {
+		used[poolStatus.SSName] = struct{}{}
+	}
+	for index := 0; ; index++ {
+		pool := miniov2.Pool{Name: fmt.Sprintf("%s-%d", prefix, index)}
+		if _, ok := used[tenant.PoolStatefulsetName(&pool)]; !ok {
+			return pool.Name
+		}
+	}
+}
+
+// poolExpansionNeeded returns whether the tenant uses more than the threshold of its capacity and can still grow. The
+// usage of every pool has to be reported first, so a pool that was just appended is accounted before the next one.
+func poolExpansionNeeded(tenant *miniov2.Tenant) bool {
+	template := tenant.Spec.PoolTemplate
+	if template == nil || int32(len(tenant.Spec.Pools)) >= template.MaxPools {
+		return false
+	}
+	if len(tenant.Status.Pools) != len(tenant.Spec.Pools) {
+		return false
+	}
+	for _, poolStatus := range tenant.Status.Pools {
+		if poolStatus.State != miniov2.PoolInitialized || poolStatus.Capacity <= 0 {
+			return false
+		}
+	}
+	threshold := int64(miniov2.DefaultPoolTemplateThresholdPercent)
+	if template.ThresholdPercent != nil {
+		threshold = int64(*template.ThresholdPercent)
+	}
+	usage := tenant.Status.Usage
+	return usage.Capacity > 0 && usage.Usage*100 >= usableSpace(usage.Usage, usage.Capacity)*threshold
+}
+
+// expandPoolsFromTemplate appends a pool built from the pool template once the tenant runs out of capacity and the
+// expansion is approved. It returns true when the spec of the tenant was updated, the new pool is then deployed by the
+// next sync through the regular pool expansion.
+func (c *Controller) expandPoolsFromTemplate(ctx context.Context, tenant *miniov2.Tenant) (*miniov2.Tenant, bool, error) {
+	if !poolExpansionNeeded(tenant) {
+		if tenant.Status.PendingPoolExpansion == "" {
+			return tenant, false, nil
+		}
+		tenant.Status.PendingPoolExpansion = ""
+		tenant, err := c.updatePoolStatus(ctx, tenant)
+		return tenant, false, err
+	}
+
+	name := nextTemplatePoolName(tenant)
+	approval := tenant.Annotations[miniov2.PoolExpansionApprovalAnnotation]
+	if approval != miniov2.PoolExpansionApprovalAlways && approval != name {
+		if tenant.Status.PendingPoolExpansion == name {
+			return tenant, false, nil
+		}
+		tenant.Status.PendingPoolExpansion = name
+		c.recorder.Event(tenant, corev1.EventTypeWarning, PoolExpansionPendingReason,
+			fmt.Sprintf("Tenant is running out of capacity, annotate it with %s=%s to add pool %s", miniov2.PoolExpansionApprovalAnnotation, name, name))
+		tenant, err := c.updatePoolStatus(ctx, tenant)
+		return tenant, false, err
+	}
+
+	// the spec is updated from the latest version of the tenant, defaults are not persisted
+	latest, err := c.minioClientSet.MinioV2().Tenants(tenant.Namespace).Get(ctx, tenant.Name, metav1.GetOptions{})
+	if err != nil {
+		return tenant, false, err
+	}
+	pool := tenant.Spec.PoolTemplate.Pool.DeepCopy()
+	pool.Name = name
+	latest.Spec.Pools = append(latest.Spec.Pools, *pool)
+	if approval == name {
+		// single pool approvals are consumed by the expansion
+		delete(latest.Annotations, miniov2.PoolExpansionApprovalAnnotation)
+	}
+	if latest, err = c.minioClientSet.MinioV2().Tenants(tenant.Namespace).Update(ctx, latest, metav1.UpdateOptions{}); err != nil {
+		return tenant, false, err
+	}
+	klog.Infof("'%s/%s' Appended pool %s from the pool template", tenant.Namespace, tenant.Name, name)
+	c.recorder.Event(latest, corev1.EventTypeNormal, PoolExpandedReason,
+		fmt.Sprintf("Pool %s appended, the tenant uses %d%% of its capacity", name, tenant.Status.Usage.Usage*100/tenant.Status.Usage.Capacity))
+
+	latest.Status.PendingPoolExpansion = ""
+	latest, err = c.updatePoolStatus(ctx, latest)
+	return latest, true, err
+}
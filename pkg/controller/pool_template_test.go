@@ -0,0 +1,127 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	miniofake "github.com/minio/operator/pkg/client/clientset/versioned/fake"
+)
+
+func Test_expandPoolsFromTemplate(t *testing.T) {
+	ctx := context.Background()
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+		},
+		Spec: miniov2.TenantSpec{
+			Pools: []miniov2.Pool{
+				{Name: "pool-0", Servers: 4, VolumesPerServer: 4},
+			},
+			PoolTemplate: &miniov2.PoolTemplate{
+				Pool:     miniov2.Pool{Name: "expansion", Servers: 4, VolumesPerServer: 4},
+				MaxPools: 2,
+			},
+		},
+		Status: miniov2.TenantStatus{
+			Pools: []miniov2.PoolStatus{
+				{SSName: "myminio-pool-0", State: miniov2.PoolInitialized, Capacity: 15, Usage: 85},
+			},
+			Usage: miniov2.TenantUsage{Capacity: 15, Usage: 85},
+		},
+	}
+	// the capacity is the free space, 45 bytes used with 55 bytes free is below the threshold
+	halfFull := tenant.DeepCopy()
+	halfFull.Status.Usage = miniov2.TenantUsage{Capacity: 55, Usage: 45}
+	if poolExpansionNeeded(halfFull) {
+		t.Error("expansion needed below the threshold")
+	}
+	if !poolExpansionNeeded(tenant) {
+		t.Error("expansion not needed above the threshold")
+	}
+	if name := nextTemplatePoolName(tenant); name != "expansion-0" {
+		t.Errorf("next pool = %s, want expansion-0", name)
+	}
+	// a pool being decommissioned keeps its name reserved
+	decommissioning := tenant.DeepCopy()
+	decommissioning.Status.Pools = append(decommissioning.Status.Pools, miniov2.PoolStatus{SSName: "myminio-expansion-0", State: miniov2.PoolInitialized})
+	if name := nextTemplatePoolName(decommissioning); name != "expansion-1" {
+		t.Errorf("next pool = %s, want expansion-1", name)
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	controller := Controller{
+		minioClientSet: miniofake.NewSimpleClientset(tenant.DeepCopy()),
+		recorder:       recorder,
+	}
+
+	// without approval the pool is only proposed
+	updated, expanded, err := controller.expandPoolsFromTemplate(ctx, tenant.DeepCopy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expanded || updated.Status.PendingPoolExpansion != "expansion-0" {
+		t.Errorf("expanded = %v, pending = %q, want a pending expansion-0", expanded, updated.Status.PendingPoolExpansion)
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("events = %d, want 1", len(recorder.Events))
+	}
+
+	// approving the proposed pool appends it and consumes the approval
+	latest, _ := controller.minioClientSet.MinioV2().Tenants("ns").Get(ctx, "myminio", metav1.GetOptions{})
+	// the fake clientset doesn't keep the spec apart from the status
+	latest.Spec = tenant.Spec
+	latest.Annotations = map[string]string{miniov2.PoolExpansionApprovalAnnotation: "expansion-0"}
+	if _, err = controller.minioClientSet.MinioV2().Tenants("ns").Update(ctx, latest, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	approved := tenant.DeepCopy()
+	approved.Annotations = latest.Annotations
+	if _, expanded, err = controller.expandPoolsFromTemplate(ctx, approved); err != nil {
+		t.Fatal(err)
+	}
+	if !expanded {
+		t.Fatal("approved pool was not appended")
+	}
+	for _, action := range controller.minioClientSet.(*miniofake.Clientset).Actions() {
+		if update, ok := action.(k8stesting.UpdateAction); ok && update.GetSubresource() == "" {
+			latest = update.GetObject().(*miniov2.Tenant)
+		}
+	}
+	if len(latest.Spec.Pools) != 2 || latest.Spec.Pools[1].Name != "expansion-0" || latest.Spec.Pools[1].Servers != 4 {
+		t.Errorf("pools = %+v, want expansion-0 appended", latest.Spec.Pools)
+	}
+	if _, ok := latest.Annotations[miniov2.PoolExpansionApprovalAnnotation]; ok {
+		t.Error("single pool approval was not consumed")
+	}
+
+	// the max pools is never exceeded
+	full := tenant.DeepCopy()
+	full.Spec.Pools = latest.Spec.Pools
+	full.Annotations = map[string]string{miniov2.PoolExpansionApprovalAnnotation: miniov2.PoolExpansionApprovalAlways}
+	full.Status.Pools = append(full.Status.Pools, miniov2.PoolStatus{SSName: "myminio-expansion-0", State: miniov2.PoolInitialized, Capacity: 15, Usage: 85})
+	if poolExpansionNeeded(full) {
+		t.Error("expansion needed beyond the max pools")
+	}
+}
@@ -16,20 +16,25 @@ package controller
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"reflect"
 	"strings"
+	"time"
 
 	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	promv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 
 	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
 	"github.com/minio/operator/pkg/resources/configmaps"
+	"github.com/minio/operator/pkg/resources/monitors"
 )
 
 // MinIOPrometheusMetrics holds metrics pulled from prometheus
@@ -119,6 +124,42 @@ func (c *Controller) getPrometheus(ctx context.Context) (promv1.PrometheusInterf
 	return instances[0], nil
 }
 
+// syncPrometheusOperatorConfig hands the tenant scrape configuration to the prometheus-operator in the configured
+// mode, scraping with the dedicated metrics user, and cleans up whatever the other modes left behind
+func (c *Controller) syncPrometheusOperatorConfig(ctx context.Context, tenant *miniov2.Tenant, tenantConfiguration map[string][]byte) error {
+	if !tenant.HasPrometheusOperatorEnabled() {
+		if err := c.deletePrometheusRule(ctx, tenant); err != nil {
+			return err
+		}
+		if err := c.deletePrometheusAddlConfig(ctx, tenant); err != nil {
+			return err
+		}
+		if err := c.deletePrometheusMonitor(ctx, tenant); err != nil {
+			return err
+		}
+		return c.deletePrometheusMetricsUser(ctx, tenant, tenantConfiguration)
+	}
+
+	if err := c.syncPrometheusRule(ctx, tenant); err != nil {
+		return err
+	}
+	accessKey, secretKey, err := c.getPrometheusMetricsCredentials(ctx, tenant, tenantConfiguration)
+	if err != nil {
+		return err
+	}
+	if tenant.GetPrometheusOperatorMode() == miniov2.PrometheusOperatorModeAdditionalScrapeConfigs {
+		if err = c.checkAndCreatePrometheusAddlConfig(ctx, tenant, accessKey, secretKey); err != nil {
+			return err
+		}
+		return c.deletePrometheusMonitor(ctx, tenant)
+	}
+	if err = c.checkAndCreatePrometheusMonitor(ctx, tenant, accessKey, secretKey); err != nil {
+		return err
+	}
+	// the tenant may have switched from the additional scrape configs mode
+	return c.deletePrometheusAddlConfig(ctx, tenant)
+}
+
 func (c *Controller) checkAndCreatePrometheusAddlConfig(ctx context.Context, tenant *miniov2.Tenant, accessKey, secretKey string) error {
 	ns := miniov2.GetPrometheusNamespace()
 
@@ -291,3 +332,232 @@ func (c *Controller) deletePrometheusAddlConfig(ctx context.Context, tenant *min
 	}
 	return nil
 }
+
+// checkAndCreatePrometheusMonitor creates or updates the ServiceMonitor or PodMonitor of the tenant, along with the
+// secret holding the bearer token it references. Both are owned by the tenant and removed with it.
+func (c *Controller) checkAndCreatePrometheusMonitor(ctx context.Context, tenant *miniov2.Tenant, accessKey, secretKey string) error {
+	if err := c.checkAndCreatePrometheusBearerToken(ctx, tenant, accessKey, secretKey); err != nil {
+		return err
+	}
+
+	if tenant.GetPrometheusOperatorMode() == miniov2.PrometheusOperatorModePodMonitor {
+		if err := c.deletePrometheusServiceMonitor(ctx, tenant); err != nil {
+			return err
+		}
+		expected := monitors.NewPodMonitorForMinIO(tenant)
+		existing, err := c.promClient.MonitoringV1().PodMonitors(tenant.Namespace).Get(ctx, expected.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			klog.Infof("Creating MinIO tenant %s/%s PodMonitor", tenant.Namespace, tenant.Name)
+			_, err = c.promClient.MonitoringV1().PodMonitors(tenant.Namespace).Create(ctx, expected, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if !equality.Semantic.DeepEqual(existing.Spec, expected.Spec) || !equality.Semantic.DeepDerivative(expected.Labels, existing.Labels) {
+			klog.Infof("Updating MinIO tenant %s/%s PodMonitor", tenant.Namespace, tenant.Name)
+			existing.Spec = expected.Spec
+			existing.Labels = expected.Labels
+			_, err = c.promClient.MonitoringV1().PodMonitors(tenant.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+		}
+		return err
+	}
+
+	if err := c.deletePrometheusPodMonitor(ctx, tenant); err != nil {
+		return err
+	}
+	expected := monitors.NewServiceMonitorForMinIO(tenant)
+	existing, err := c.promClient.MonitoringV1().ServiceMonitors(tenant.Namespace).Get(ctx, expected.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		klog.Infof("Creating MinIO tenant %s/%s ServiceMonitor", tenant.Namespace, tenant.Name)
+		_, err = c.promClient.MonitoringV1().ServiceMonitors(tenant.Namespace).Create(ctx, expected, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if !equality.Semantic.DeepEqual(existing.Spec, expected.Spec) || !equality.Semantic.DeepDerivative(expected.Labels, existing.Labels) {
+		klog.Infof("Updating MinIO tenant %s/%s ServiceMonitor", tenant.Namespace, tenant.Name)
+		existing.Spec = expected.Spec
+		existing.Labels = expected.Labels
+		_, err = c.promClient.MonitoringV1().ServiceMonitors(tenant.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+	}
+	return err
+}
+
+// checkAndCreatePrometheusBearerToken stores the bearer token used by the monitors in the tenant namespace, the
+// token is only re-issued when it no longer matches the tenant credentials
+func (c *Controller) checkAndCreatePrometheusBearerToken(ctx context.Context, tenant *miniov2.Tenant, accessKey, secretKey string) error {
+	secretName := tenant.PrometheusBearerTokenSecretName()
+	secret, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Get(ctx, secretName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			Type: "Opaque",
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            secretName,
+				Namespace:       tenant.Namespace,
+				Labels:          tenant.MinIOPodLabels(),
+				OwnerReferences: tenant.OwnerRef(),
+			},
+			Data: map[string][]byte{
+				miniov2.PrometheusBearerTokenSecretKey: []byte(tenant.GenBearerToken(accessKey, secretKey)),
+			},
+		}
+		_, err = c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	accKey, err := miniov2.GetAccessKeyFromBearerToken(string(secret.Data[miniov2.PrometheusBearerTokenSecretKey]), secretKey)
+	if err == nil && accKey == accessKey {
+		return nil
+	}
+	klog.Infof("Updating MinIO tenant %s/%s Prometheus bearer token", tenant.Namespace, tenant.Name)
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[miniov2.PrometheusBearerTokenSecretKey] = []byte(tenant.GenBearerToken(accessKey, secretKey))
+	_, err = c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	return err
+}
+
+// deletePrometheusMonitor removes the ServiceMonitor, PodMonitor and bearer token secret of the tenant, if any
+func (c *Controller) deletePrometheusMonitor(ctx context.Context, tenant *miniov2.Tenant) error {
+	// the bearer token secret is created before the monitors and removed last, if it is gone there is nothing to clean
+	_, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Get(ctx, tenant.PrometheusBearerTokenSecretName(), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := c.deletePrometheusServiceMonitor(ctx, tenant); err != nil {
+		return err
+	}
+	if err := c.deletePrometheusPodMonitor(ctx, tenant); err != nil {
+		return err
+	}
+	klog.Infof("Deleting MinIO tenant %s/%s Prometheus monitor", tenant.Namespace, tenant.Name)
+	err = c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Delete(ctx, tenant.PrometheusBearerTokenSecretName(), metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) deletePrometheusServiceMonitor(ctx context.Context, tenant *miniov2.Tenant) error {
+	err := c.promClient.MonitoringV1().ServiceMonitors(tenant.Namespace).Delete(ctx, tenant.PrometheusMonitorName(), metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) deletePrometheusPodMonitor(ctx context.Context, tenant *miniov2.Tenant) error {
+	err := c.promClient.MonitoringV1().PodMonitors(tenant.Namespace).Delete(ctx, tenant.PrometheusMonitorName(), metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// syncPrometheusRule creates or updates the PrometheusRule of the tenant when `prometheusRules` is set, and removes
+// it otherwise
+func (c *Controller) syncPrometheusRule(ctx context.Context, tenant *miniov2.Tenant) error {
+	if !tenant.HasPrometheusRulesEnabled() {
+		return c.deletePrometheusRule(ctx, tenant)
+	}
+
+	certificates, err := c.getPrometheusRuleCertificates(ctx, tenant)
+	if err != nil {
+		return err
+	}
+	expected := monitors.NewPrometheusRuleForMinIO(tenant, certificates)
+	existing, err := c.promClient.MonitoringV1().PrometheusRules(tenant.Namespace).Get(ctx, expected.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		klog.Infof("Creating MinIO tenant %s/%s PrometheusRule", tenant.Namespace, tenant.Name)
+		_, err = c.promClient.MonitoringV1().PrometheusRules(tenant.Namespace).Create(ctx, expected, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if !equality.Semantic.DeepEqual(existing.Spec, expected.Spec) || !equality.Semantic.DeepDerivative(expected.Labels, existing.Labels) {
+		klog.Infof("Updating MinIO tenant %s/%s PrometheusRule", tenant.Namespace, tenant.Name)
+		existing.Spec = expected.Spec
+		existing.Labels = expected.Labels
+		_, err = c.promClient.MonitoringV1().PrometheusRules(tenant.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+	}
+	return err
+}
+
+// getPrometheusRuleCertificates returns the earliest expiry of each certificate served or used by MinIO and KES, user
+// provided certificates and CAs are taken from the tenant status and the auto generated ones from their secrets
+func (c *Controller) getPrometheusRuleCertificates(ctx context.Context, tenant *miniov2.Tenant) ([]monitors.CertificateExpiry, error) {
+	var certificates []monitors.CertificateExpiry
+	expiries := map[string]time.Time{}
+	add := func(name string, notAfter time.Time) {
+		if current, ok := expiries[name]; !ok || notAfter.Before(current) {
+			if !ok {
+				certificates = append(certificates, monitors.CertificateExpiry{Name: name})
+			}
+			expiries[name] = notAfter
+		}
+	}
+
+	var generatedSecrets []string
+	if tenant.AutoCert() {
+		generatedSecrets = append(generatedSecrets, tenant.MinIOTLSSecretName())
+		if tenant.HasKESEnabled() {
+			if !tenant.ExternalClientCert() {
+				generatedSecrets = append(generatedSecrets, tenant.MinIOClientTLSSecretName())
+			}
+			if !tenant.KESExternalCert() {
+				generatedSecrets = append(generatedSecrets, tenant.KESTLSSecretName())
+			}
+		}
+	}
+	for _, name := range generatedSecrets {
+		secret, err := c.getCertificateSecret(ctx, tenant.Namespace, name)
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		publicKey, _ := c.getKeyNames(secret)
+		if block, _ := pem.Decode(secret.Data[publicKey]); block != nil {
+			if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+				add(secret.Name, cert.NotAfter)
+			}
+		}
+	}
+
+	if customCertificates := tenant.Status.Certificates.CustomCertificates; customCertificates != nil {
+		var custom []*miniov2.CustomCertificateConfig
+		for _, group := range [][]*miniov2.CustomCertificateConfig{customCertificates.Minio, customCertificates.Client, customCertificates.MinioCAs, customCertificates.KES} {
+			custom = append(custom, group...)
+		}
+		for _, cert := range custom {
+			notAfter, err := time.Parse(time.RFC3339, cert.Expiry)
+			if err != nil {
+				continue
+			}
+			add(cert.CertName, notAfter)
+		}
+	}
+
+	for i := range certificates {
+		certificates[i].NotAfter = expiries[certificates[i].Name]
+	}
+	return certificates, nil
+}
+
+func (c *Controller) deletePrometheusRule(ctx context.Context, tenant *miniov2.Tenant) error {
+	err := c.promClient.MonitoringV1().PrometheusRules(tenant.Namespace).Delete(ctx, tenant.PrometheusRuleName(), metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
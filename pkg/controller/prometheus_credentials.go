@@ -0,0 +1,232 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/auth/utils"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// prometheusMetricsPolicy only allows reading the metrics endpoints
+	prometheusMetricsPolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["admin:Prometheus"]}]}`
+	// errCodeServiceAccountNotFound is the error code MinIO returns for an unknown service account
+	errCodeServiceAccountNotFound = "XMinioInvalidIAMCredentials"
+)
+
+// prometheusAdminClient is the subset of the MinIO admin API used to manage the metrics user
+type prometheusAdminClient interface {
+	AddCannedPolicy(ctx context.Context, policyName string, policy []byte) error
+	AddUser(ctx context.Context, accessKey, secretKey string) error
+	SetPolicy(ctx context.Context, policyName, entityName string, isGroup bool) error
+	GetUserInfo(ctx context.Context, name string) (madmin.UserInfo, error)
+	RemoveUser(ctx context.Context, accessKey string) error
+	AddServiceAccount(ctx context.Context, opts madmin.AddServiceAccountReq) (madmin.Credentials, error)
+	InfoServiceAccount(ctx context.Context, accessKey string) (madmin.InfoServiceAccountResp, error)
+	UpdateServiceAccount(ctx context.Context, accessKey string, opts madmin.UpdateServiceAccountReq) error
+	DeleteServiceAccount(ctx context.Context, serviceAccount string) error
+}
+
+// getPrometheusMetricsCredentials returns the credentials Prometheus uses to scrape the tenant. A dedicated MinIO user
+// limited to `admin:Prometheus` is provisioned and its secret key rotated on a schedule, tenants authenticating users
+// through LDAP can't have internal users and get a service account of the root user limited to the same policy instead.
+func (c *Controller) getPrometheusMetricsCredentials(ctx context.Context, tenant *miniov2.Tenant, tenantConfiguration map[string][]byte) (string, string, error) {
+	adminClient, err := tenant.NewMinIOAdmin(tenantConfiguration, c.getTransport())
+	if err != nil {
+		return "", "", err
+	}
+	return c.checkAndCreatePrometheusMetricsUser(ctx, tenant, adminClient, prometheusMetricsParentUser(tenantConfiguration))
+}
+
+// prometheusMetricsParentUser returns the root access key when the metrics credentials must be a service account of the
+// root user, or an empty string when they are an internal MinIO user
+func prometheusMetricsParentUser(tenantConfiguration map[string][]byte) string {
+	if ldapAddress, ok := tenantConfiguration["MINIO_IDENTITY_LDAP_SERVER_ADDR"]; ok && string(ldapAddress) != "" {
+		return string(tenantConfiguration["accesskey"])
+	}
+	return ""
+}
+
+// checkAndCreatePrometheusMetricsUser provisions the metrics credentials and stores them in a secret, they are a service
+// account of parentUser when set and an internal MinIO user otherwise
+func (c *Controller) checkAndCreatePrometheusMetricsUser(ctx context.Context, tenant *miniov2.Tenant, adminClient prometheusAdminClient, parentUser string) (string, string, error) {
+	secretName := tenant.PrometheusMetricsUserSecretName()
+	secret, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return "", "", err
+	}
+
+	if k8serrors.IsNotFound(err) {
+		accessKey, secretKey := miniov2.PrometheusMetricsUserAccessKey, utils.RandomCharString(40)
+		if err = provisionPrometheusMetricsUser(ctx, adminClient, parentUser, accessKey, secretKey); err != nil {
+			return "", "", err
+		}
+		klog.Infof("Created Prometheus metrics user for tenant %s/%s", tenant.Namespace, tenant.Name)
+		secret = &corev1.Secret{
+			Type: "Opaque",
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: tenant.Namespace,
+				Labels:    tenant.MinIOPodLabels(),
+				Annotations: map[string]string{
+					miniov2.PrometheusCredentialsRotatedAtAnnotation: time.Now().UTC().Format(time.RFC3339),
+				},
+				OwnerReferences: tenant.OwnerRef(),
+			},
+			Data: map[string][]byte{
+				"accesskey": []byte(accessKey),
+				"secretkey": []byte(secretKey),
+			},
+		}
+		_, err = c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+		return accessKey, secretKey, err
+	}
+
+	accessKey, secretKey := string(secret.Data["accesskey"]), string(secret.Data["secretkey"])
+	rotatedAt, err := time.Parse(time.RFC3339, secret.Annotations[miniov2.PrometheusCredentialsRotatedAtAnnotation])
+	if err != nil || time.Since(rotatedAt) > miniov2.GetPrometheusCredentialsRotationInterval() || accessKey == "" {
+		// the new secret key is applied to MinIO first, the secret is only updated once MinIO accepted it
+		if accessKey == "" {
+			accessKey = miniov2.PrometheusMetricsUserAccessKey
+		}
+		secretKey = utils.RandomCharString(40)
+		if err = provisionPrometheusMetricsUser(ctx, adminClient, parentUser, accessKey, secretKey); err != nil {
+			return "", "", err
+		}
+		klog.Infof("Rotated Prometheus metrics user credentials for tenant %s/%s", tenant.Namespace, tenant.Name)
+		c.recorder.Event(tenant, corev1.EventTypeNormal, "PrometheusCredentialsRotated", "Prometheus metrics user credentials rotated")
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[miniov2.PrometheusCredentialsRotatedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		secret.Data = map[string][]byte{
+			"accesskey": []byte(accessKey),
+			"secretkey": []byte(secretKey),
+		}
+		_, err = c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return accessKey, secretKey, err
+	}
+
+	// recreate the user if it was removed from MinIO, eg: after a tenant redeployment or a root credentials rotation
+	exists, err := prometheusMetricsUserExists(ctx, adminClient, parentUser, accessKey)
+	if err != nil {
+		return "", "", err
+	}
+	if !exists {
+		if err = provisionPrometheusMetricsUser(ctx, adminClient, parentUser, accessKey, secretKey); err != nil {
+			return "", "", err
+		}
+		klog.Infof("Recreated Prometheus metrics user for tenant %s/%s", tenant.Namespace, tenant.Name)
+	}
+	return accessKey, secretKey, nil
+}
+
+// prometheusMetricsUserExists checks the metrics user exists in MinIO, a service account must also belong to parentUser
+func prometheusMetricsUserExists(ctx context.Context, adminClient prometheusAdminClient, parentUser, accessKey string) (bool, error) {
+	if parentUser != "" {
+		info, err := adminClient.InfoServiceAccount(ctx, accessKey)
+		if err != nil {
+			if madmin.ToErrorResponse(err).Code == errCodeServiceAccountNotFound {
+				return false, nil
+			}
+			return false, err
+		}
+		return info.ParentUser == parentUser, nil
+	}
+	if _, err := adminClient.GetUserInfo(ctx, accessKey); err != nil {
+		if madmin.ToErrorResponse(err).Code == "XMinioAdminNoSuchUser" {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// provisionPrometheusMetricsUser creates or updates the metrics user along with its policy
+func provisionPrometheusMetricsUser(ctx context.Context, adminClient prometheusAdminClient, parentUser, accessKey, secretKey string) error {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	if parentUser != "" {
+		return provisionPrometheusMetricsServiceAccount(ctx, adminClient, parentUser, accessKey, secretKey)
+	}
+	if err := adminClient.AddCannedPolicy(ctx, miniov2.PrometheusMetricsPolicyName, []byte(prometheusMetricsPolicy)); err != nil {
+		return err
+	}
+	if err := adminClient.AddUser(ctx, accessKey, secretKey); err != nil {
+		return err
+	}
+	return adminClient.SetPolicy(ctx, miniov2.PrometheusMetricsPolicyName, accessKey, false)
+}
+
+// provisionPrometheusMetricsServiceAccount creates or updates a service account of parentUser restricted to the metrics
+// policy, a service account left from previous root credentials is replaced
+func provisionPrometheusMetricsServiceAccount(ctx context.Context, adminClient prometheusAdminClient, parentUser, accessKey, secretKey string) error {
+	info, err := adminClient.InfoServiceAccount(ctx, accessKey)
+	if err == nil && info.ParentUser == parentUser {
+		return adminClient.UpdateServiceAccount(ctx, accessKey, madmin.UpdateServiceAccountReq{
+			NewPolicy:    []byte(prometheusMetricsPolicy),
+			NewSecretKey: secretKey,
+		})
+	}
+	if err == nil {
+		if err = adminClient.DeleteServiceAccount(ctx, accessKey); err != nil {
+			return err
+		}
+	} else if madmin.ToErrorResponse(err).Code != errCodeServiceAccountNotFound {
+		return err
+	}
+	_, err = adminClient.AddServiceAccount(ctx, madmin.AddServiceAccountReq{
+		Policy:      []byte(prometheusMetricsPolicy),
+		AccessKey:   accessKey,
+		SecretKey:   secretKey,
+		Description: "Prometheus metrics, managed by the MinIO Operator",
+	})
+	return err
+}
+
+// deletePrometheusMetricsUser removes the metrics user from MinIO and its secret, if it was ever created
+func (c *Controller) deletePrometheusMetricsUser(ctx context.Context, tenant *miniov2.Tenant, tenantConfiguration map[string][]byte) error {
+	secret, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Get(ctx, tenant.PrometheusMetricsUserSecretName(), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	adminClient, err := tenant.NewMinIOAdmin(tenantConfiguration, c.getTransport())
+	if err != nil {
+		return err
+	}
+	accessKey := string(secret.Data["accesskey"])
+	if prometheusMetricsParentUser(tenantConfiguration) != "" {
+		if err = adminClient.DeleteServiceAccount(ctx, accessKey); err != nil && madmin.ToErrorResponse(err).Code != errCodeServiceAccountNotFound {
+			return err
+		}
+	} else if err = adminClient.RemoveUser(ctx, accessKey); err != nil && madmin.ToErrorResponse(err).Code != "XMinioAdminNoSuchUser" {
+		return err
+	}
+	klog.Infof("Deleted Prometheus metrics user for tenant %s/%s", tenant.Namespace, tenant.Name)
+	return c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{})
+}
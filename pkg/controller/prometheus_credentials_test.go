@@ -0,0 +1,229 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+type fakePrometheusAdminClient struct {
+	users           map[string]string
+	policies        map[string]string
+	serviceAccounts map[string]fakeServiceAccount
+	// rootUser is the parent of the service accounts created
+	rootUser string
+}
+
+type fakeServiceAccount struct {
+	parentUser, secretKey, policy string
+}
+
+func (f *fakePrometheusAdminClient) AddCannedPolicy(_ context.Context, _ string, _ []byte) error {
+	return nil
+}
+
+func (f *fakePrometheusAdminClient) AddUser(_ context.Context, accessKey, secretKey string) error {
+	f.users[accessKey] = secretKey
+	return nil
+}
+
+func (f *fakePrometheusAdminClient) SetPolicy(_ context.Context, policyName, entityName string, _ bool) error {
+	f.policies[entityName] = policyName
+	return nil
+}
+
+func (f *fakePrometheusAdminClient) GetUserInfo(_ context.Context, name string) (madmin.UserInfo, error) {
+	if _, ok := f.users[name]; !ok {
+		return madmin.UserInfo{}, madmin.ErrorResponse{Code: "XMinioAdminNoSuchUser"}
+	}
+	return madmin.UserInfo{}, nil
+}
+
+func (f *fakePrometheusAdminClient) RemoveUser(_ context.Context, accessKey string) error {
+	delete(f.users, accessKey)
+	return nil
+}
+
+func (f *fakePrometheusAdminClient) AddServiceAccount(_ context.Context, opts madmin.AddServiceAccountReq) (madmin.Credentials, error) {
+	f.serviceAccounts[opts.AccessKey] = fakeServiceAccount{parentUser: f.rootUser, secretKey: opts.SecretKey, policy: string(opts.Policy)}
+	return madmin.Credentials{AccessKey: opts.AccessKey, SecretKey: opts.SecretKey}, nil
+}
+
+func (f *fakePrometheusAdminClient) InfoServiceAccount(_ context.Context, accessKey string) (madmin.InfoServiceAccountResp, error) {
+	sa, ok := f.serviceAccounts[accessKey]
+	if !ok {
+		return madmin.InfoServiceAccountResp{}, madmin.ErrorResponse{Code: errCodeServiceAccountNotFound}
+	}
+	return madmin.InfoServiceAccountResp{ParentUser: sa.parentUser, Policy: sa.policy}, nil
+}
+
+func (f *fakePrometheusAdminClient) UpdateServiceAccount(_ context.Context, accessKey string, opts madmin.UpdateServiceAccountReq) error {
+	sa := f.serviceAccounts[accessKey]
+	sa.secretKey, sa.policy = opts.NewSecretKey, string(opts.NewPolicy)
+	f.serviceAccounts[accessKey] = sa
+	return nil
+}
+
+func (f *fakePrometheusAdminClient) DeleteServiceAccount(_ context.Context, accessKey string) error {
+	delete(f.serviceAccounts, accessKey)
+	return nil
+}
+
+func Test_checkAndCreatePrometheusMetricsUser(t *testing.T) {
+	ctx := context.Background()
+	kubeclient := fake.NewSimpleClientset()
+	controller := Controller{
+		kubeClientSet: kubeclient,
+		recorder:      record.NewFakeRecorder(10),
+	}
+	adminClient := &fakePrometheusAdminClient{users: map[string]string{}, policies: map[string]string{}}
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tenantA",
+			Namespace: "tenantA-ns",
+		},
+	}
+
+	accessKey, secretKey, err := controller.checkAndCreatePrometheusMetricsUser(ctx, tenant, adminClient, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accessKey != miniov2.PrometheusMetricsUserAccessKey || adminClient.users[accessKey] != secretKey {
+		t.Fatalf("metrics user not provisioned in MinIO")
+	}
+	if adminClient.policies[accessKey] != miniov2.PrometheusMetricsPolicyName {
+		t.Fatalf("expected policy %s to be attached, got %s", miniov2.PrometheusMetricsPolicyName, adminClient.policies[accessKey])
+	}
+
+	// credentials are stable until the rotation interval elapses
+	_, sameSecretKey, err := controller.checkAndCreatePrometheusMetricsUser(ctx, tenant, adminClient, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sameSecretKey != secretKey {
+		t.Fatalf("secret key rotated before the rotation interval")
+	}
+
+	// the user is recreated when it is missing in MinIO
+	delete(adminClient.users, accessKey)
+	if _, _, err = controller.checkAndCreatePrometheusMetricsUser(ctx, tenant, adminClient, ""); err != nil {
+		t.Fatal(err)
+	}
+	if adminClient.users[accessKey] != secretKey {
+		t.Fatalf("metrics user not recreated in MinIO")
+	}
+
+	// rotate once the interval elapsed
+	secret, err := kubeclient.CoreV1().Secrets(tenant.Namespace).Get(ctx, tenant.PrometheusMetricsUserSecretName(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret.Annotations[miniov2.PrometheusCredentialsRotatedAtAnnotation] = time.Now().Add(-miniov2.GetPrometheusCredentialsRotationInterval() - time.Hour).UTC().Format(time.RFC3339)
+	if _, err = kubeclient.CoreV1().Secrets(tenant.Namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	_, rotatedSecretKey, err := controller.checkAndCreatePrometheusMetricsUser(ctx, tenant, adminClient, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotatedSecretKey == secretKey || adminClient.users[accessKey] != rotatedSecretKey {
+		t.Fatalf("secret key was not rotated")
+	}
+	secret, err = kubeclient.CoreV1().Secrets(tenant.Namespace).Get(ctx, tenant.PrometheusMetricsUserSecretName(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data["secretkey"]) != rotatedSecretKey {
+		t.Fatalf("rotated secret key not stored")
+	}
+}
+
+func Test_checkAndCreatePrometheusMetricsServiceAccount(t *testing.T) {
+	ctx := context.Background()
+	kubeclient := fake.NewSimpleClientset()
+	controller := Controller{
+		kubeClientSet: kubeclient,
+		recorder:      record.NewFakeRecorder(10),
+	}
+	adminClient := &fakePrometheusAdminClient{users: map[string]string{}, policies: map[string]string{}, serviceAccounts: map[string]fakeServiceAccount{}, rootUser: "minio"}
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tenantA",
+			Namespace: "tenantA-ns",
+		},
+	}
+	tenantConfiguration := map[string][]byte{
+		"accesskey":                       []byte("minio"),
+		"secretkey":                       []byte("minio123"),
+		"MINIO_IDENTITY_LDAP_SERVER_ADDR": []byte("ldap.example.com:636"),
+	}
+	parentUser := prometheusMetricsParentUser(tenantConfiguration)
+	if parentUser != "minio" {
+		t.Fatalf("parent user = %q, want the root access key for LDAP tenants", parentUser)
+	}
+
+	// LDAP tenants get a service account restricted to the metrics policy, never the root credentials
+	accessKey, secretKey, err := controller.checkAndCreatePrometheusMetricsUser(ctx, tenant, adminClient, parentUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accessKey == "minio" || secretKey == "minio123" {
+		t.Fatalf("the root credentials are used to scrape the tenant")
+	}
+	sa, ok := adminClient.serviceAccounts[accessKey]
+	if !ok || sa.secretKey != secretKey || sa.policy != prometheusMetricsPolicy {
+		t.Fatalf("service account = %+v, want it restricted to the metrics policy", sa)
+	}
+	if len(adminClient.users) != 0 {
+		t.Fatalf("internal users created for an LDAP tenant: %v", adminClient.users)
+	}
+
+	// a service account of previous root credentials is replaced
+	sa.parentUser = "previous-root"
+	adminClient.serviceAccounts[accessKey] = sa
+	if _, _, err = controller.checkAndCreatePrometheusMetricsUser(ctx, tenant, adminClient, parentUser); err != nil {
+		t.Fatal(err)
+	}
+	if adminClient.serviceAccounts[accessKey].parentUser != parentUser || adminClient.serviceAccounts[accessKey].secretKey != secretKey {
+		t.Fatalf("service account = %+v, want it recreated under the current root user", adminClient.serviceAccounts[accessKey])
+	}
+
+	// rotate once the interval elapsed
+	secret, err := kubeclient.CoreV1().Secrets(tenant.Namespace).Get(ctx, tenant.PrometheusMetricsUserSecretName(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret.Annotations[miniov2.PrometheusCredentialsRotatedAtAnnotation] = time.Now().Add(-miniov2.GetPrometheusCredentialsRotationInterval() - time.Hour).UTC().Format(time.RFC3339)
+	if _, err = kubeclient.CoreV1().Secrets(tenant.Namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	_, rotatedSecretKey, err := controller.checkAndCreatePrometheusMetricsUser(ctx, tenant, adminClient, parentUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotatedSecretKey == secretKey || adminClient.serviceAccounts[accessKey].secretKey != rotatedSecretKey {
+		t.Fatalf("service account secret key was not rotated")
+	}
+}
@@ -17,6 +17,8 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -547,3 +549,187 @@ func Test_checkAndCreatePrometheusAddlConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkAndCreatePrometheusMonitor(t *testing.T) {
+	ctx := context.Background()
+	kubeclient := fake3.NewSimpleClientset()
+	promClient := fake.NewSimpleClientset()
+	controller := Controller{
+		promClient:    promClient,
+		kubeClientSet: kubeclient,
+	}
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tenantA",
+			Namespace: "tenantA-ns",
+		},
+		Spec: miniov2.TenantSpec{
+			PrometheusOperator:                   true,
+			PrometheusOperatorMode:               miniov2.PrometheusOperatorModeServiceMonitor,
+			PrometheusOperatorScrapeMetricsPaths: []string{"/minio/v2/metrics/cluster", "/minio/metrics/v3/api"},
+			PrometheusOperatorMonitorLabels:      map[string]string{"release": "prometheus"},
+		},
+	}
+
+	if err := controller.checkAndCreatePrometheusMonitor(ctx, tenant, "accessKey", "secretKey"); err != nil {
+		t.Fatal(err)
+	}
+	sm, err := promClient.MonitoringV1().ServiceMonitors(tenant.Namespace).Get(ctx, tenant.PrometheusMonitorName(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sm.Spec.Endpoints) != 2 || sm.Spec.Endpoints[1].Path != "/minio/metrics/v3/api" {
+		t.Fatalf("unexpected ServiceMonitor endpoints %+v", sm.Spec.Endpoints)
+	}
+	// the cluster metrics are scraped once through the service, the node metrics on every server
+	if relabelings := sm.Spec.Endpoints[0].RelabelConfigs; len(relabelings) == 0 || relabelings[0].TargetLabel != "__address__" ||
+		!strings.HasPrefix(*relabelings[0].Replacement, tenant.MinIOFQDNServiceName()+":") {
+		t.Errorf("cluster metrics relabelings = %+v, want the service address", relabelings)
+	}
+	if len(sm.Spec.Endpoints[1].RelabelConfigs) != 0 {
+		t.Errorf("node metrics relabelings = %+v, want every server scraped", sm.Spec.Endpoints[1].RelabelConfigs)
+	}
+	if sm.Labels["release"] != "prometheus" {
+		t.Fatalf("expected monitor labels to be set, got %v", sm.Labels)
+	}
+	tokenSecret, err := kubeclient.CoreV1().Secrets(tenant.Namespace).Get(ctx, tenant.PrometheusBearerTokenSecretName(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	accessKey, err := miniov2.GetAccessKeyFromBearerToken(string(tokenSecret.Data[miniov2.PrometheusBearerTokenSecretKey]), "secretKey")
+	if err != nil || accessKey != "accessKey" {
+		t.Fatalf("unexpected bearer token, access key %s, err %v", accessKey, err)
+	}
+
+	// switching to a PodMonitor removes the ServiceMonitor
+	tenant.Spec.PrometheusOperatorMode = miniov2.PrometheusOperatorModePodMonitor
+	if err = controller.checkAndCreatePrometheusMonitor(ctx, tenant, "accessKey", "secretKey"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = promClient.MonitoringV1().PodMonitors(tenant.Namespace).Get(ctx, tenant.PrometheusMonitorName(), metav1.GetOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = promClient.MonitoringV1().ServiceMonitors(tenant.Namespace).Get(ctx, tenant.PrometheusMonitorName(), metav1.GetOptions{}); err == nil {
+		t.Fatal("expected the ServiceMonitor to be deleted")
+	}
+
+	if err = controller.deletePrometheusMonitor(ctx, tenant); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = promClient.MonitoringV1().PodMonitors(tenant.Namespace).Get(ctx, tenant.PrometheusMonitorName(), metav1.GetOptions{}); err == nil {
+		t.Fatal("expected the PodMonitor to be deleted")
+	}
+}
+
+func Test_syncPrometheusRule(t *testing.T) {
+	ctx := context.Background()
+	promClient := fake.NewSimpleClientset()
+	controller := Controller{
+		promClient:    promClient,
+		kubeClientSet: fake3.NewSimpleClientset(),
+	}
+	notAfter := time.Now().Add(10 * 24 * time.Hour).UTC().Truncate(time.Second)
+	warningThreshold := int32(60)
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tenantA",
+			Namespace: "tenantA-ns",
+		},
+		Spec: miniov2.TenantSpec{
+			PrometheusOperator:     true,
+			PrometheusOperatorMode: miniov2.PrometheusOperatorModePodMonitor,
+			PrometheusRules: &miniov2.PrometheusRulesConfig{
+				Labels:                   map[string]string{"release": "prometheus"},
+				AlertLabels:              map[string]string{"severity": "page"},
+				CapacityWarningThreshold: &warningThreshold,
+			},
+		},
+		Status: miniov2.TenantStatus{
+			Certificates: miniov2.CertificateStatus{
+				CustomCertificates: &miniov2.CustomCertificates{
+					Minio: []*miniov2.CustomCertificateConfig{
+						{CertName: "tenant-tls", Expiry: notAfter.Format(time.RFC3339)},
+					},
+					MinioCAs: []*miniov2.CustomCertificateConfig{
+						{CertName: "tenant-ca", Expiry: notAfter.Format(time.RFC3339)},
+					},
+					KES: []*miniov2.CustomCertificateConfig{
+						{CertName: "kes-tls", Expiry: notAfter.Format(time.RFC3339)},
+						{CertName: "kes-client-tls", Expiry: notAfter.Format(time.RFC3339)},
+					},
+				},
+			},
+		},
+	}
+
+	if err := controller.syncPrometheusRule(ctx, tenant); err != nil {
+		t.Fatal(err)
+	}
+	rule, err := promClient.MonitoringV1().PrometheusRules(tenant.Namespace).Get(ctx, tenant.PrometheusRuleName(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.Labels["release"] != "prometheus" {
+		t.Fatalf("expected rule labels to be set, got %v", rule.Labels)
+	}
+	alerts := map[string]v1.Rule{}
+	expiringCertificates := map[string]bool{}
+	for _, r := range rule.Spec.Groups[0].Rules {
+		alerts[r.Alert] = r
+		if r.Alert == "MinIOCertificateExpiring" {
+			expiringCertificates[r.Labels["certificate"]] = true
+		}
+		if r.Labels["severity"] != "page" || r.Labels["tenant"] != tenant.Name {
+			t.Fatalf("unexpected labels on alert %s: %v", r.Alert, r.Labels)
+		}
+	}
+	for _, alert := range []string{"MinIODrivesOffline", "MinIOWriteQuorumAtRisk", "MinIOHealingTooLong", "MinIOCapacityWarning", "MinIOCapacityCritical", "MinIONodesOffline", "MinIODriveErrors", "MinIOCertificateExpiring"} {
+		if _, ok := alerts[alert]; !ok {
+			t.Fatalf("missing alert %s", alert)
+		}
+	}
+	if expr := alerts["MinIOCapacityWarning"].Expr.StrVal; !strings.HasSuffix(expr, "> 60") || !strings.Contains(expr, `job="tenantA-ns/tenantA-minio"`) {
+		t.Fatalf("unexpected capacity warning expression %s", expr)
+	}
+	for _, name := range []string{"tenant-tls", "tenant-ca", "kes-tls", "kes-client-tls"} {
+		if !expiringCertificates[name] {
+			t.Fatalf("missing certificate expiry alert for %s", name)
+		}
+	}
+	// the drive error alerts need the node metrics
+	if paths := tenant.GetPrometheusOperatorScrapeMetricsPaths(); !slices.Contains(paths, miniov2.PrometheusNodeMetricsPath) {
+		t.Fatalf("node metrics not scraped with the alerting rules enabled: %v", paths)
+	}
+	if expr := alerts["MinIOCertificateExpiring"].Expr.StrVal; expr != fmt.Sprintf("vector(%d) - time() < %d", notAfter.Unix(), 30*24*60*60) {
+		t.Fatalf("unexpected certificate expiry expression %s", expr)
+	}
+
+	// the additional scrape configs scrape a random server, the drive error alerts are not generated
+	tenant.Spec.PrometheusOperatorMode = miniov2.PrometheusOperatorModeAdditionalScrapeConfigs
+	if err = controller.syncPrometheusRule(ctx, tenant); err != nil {
+		t.Fatal(err)
+	}
+	if rule, err = promClient.MonitoringV1().PrometheusRules(tenant.Namespace).Get(ctx, tenant.PrometheusRuleName(), metav1.GetOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range rule.Spec.Groups[0].Rules {
+		if r.Alert == "MinIODriveErrors" {
+			t.Fatalf("drive error alert generated with the additional scrape configs: %s", r.Expr.StrVal)
+		}
+	}
+	if paths := tenant.GetPrometheusOperatorScrapeMetricsPaths(); slices.Contains(paths, miniov2.PrometheusNodeMetricsPath) {
+		t.Fatalf("node metrics scraped through the service: %v", paths)
+	}
+
+	// rules are removed once disabled
+	tenant.Spec.PrometheusRules = nil
+	if err = controller.syncPrometheusRule(ctx, tenant); err != nil {
+		t.Fatal(err)
+	}
+	if paths := tenant.GetPrometheusOperatorScrapeMetricsPaths(); slices.Contains(paths, miniov2.PrometheusNodeMetricsPath) {
+		t.Fatalf("node metrics scraped with the alerting rules disabled: %v", paths)
+	}
+	if _, err = promClient.MonitoringV1().PrometheusRules(tenant.Namespace).Get(ctx, tenant.PrometheusRuleName(), metav1.GetOptions{}); err == nil {
+		t.Fatal("expected the PrometheusRule to be deleted")
+	}
+}
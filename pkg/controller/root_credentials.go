@@ -0,0 +1,425 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/auth/utils"
+	"github.com/minio/operator/pkg/common"
+)
+
+const (
+	// RootCredentialsRotationStartedReason is the event reason recorded when new root credentials are detected
+	RootCredentialsRotationStartedReason = "RootCredentialsRotationStarted"
+	// RootCredentialsRotationBlockedReason is the event reason recorded when the root credentials can't be rotated
+	RootCredentialsRotationBlockedReason = "RootCredentialsRotationBlocked"
+	// RootCredentialsRotatedReason is the event reason recorded once MinIO runs with the new root credentials
+	RootCredentialsRotatedReason = "RootCredentialsRotated"
+	// RootCredentialsRotationFailedReason is the event reason recorded when a rotation doesn't complete in time
+	RootCredentialsRotationFailedReason = "RootCredentialsRotationFailed"
+
+	// rootCredentialsRetirementDelay is how long the previous root credentials stay valid once MinIO accepts the new ones
+	rootCredentialsRetirementDelay = time.Hour
+	// rootCredentialsStepTimeout is how long the pods have to load the new root credentials, and then how long the
+	// servers have to accept them once MinIO was restarted, before the rotation is marked as failed
+	rootCredentialsStepTimeout = 15 * time.Minute
+	// rootCredentialsRequeueInterval is how often a rotation in progress is checked
+	rootCredentialsRequeueInterval = 10 * time.Second
+	// sidecarRequestTimeout bounds the requests to the sidecars of the MinIO pods
+	sidecarRequestTimeout = 5 * time.Second
+
+	// Keys of the active root credentials secret holding the previous credentials until they are retired
+	previousAccessKeyKey = "previousaccesskey"
+	previousSecretKeyKey = "previoussecretkey"
+)
+
+// rootCredentialsAdminClient is the subset of the MinIO admin API used to rotate the root credentials
+type rootCredentialsAdminClient interface {
+	ServerInfo(ctx context.Context, options ...func(*madmin.ServerInfoOpts)) (madmin.InfoMessage, error)
+	ServiceRestartV2(ctx context.Context) error
+	AddUser(ctx context.Context, accessKey, secretKey string) error
+	SetPolicy(ctx context.Context, policyName, entityName string, isGroup bool) error
+	RemoveUser(ctx context.Context, accessKey string) error
+}
+
+// sidecarClient checks the configuration generated by the sidecar of a MinIO pod
+type sidecarClient interface {
+	RootCredentialsLoaded(ctx context.Context, pod *corev1.Pod, hash string) (bool, error)
+}
+
+// httpSidecarClient reaches the sidecars on the IP of their pod
+type httpSidecarClient struct {
+	client *http.Client
+}
+
+// RootCredentialsLoaded returns true when the config.env of the pod holds the root credentials of the hash
+func (s *httpSidecarClient) RootCredentialsLoaded(ctx context.Context, pod *corev1.Pod, hash string) (bool, error) {
+	if pod.Status.PodIP == "" {
+		return false, nil
+	}
+	endpoint := url.URL{
+		Scheme:   "http",
+		Host:     net.JoinHostPort(pod.Status.PodIP, common.SidecarHTTPPort),
+		Path:     common.SidecarAPIRootCredentialsEndpoint,
+		RawQuery: url.Values{"c": []string{hash}}.Encode(),
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusPreconditionFailed:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected response from the sidecar: %s", resp.Status)
+	}
+}
+
+// syncRootCredentials rotates the root credentials MinIO runs with when the ones configured in the tenant change, either
+// edited by the user or generated by the operator when the `min.io/rotate-root-credentials` annotation changes. The
+// credentials MinIO runs with are kept in a secret owned by the tenant, the operator keeps using them until MinIO
+// accepts the new ones. Returns true when the tenant must be synced again before the root credentials are used, while a
+// rotation is in progress or right after it completed. A failed rotation doesn't hold the sync back.
+func (c *Controller) syncRootCredentials(ctx context.Context, tenant *miniov2.Tenant, tenantConfiguration map[string][]byte) (*miniov2.Tenant, bool, error) {
+	configured, err := c.getConfiguredTenantCredentials(ctx, tenant)
+	if err != nil {
+		return tenant, false, err
+	}
+	active, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Get(ctx, tenant.ActiveRootCredentialsSecretName(), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		// MinIO runs with the configured credentials until they change
+		return tenant, false, c.storeActiveRootCredentials(ctx, tenant, nil, configured, nil)
+	}
+	if err != nil {
+		return tenant, false, err
+	}
+
+	status := &miniov2.RootCredentialsStatus{}
+	if tenant.Status.RootCredentials != nil {
+		status = tenant.Status.RootCredentials.DeepCopy()
+	}
+	var rotating bool
+	if bytes.Equal(active.Data["accesskey"], configured["accesskey"]) && bytes.Equal(active.Data["secretkey"], configured["secretkey"]) {
+		if status.Phase == miniov2.RootCredentialsRetiring {
+			if status.LastRotationTime == nil || time.Since(status.LastRotationTime.Time) >= rootCredentialsRetirementDelay {
+				var adminClnt *madmin.AdminClient
+				if adminClnt, err = tenant.NewMinIOAdmin(tenantConfiguration, c.getTransport()); err != nil {
+					return tenant, false, err
+				}
+				err = c.retirePreviousRootCredentials(ctx, tenant, status, active, adminClnt)
+			}
+		} else {
+			status.Phase, status.StartedAt, status.RestartedAt, status.CredentialsHash = "", nil, nil, ""
+		}
+		if err == nil {
+			rotating, err = c.requestRootCredentialsRotation(ctx, tenant, status)
+		}
+	} else {
+		var activeAdminClnt, newAdminClnt *madmin.AdminClient
+		if activeAdminClnt, err = tenant.NewMinIOAdmin(tenantConfiguration, c.getTransport()); err != nil {
+			return tenant, false, err
+		}
+		if newAdminClnt, err = tenant.NewMinIOAdmin(configured, c.getTransport()); err != nil {
+			return tenant, false, err
+		}
+		sidecars := &httpSidecarClient{client: &http.Client{Timeout: sidecarRequestTimeout}}
+		rotating, err = c.rotateRootCredentials(ctx, tenant, status, active, configured, activeAdminClnt, newAdminClnt, sidecars)
+	}
+	if err != nil {
+		return tenant, false, err
+	}
+
+	if (tenant.Status.RootCredentials == nil && *status == (miniov2.RootCredentialsStatus{})) ||
+		equality.Semantic.DeepEqual(tenant.Status.RootCredentials, status) {
+		return tenant, rotating, nil
+	}
+	tenant, err = c.updateRootCredentialsStatus(ctx, tenant, status)
+	return tenant, rotating, err
+}
+
+// requestRootCredentialsRotation generates new root credentials in the configuration secret of the tenant when the
+// `min.io/rotate-root-credentials` annotation changes, the rotation starts once the change of the secret is observed
+func (c *Controller) requestRootCredentialsRotation(ctx context.Context, tenant *miniov2.Tenant, status *miniov2.RootCredentialsStatus) (bool, error) {
+	request := tenant.Annotations[miniov2.RotateRootCredentialsAnnotation]
+	if request == "" || request == status.RotationRequest {
+		return false, nil
+	}
+	if !tenant.HasConfigurationSecret() {
+		status.RotationRequest = request
+		status.Message = "The root credentials can only be rotated when they are set in the spec.configuration secret"
+		c.recorder.Event(tenant, corev1.EventTypeWarning, RootCredentialsRotationBlockedReason, status.Message)
+		return false, nil
+	}
+	secret, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Get(ctx, tenant.Spec.Configuration.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data["config.env"] = miniov2.SetRootCredentials(secret.Data["config.env"], utils.RandomCharString(20), utils.RandomCharString(40))
+	if _, err = c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return false, err
+	}
+	klog.Infof("'%s/%s' Generated new root credentials in secret %s", tenant.Namespace, tenant.Name, secret.Name)
+	status.RotationRequest = request
+	status.Message = ""
+	return true, nil
+}
+
+// rotateRootCredentials restarts MinIO once the sidecars of every pod confirmed config.env holds the new credentials,
+// and switches the operator to the new credentials once every server accepts them. MinIO only loads its root credentials
+// when it starts and all the servers of a tenant must run with the same ones, so the pools are restarted together
+// rather than pod by pod and the tenant is unavailable until the servers are back online. The previous credentials
+// stay valid as a temporary user for rootCredentialsRetirementDelay once MinIO accepts the new ones.
+func (c *Controller) rotateRootCredentials(ctx context.Context, tenant *miniov2.Tenant, status *miniov2.RootCredentialsStatus, active *corev1.Secret, configured map[string][]byte, activeAdminClnt, newAdminClnt rootCredentialsAdminClient, sidecars sidecarClient) (bool, error) {
+	hash := miniov2.RootCredentialsHash(configured["accesskey"], configured["secretkey"])
+	if status.Phase == miniov2.RootCredentialsFailed && status.CredentialsHash != hash {
+		// the credentials changed since the rotation failed, it starts over
+		status.Phase = ""
+	}
+	if status.Phase == miniov2.RootCredentialsRetiring {
+		// a new rotation started before the credentials of the previous one were retired
+		if err := c.retirePreviousRootCredentials(ctx, tenant, status, active, activeAdminClnt); err != nil {
+			return true, err
+		}
+	}
+	if status.Phase == "" {
+		now := metav1.Now()
+		status.Phase = miniov2.RootCredentialsPropagating
+		status.StartedAt = &now
+		status.RestartedAt = nil
+		status.CredentialsHash = hash
+		status.Message = ""
+		klog.Infof("'%s/%s' Rotating the root credentials", tenant.Namespace, tenant.Name)
+		c.recorder.Event(tenant, corev1.EventTypeNormal, RootCredentialsRotationStartedReason,
+			"New root credentials detected, MinIO will be restarted once every pod loaded them: all the servers restart together and the tenant is unavailable until they are back online")
+		return true, nil
+	}
+
+	// pods restarted for any other reason since the rotation started also run with the new credentials, and a failed
+	// rotation completes once the servers that didn't come back in time are online
+	failed := status.Phase == miniov2.RootCredentialsFailed
+	if info, err := newAdminClnt.ServerInfo(ctx); err == nil {
+		online := 0
+		for _, server := range info.Servers {
+			if server.State == string(madmin.ItemOnline) {
+				online++
+			}
+		}
+		if online == 0 || online < len(info.Servers) {
+			if failed {
+				return false, nil
+			}
+			status.Message = fmt.Sprintf("Waiting for the MinIO servers to be online, %d of %d online", online, len(info.Servers))
+			return true, nil
+		}
+		// MinIO rejects a user named after the root user, the previous password can't stay valid when only it changed
+		var previous map[string][]byte
+		if previousAccessKey := string(active.Data["accesskey"]); previousAccessKey != string(configured["accesskey"]) {
+			if err = newAdminClnt.AddUser(ctx, previousAccessKey, string(active.Data["secretkey"])); err == nil {
+				err = newAdminClnt.SetPolicy(ctx, miniov2.ConsoleAdminPolicyName, previousAccessKey, false)
+			}
+			if err != nil {
+				klog.Infof("'%s/%s' Unable to keep the previous root credentials valid: %v", tenant.Namespace, tenant.Name, err)
+				if failed {
+					return false, nil
+				}
+				status.Message = fmt.Sprintf("Unable to keep the previous root credentials valid: %s", err)
+				return true, nil
+			}
+			previous = map[string][]byte{
+				previousAccessKeyKey: active.Data["accesskey"],
+				previousSecretKeyKey: active.Data["secretkey"],
+			}
+		}
+		if err = c.storeActiveRootCredentials(ctx, tenant, active, configured, previous); err != nil {
+			return true, err
+		}
+		now := metav1.Now()
+		status.Phase, status.StartedAt, status.RestartedAt, status.CredentialsHash = "", nil, nil, ""
+		status.LastRotationTime = &now
+		status.Message = ""
+		message := "MinIO runs with the new root credentials"
+		if previous != nil {
+			status.Phase = miniov2.RootCredentialsRetiring
+			message = fmt.Sprintf("%s, the previous ones stay valid until %s", message, now.Add(rootCredentialsRetirementDelay).Format(time.RFC3339))
+		}
+		klog.Infof("'%s/%s' %s", tenant.Namespace, tenant.Name, message)
+		c.recorder.Event(tenant, corev1.EventTypeNormal, RootCredentialsRotatedReason, message)
+		return true, nil
+	}
+
+	if failed {
+		// a new rotation request generates new credentials, which retries the rotation
+		_, err := c.requestRootCredentialsRotation(ctx, tenant, status)
+		return false, err
+	}
+	if status.Phase == miniov2.RootCredentialsPropagating {
+		loaded, total, err := c.podsWithRootCredentials(ctx, tenant, configured, sidecars)
+		if err != nil {
+			return true, err
+		}
+		if loaded < total {
+			status.Message = fmt.Sprintf("Waiting for the sidecars to load the new root credentials, %d of %d pods", loaded, total)
+			return true, nil
+		}
+		if err := activeAdminClnt.ServiceRestartV2(ctx); err != nil {
+			klog.Infof("'%s/%s' Unable to restart MinIO to load the new root credentials: %v", tenant.Namespace, tenant.Name, err)
+			status.Message = fmt.Sprintf("Unable to restart MinIO: %s", err)
+			return true, nil
+		}
+		now := metav1.Now()
+		status.Phase = miniov2.RootCredentialsRestarting
+		status.RestartedAt = &now
+		status.Message = ""
+		klog.Infof("'%s/%s' Restarted MinIO to load the new root credentials", tenant.Namespace, tenant.Name)
+	}
+	return true, nil
+}
+
+// expireRootCredentialsRotation marks the rotation of the root credentials as failed when the pods didn't load the new
+// credentials in time, or when the servers didn't accept them in time once MinIO was restarted. It runs before MinIO is
+// checked to be healthy since a server that doesn't come back keeps the tenant unhealthy, the rest of the sync then
+// stops waiting for the rotation.
+func (c *Controller) expireRootCredentialsRotation(ctx context.Context, tenant *miniov2.Tenant) (*miniov2.Tenant, error) {
+	status := tenant.Status.RootCredentials
+	if status == nil {
+		return tenant, nil
+	}
+	var since *metav1.Time
+	var step string
+	switch status.Phase {
+	case miniov2.RootCredentialsPropagating:
+		since, step = status.StartedAt, "the MinIO pods didn't load the new root credentials"
+	case miniov2.RootCredentialsRestarting:
+		since, step = status.RestartedAt, "the MinIO servers didn't come back online with the new root credentials"
+	default:
+		return tenant, nil
+	}
+	if since == nil || time.Since(since.Time) < rootCredentialsStepTimeout {
+		return tenant, nil
+	}
+	status = status.DeepCopy()
+	status.Phase = miniov2.RootCredentialsFailed
+	status.Message = fmt.Sprintf("The rotation of the root credentials failed, %s within %s", step, rootCredentialsStepTimeout)
+	if tenant.Status.RootCredentials.Message != "" {
+		status.Message = fmt.Sprintf("%s: %s", status.Message, tenant.Status.RootCredentials.Message)
+	}
+	klog.Warningf("'%s/%s' %s", tenant.Namespace, tenant.Name, status.Message)
+	c.recorder.Event(tenant, corev1.EventTypeWarning, RootCredentialsRotationFailedReason,
+		fmt.Sprintf("%s. Change the root credentials or the %s annotation to retry", status.Message, miniov2.RotateRootCredentialsAnnotation))
+	return c.updateRootCredentialsStatus(ctx, tenant, status)
+}
+
+// podsWithRootCredentials returns how many MinIO pods have the credentials in their config.env, out of the servers of
+// the tenant. Pods whose sidecar can't be reached are not counted.
+func (c *Controller) podsWithRootCredentials(ctx context.Context, tenant *miniov2.Tenant, credentials map[string][]byte, sidecars sidecarClient) (int, int, error) {
+	total := 0
+	for _, pool := range tenant.Spec.Pools {
+		total += int(pool.Servers)
+	}
+	pods, err := c.kubeClientSet.CoreV1().Pods(tenant.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", miniov2.TenantLabel, tenant.Name),
+	})
+	if err != nil {
+		return 0, total, err
+	}
+	hash := miniov2.RootCredentialsHash(credentials["accesskey"], credentials["secretkey"])
+	loaded := 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		ok, err := sidecars.RootCredentialsLoaded(ctx, pod, hash)
+		if err != nil {
+			klog.V(2).Infof("'%s/%s' Unable to check the root credentials of pod %s: %v", tenant.Namespace, tenant.Name, pod.Name, err)
+			continue
+		}
+		if ok {
+			loaded++
+		}
+	}
+	return loaded, total, nil
+}
+
+// retirePreviousRootCredentials removes the temporary user keeping the previous root credentials valid
+func (c *Controller) retirePreviousRootCredentials(ctx context.Context, tenant *miniov2.Tenant, status *miniov2.RootCredentialsStatus, active *corev1.Secret, adminClnt rootCredentialsAdminClient) error {
+	if previousAccessKey := string(active.Data[previousAccessKeyKey]); previousAccessKey != "" {
+		if err := adminClnt.RemoveUser(ctx, previousAccessKey); err != nil && madmin.ToErrorResponse(err).Code != "XMinioAdminNoSuchUser" {
+			return err
+		}
+		delete(active.Data, previousAccessKeyKey)
+		delete(active.Data, previousSecretKeyKey)
+		if _, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Update(ctx, active, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	status.Phase = ""
+	status.Message = ""
+	klog.Infof("'%s/%s' Retired the previous root credentials", tenant.Namespace, tenant.Name)
+	return nil
+}
+
+// storeActiveRootCredentials records the root credentials MinIO runs with and the previous ones still valid, the secret
+// is created when missing
+func (c *Controller) storeActiveRootCredentials(ctx context.Context, tenant *miniov2.Tenant, secret *corev1.Secret, credentials, previous map[string][]byte) error {
+	data := map[string][]byte{
+		"accesskey": credentials["accesskey"],
+		"secretkey": credentials["secretkey"],
+	}
+	for key, value := range previous {
+		data[key] = value
+	}
+	if secret != nil {
+		secret.Data = data
+		_, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	}
+	_, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Create(ctx, &corev1.Secret{
+		Type: corev1.SecretTypeOpaque,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            tenant.ActiveRootCredentialsSecretName(),
+			Namespace:       tenant.Namespace,
+			Labels:          tenant.MinIOPodLabels(),
+			OwnerReferences: tenant.OwnerRef(),
+		},
+		Data: data,
+	}, metav1.CreateOptions{})
+	return err
+}
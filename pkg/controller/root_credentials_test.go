@@ -0,0 +1,309 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	miniofake "github.com/minio/operator/pkg/client/clientset/versioned/fake"
+)
+
+type fakeRootCredentialsAdminClient struct {
+	info      madmin.InfoMessage
+	infoErr   error
+	restarted bool
+	users     map[string]string
+	policies  map[string]string
+}
+
+func (f *fakeRootCredentialsAdminClient) ServerInfo(_ context.Context, _ ...func(*madmin.ServerInfoOpts)) (madmin.InfoMessage, error) {
+	return f.info, f.infoErr
+}
+
+func (f *fakeRootCredentialsAdminClient) ServiceRestartV2(_ context.Context) error {
+	f.restarted = true
+	return nil
+}
+
+func (f *fakeRootCredentialsAdminClient) AddUser(_ context.Context, accessKey, secretKey string) error {
+	if f.users == nil {
+		f.users = map[string]string{}
+	}
+	f.users[accessKey] = secretKey
+	return nil
+}
+
+func (f *fakeRootCredentialsAdminClient) SetPolicy(_ context.Context, policyName, entityName string, _ bool) error {
+	if f.policies == nil {
+		f.policies = map[string]string{}
+	}
+	f.policies[entityName] = policyName
+	return nil
+}
+
+func (f *fakeRootCredentialsAdminClient) RemoveUser(_ context.Context, accessKey string) error {
+	delete(f.users, accessKey)
+	delete(f.policies, accessKey)
+	return nil
+}
+
+// fakeSidecarClient answers for the sidecars of the pods, by pod name
+type fakeSidecarClient struct {
+	hashes map[string]string
+}
+
+func (f *fakeSidecarClient) RootCredentialsLoaded(_ context.Context, pod *corev1.Pod, hash string) (bool, error) {
+	loaded, ok := f.hashes[pod.Name]
+	if !ok {
+		return false, fmt.Errorf("sidecar of pod %s unreachable", pod.Name)
+	}
+	return loaded == hash, nil
+}
+
+func Test_syncRootCredentials(t *testing.T) {
+	ctx := context.Background()
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "myminio",
+			Namespace:   "ns",
+			Annotations: map[string]string{miniov2.RotateRootCredentialsAnnotation: "1"},
+		},
+		Spec: miniov2.TenantSpec{
+			Configuration: &corev1.LocalObjectReference{Name: "myminio-env"},
+			Pools:         []miniov2.Pool{{Name: "pool-0", Servers: 2}},
+		},
+	}
+	var pods []runtime.Object
+	for i := 0; i < 2; i++ {
+		pods = append(pods, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("myminio-pool-0-%d", i),
+			Namespace: "ns",
+			Labels:    tenant.MinIOPodLabels(),
+		}})
+	}
+	controller := Controller{
+		kubeClientSet: fake.NewSimpleClientset(append(pods, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "myminio-env", Namespace: "ns"},
+			Data: map[string][]byte{
+				"config.env": []byte("export MINIO_BROWSER=\"on\"\nexport MINIO_ROOT_USER=\"minio\"\nexport MINIO_ROOT_PASSWORD=\"minio123\"\n"),
+			},
+		})...),
+		minioClientSet: miniofake.NewSimpleClientset(tenant),
+		recorder:       record.NewFakeRecorder(10),
+	}
+	sync := func() (bool, map[string][]byte) {
+		t.Helper()
+		updated, requeue, err := controller.syncRootCredentials(ctx, tenant, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the fake clientset drops the spec cleared when updating the status
+		tenant.Status = updated.Status
+		tenantConfiguration, err := controller.getTenantCredentials(ctx, tenant)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return requeue, tenantConfiguration
+	}
+
+	// the credentials MinIO runs with are recorded first
+	if requeue, tenantConfiguration := sync(); requeue || string(tenantConfiguration["accesskey"]) != "minio" {
+		t.Fatalf("requeue = %t, access key = %s, want the configured credentials", requeue, tenantConfiguration["accesskey"])
+	}
+	// the annotation generates new credentials, the operator keeps using the previous ones
+	requeue, tenantConfiguration := sync()
+	if !requeue || tenant.Status.RootCredentials == nil || tenant.Status.RootCredentials.RotationRequest != "1" {
+		t.Fatalf("requeue = %t, status = %+v, want the rotation requested", requeue, tenant.Status.RootCredentials)
+	}
+	configured, err := controller.getConfiguredTenantCredentials(ctx, tenant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(configured["accesskey"]) == "minio" || string(configured["secretkey"]) == "minio123" || string(configured["MINIO_BROWSER"]) != "on" {
+		t.Fatalf("configuration after the rotation request = %v", configured)
+	}
+	if string(tenantConfiguration["accesskey"]) != "minio" || string(tenantConfiguration["secretkey"]) != "minio123" {
+		t.Errorf("the operator switched to the new credentials before MinIO was restarted")
+	}
+
+	active, err := controller.kubeClientSet.CoreV1().Secrets("ns").Get(ctx, tenant.ActiveRootCredentialsSecretName(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := tenant.Status.RootCredentials.DeepCopy()
+	activeAdminClnt := &fakeRootCredentialsAdminClient{}
+	newAdminClnt := &fakeRootCredentialsAdminClient{infoErr: errors.New("invalid credentials")}
+	previousHash := miniov2.RootCredentialsHash([]byte("minio"), []byte("minio123"))
+	sidecars := &fakeSidecarClient{hashes: map[string]string{"myminio-pool-0-0": previousHash}}
+	rotate := func() bool {
+		t.Helper()
+		requeue, err := controller.rotateRootCredentials(ctx, tenant, status, active, configured, activeAdminClnt, newAdminClnt, sidecars)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return requeue
+	}
+
+	// MinIO is only restarted once the sidecar of every pod regenerated config.env
+	if !rotate() || status.Phase != miniov2.RootCredentialsPropagating {
+		t.Fatalf("phase = %s, want %s", status.Phase, miniov2.RootCredentialsPropagating)
+	}
+	newHash := miniov2.RootCredentialsHash(configured["accesskey"], configured["secretkey"])
+	sidecars.hashes["myminio-pool-0-0"] = newHash
+	if rotate(); activeAdminClnt.restarted || status.Message != "Waiting for the sidecars to load the new root credentials, 1 of 2 pods" {
+		t.Fatalf("restarted = %t, message = %q, want MinIO not restarted before every pod loaded the credentials", activeAdminClnt.restarted, status.Message)
+	}
+	sidecars.hashes["myminio-pool-0-1"] = newHash
+	if rotate(); !activeAdminClnt.restarted || status.Phase != miniov2.RootCredentialsRestarting {
+		t.Fatalf("restarted = %t, phase = %s, want MinIO restarted with the previous credentials", activeAdminClnt.restarted, status.Phase)
+	}
+
+	// the rotation completes once every server accepts the new credentials
+	newAdminClnt.infoErr = nil
+	newAdminClnt.info.Servers = []madmin.ServerProperties{{State: string(madmin.ItemOnline)}, {State: string(madmin.ItemOffline)}}
+	if rotate(); status.Phase != miniov2.RootCredentialsRestarting {
+		t.Fatalf("phase = %s, the rotation completed with a server offline", status.Phase)
+	}
+	newAdminClnt.info.Servers[1].State = string(madmin.ItemOnline)
+	if rotate(); status.Phase != miniov2.RootCredentialsRetiring || status.LastRotationTime == nil {
+		t.Fatalf("status = %+v, want the previous credentials retiring", status)
+	}
+	tenantConfiguration, err = controller.getTenantCredentials(ctx, tenant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(tenantConfiguration["accesskey"]) != string(configured["accesskey"]) || string(tenantConfiguration["secretkey"]) != string(configured["secretkey"]) {
+		t.Errorf("the operator still uses the previous credentials after the rotation")
+	}
+	// the previous credentials stay valid as a temporary user
+	if newAdminClnt.users["minio"] != "minio123" || newAdminClnt.policies["minio"] != miniov2.ConsoleAdminPolicyName {
+		t.Fatalf("users = %v, policies = %v, want the previous credentials kept valid", newAdminClnt.users, newAdminClnt.policies)
+	}
+
+	// the temporary user is removed once the previous credentials are retired
+	if active, err = controller.kubeClientSet.CoreV1().Secrets("ns").Get(ctx, tenant.ActiveRootCredentialsSecretName(), metav1.GetOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if string(active.Data[previousAccessKeyKey]) != "minio" {
+		t.Fatalf("active root credentials = %v, want the previous access key recorded", active.Data)
+	}
+	if err = controller.retirePreviousRootCredentials(ctx, tenant, status, active, newAdminClnt); err != nil {
+		t.Fatal(err)
+	}
+	if status.Phase != "" || len(newAdminClnt.users) != 0 {
+		t.Errorf("phase = %s, users = %v, want the previous credentials retired", status.Phase, newAdminClnt.users)
+	}
+	if active, err = controller.kubeClientSet.CoreV1().Secrets("ns").Get(ctx, tenant.ActiveRootCredentialsSecretName(), metav1.GetOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := active.Data[previousAccessKeyKey]; ok {
+		t.Errorf("active root credentials = %v, want the previous credentials removed", active.Data)
+	}
+}
+
+func Test_expireRootCredentialsRotation(t *testing.T) {
+	ctx := context.Background()
+	configured := map[string][]byte{"accesskey": []byte("new"), "secretkey": []byte("new-secret")}
+	hash := miniov2.RootCredentialsHash(configured["accesskey"], configured["secretkey"])
+	restartedAt := metav1.NewTime(time.Now().Add(-rootCredentialsStepTimeout - time.Minute))
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+		},
+		Spec: miniov2.TenantSpec{
+			Pools: []miniov2.Pool{{Name: "pool-0", Servers: 2}},
+		},
+		Status: miniov2.TenantStatus{
+			RootCredentials: &miniov2.RootCredentialsStatus{
+				Phase:           miniov2.RootCredentialsRestarting,
+				StartedAt:       &restartedAt,
+				RestartedAt:     &restartedAt,
+				CredentialsHash: hash,
+				Message:         "Waiting for the MinIO servers to be online, 1 of 2 online",
+			},
+		},
+	}
+	active := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: tenant.ActiveRootCredentialsSecretName(), Namespace: "ns"},
+		Data:       map[string][]byte{"accesskey": []byte("minio"), "secretkey": []byte("minio123")},
+	}
+	recorder := record.NewFakeRecorder(10)
+	controller := Controller{
+		kubeClientSet:  fake.NewSimpleClientset(active),
+		minioClientSet: miniofake.NewSimpleClientset(tenant.DeepCopy()),
+		recorder:       recorder,
+	}
+
+	// a server that doesn't come back in time fails the rotation
+	updated, err := controller.expireRootCredentialsRotation(ctx, tenant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := updated.Status.RootCredentials.DeepCopy()
+	if status.Phase != miniov2.RootCredentialsFailed || !strings.Contains(status.Message, "1 of 2 online") {
+		t.Fatalf("status = %+v, want the rotation failed", status)
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("events = %d, want the failure warning", len(recorder.Events))
+	}
+
+	// a failed rotation doesn't hold the sync back nor restarts MinIO again
+	activeAdminClnt := &fakeRootCredentialsAdminClient{}
+	newAdminClnt := &fakeRootCredentialsAdminClient{}
+	newAdminClnt.info.Servers = []madmin.ServerProperties{{State: string(madmin.ItemOnline)}, {State: string(madmin.ItemOffline)}}
+	sidecars := &fakeSidecarClient{}
+	rotating, err := controller.rotateRootCredentials(ctx, tenant, status, active, configured, activeAdminClnt, newAdminClnt, sidecars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotating || status.Phase != miniov2.RootCredentialsFailed || activeAdminClnt.restarted {
+		t.Fatalf("rotating = %t, phase = %s, restarted = %t, want the failed rotation left alone", rotating, status.Phase, activeAdminClnt.restarted)
+	}
+
+	// the rotation completes once the server is back
+	newAdminClnt.info.Servers[1].State = string(madmin.ItemOnline)
+	if _, err = controller.rotateRootCredentials(ctx, tenant, status, active, configured, activeAdminClnt, newAdminClnt, sidecars); err != nil {
+		t.Fatal(err)
+	}
+	if status.Phase != miniov2.RootCredentialsRetiring || status.CredentialsHash != "" {
+		t.Fatalf("status = %+v, want the rotation completed", status)
+	}
+
+	// new credentials retry a failed rotation
+	status.Phase, status.CredentialsHash = miniov2.RootCredentialsFailed, "previous"
+	if rotating, err = controller.rotateRootCredentials(ctx, tenant, status, active, configured, activeAdminClnt, newAdminClnt, sidecars); err != nil {
+		t.Fatal(err)
+	}
+	if !rotating || status.Phase != miniov2.RootCredentialsPropagating || status.CredentialsHash != hash {
+		t.Errorf("rotating = %t, status = %+v, want a new rotation", rotating, status)
+	}
+}
@@ -132,13 +132,14 @@ func (c *Controller) updateCertificatesWithRetry(ctx context.Context, tenant *mi
 	return t, nil
 }
 
-func (c *Controller) updateCustomCertificatesStatus(ctx context.Context, tenant *miniov2.Tenant, customCertificates *miniov2.CustomCertificates) (*miniov2.Tenant, error) {
+func (c *Controller) updateCustomCertificatesStatus(ctx context.Context, tenant *miniov2.Tenant, customCertificates *miniov2.CustomCertificates, expiring []expiringCertificate) (*miniov2.Tenant, error) {
 	// NEVER modify objects from the store. It's a read-only, local cache.
 	// You can use DeepCopy() to make a deep copy of original object and modify this copy
 	// Or create a copy manually for better performance
 	tenantCopy := tenant.DeepCopy()
 	tenantCopy.Spec = miniov2.TenantSpec{}
 	tenantCopy.Status.Certificates.CustomCertificates = customCertificates
+	c.updateCertificatesExpiringCondition(tenantCopy, expiring)
 
 	// If the CustomResourceSubresources feature gate is not enabled,
 	// we must use Update instead of UpdateStatus to update the Status block of the Tenant resource.
@@ -154,6 +155,45 @@ func (c *Controller) updateCustomCertificatesStatus(ctx context.Context, tenant
 	return t, nil
 }
 
+func (c *Controller) updateRootCredentialsStatus(ctx context.Context, tenant *miniov2.Tenant, rootCredentials *miniov2.RootCredentialsStatus) (*miniov2.Tenant, error) {
+	tenantCopy := tenant.DeepCopy()
+	tenantCopy.Spec = miniov2.TenantSpec{}
+	tenantCopy.Status.RootCredentials = rootCredentials
+	opts := metav1.UpdateOptions{}
+	t, err := c.minioClientSet.MinioV2().Tenants(tenant.Namespace).UpdateStatus(ctx, tenantCopy, opts)
+	t.EnsureDefaults()
+	if err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+func (c *Controller) updateCABundleStatus(ctx context.Context, tenant *miniov2.Tenant, caBundle *miniov2.CABundleStatus) (*miniov2.Tenant, error) {
+	return c.updateCABundleStatusWithRetry(ctx, tenant, caBundle, true)
+}
+
+func (c *Controller) updateCABundleStatusWithRetry(ctx context.Context, tenant *miniov2.Tenant, caBundle *miniov2.CABundleStatus, retry bool) (*miniov2.Tenant, error) {
+	tenantCopy := tenant.DeepCopy()
+	tenantCopy.Spec = miniov2.TenantSpec{}
+	tenantCopy.Status = *tenant.Status.DeepCopy()
+	tenantCopy.Status.CABundle = caBundle
+	opts := metav1.UpdateOptions{}
+	t, err := c.minioClientSet.MinioV2().Tenants(tenant.Namespace).UpdateStatus(ctx, tenantCopy, opts)
+	t.EnsureDefaults()
+	if err != nil {
+		if k8serrors.IsConflict(err) && retry {
+			klog.Info("Hit conflict issue, getting latest version of tenant")
+			tenant, err = c.minioClientSet.MinioV2().Tenants(tenant.Namespace).Get(ctx, tenant.Name, metav1.GetOptions{})
+			if err != nil {
+				return tenant, err
+			}
+			return c.updateCABundleStatusWithRetry(ctx, tenant, caBundle, false)
+		}
+		return t, err
+	}
+	return t, nil
+}
+
 func (c *Controller) updateProvisionedUsersStatus(ctx context.Context, tenant *miniov2.Tenant, provisionedUsers bool) (*miniov2.Tenant, error) {
 	return c.updateProvisionedUsersWithRetry(ctx, tenant, provisionedUsers, true)
 }
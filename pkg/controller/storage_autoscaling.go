@@ -0,0 +1,241 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+const (
+	// PVCExpandedReason is the event reason recorded when the storage autoscaling expands a PVC
+	PVCExpandedReason = "PVCExpanded"
+	// PVCExpansionFailedReason is the event reason recorded when the storage autoscaling fails to expand PVCs of a pool
+	PVCExpansionFailedReason = "PVCExpansionFailed"
+)
+
+// pvcResizeStatus returns the resize status of a PVC, nil when no resize is in progress
+func pvcResizeStatus(pvc *corev1.PersistentVolumeClaim) *miniov2.PVCResizeStatus {
+	requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	capacity := pvc.Status.Capacity[corev1.ResourceStorage]
+	status := &miniov2.PVCResizeStatus{
+		Name:      pvc.Name,
+		Requested: requested.String(),
+		Capacity:  capacity.String(),
+	}
+	for _, condition := range pvc.Status.Conditions {
+		switch condition.Type {
+		case corev1.PersistentVolumeClaimResizing,
+			corev1.PersistentVolumeClaimFileSystemResizePending,
+			corev1.PersistentVolumeClaimControllerResizeError,
+			corev1.PersistentVolumeClaimNodeResizeError:
+			if condition.Status == corev1.ConditionTrue {
+				status.Condition = string(condition.Type)
+				status.Message = condition.Message
+				return status
+			}
+		}
+	}
+	if requested.Cmp(capacity) > 0 {
+		return status
+	}
+	return nil
+}
+
+// expandPVC requests the new size for the PVC, a PVC that can't be expanded, eg: rejected by a quota, is logged and
+// doesn't block the other PVCs nor the tenant sync
+func (c *Controller) expandPVC(ctx context.Context, tenant *miniov2.Tenant, pvc *corev1.PersistentVolumeClaim, size resource.Quantity) error {
+	current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = size
+	if _, err := c.kubeClientSet.CoreV1().PersistentVolumeClaims(tenant.Namespace).Update(ctx, pvc, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("'%s/%s' Unable to expand PVC %s to %s: %v", tenant.Namespace, tenant.Name, pvc.Name, size.String(), err)
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = current
+		return err
+	}
+	klog.Infof("'%s/%s' Expanded PVC %s from %s to %s", tenant.Namespace, tenant.Name, pvc.Name, current.String(), size.String())
+	return nil
+}
+
+// storageClassAllowsExpansion returns whether the StorageClass of the PVC allows volume expansion
+func (c *Controller) storageClassAllowsExpansion(ctx context.Context, pvc *corev1.PersistentVolumeClaim, cache map[string]bool) (bool, error) {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return false, nil
+	}
+	name := *pvc.Spec.StorageClassName
+	if allowed, ok := cache[name]; ok {
+		return allowed, nil
+	}
+	sc, err := c.kubeClientSet.StorageV1().StorageClasses().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			cache[name] = false
+			return false, nil
+		}
+		return false, err
+	}
+	allowed := sc.AllowVolumeExpansion != nil && *sc.AllowVolumeExpansion
+	cache[name] = allowed
+	return allowed, nil
+}
+
+// autoscalePoolStorage expands the PVCs of the pools with `storageAutoscaling` whose usage is above the threshold, and
+// tracks the resize of their PVCs in the pool status. A pool is only expanded again once all its PVCs finished
+// resizing and the cooldown elapsed. PVCs smaller than the rest of their pool are grown to its size right away.
+func (c *Controller) autoscalePoolStorage(ctx context.Context, tenant *miniov2.Tenant) (*miniov2.Tenant, error) {
+	statusChanged := false
+	storageClasses := map[string]bool{}
+	for i := range tenant.Spec.Pools {
+		pool := &tenant.Spec.Pools[i]
+		autoscaling := pool.StorageAutoscaling
+		if autoscaling == nil {
+			continue
+		}
+		var poolStatus *miniov2.PoolStatus
+		for j := range tenant.Status.Pools {
+			if tenant.Status.Pools[j].SSName == tenant.PoolStatefulsetName(pool) {
+				poolStatus = &tenant.Status.Pools[j]
+				break
+			}
+		}
+		if poolStatus == nil {
+			continue
+		}
+
+		pvcList, err := c.kubeClientSet.CoreV1().PersistentVolumeClaims(tenant.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s,%s=%s", miniov2.TenantLabel, tenant.Name, miniov2.PoolLabel, pool.Name),
+		})
+		if err != nil {
+			return tenant, err
+		}
+
+		// the volume claim template keeps the original size, so the PVCs recreated by a drive replacement or a node
+		// recovery come back smaller than the rest of the pool and are grown to the size of the largest PVC first
+		var largest resource.Quantity
+		for j := range pvcList.Items {
+			if size := pvcList.Items[j].Spec.Resources.Requests[corev1.ResourceStorage]; size.Cmp(largest) > 0 {
+				largest = size.DeepCopy()
+			}
+		}
+		var failed []string
+		for j := range pvcList.Items {
+			pvc := &pvcList.Items[j]
+			current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+			if current.Cmp(largest) >= 0 {
+				continue
+			}
+			allowed, err := c.storageClassAllowsExpansion(ctx, pvc, storageClasses)
+			if err != nil {
+				return tenant, err
+			}
+			if !allowed {
+				klog.Warningf("'%s/%s' Can't expand PVC %s, its StorageClass doesn't allow volume expansion", tenant.Namespace, tenant.Name, pvc.Name)
+				continue
+			}
+			if err := c.expandPVC(ctx, tenant, pvc, largest); err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %s", pvc.Name, err))
+				continue
+			}
+			c.recorder.Event(tenant, corev1.EventTypeNormal, PVCExpandedReason,
+				fmt.Sprintf("Expanded PVC %s from %s to %s, the size of the other PVCs of pool %s", pvc.Name, current.String(), largest.String(), pool.Name))
+		}
+
+		var resizing []miniov2.PVCResizeStatus
+		for j := range pvcList.Items {
+			if status := pvcResizeStatus(&pvcList.Items[j]); status != nil {
+				resizing = append(resizing, *status)
+			}
+		}
+
+		threshold := int64(miniov2.DefaultStorageAutoscalingThresholdPercent)
+		if autoscaling.ThresholdPercent != nil {
+			threshold = int64(*autoscaling.ThresholdPercent)
+		}
+		cooldown := time.Duration(miniov2.DefaultStorageAutoscalingCooldownMinutes) * time.Minute
+		if autoscaling.CooldownMinutes != nil {
+			cooldown = time.Duration(*autoscaling.CooldownMinutes) * time.Minute
+		}
+		total := usableSpace(poolStatus.Usage, poolStatus.Capacity)
+		expand := len(resizing) == 0 && poolStatus.Capacity > 0 && poolStatus.Usage*100 >= total*threshold
+		if poolStatus.LastStorageExpansion != nil && time.Since(poolStatus.LastStorageExpansion.Time) < cooldown {
+			expand = false
+		}
+
+		expanded := false
+		for j := 0; expand && j < len(pvcList.Items); j++ {
+			pvc := &pvcList.Items[j]
+			current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+			if current.Cmp(autoscaling.MaxSize) >= 0 {
+				continue
+			}
+			allowed, err := c.storageClassAllowsExpansion(ctx, pvc, storageClasses)
+			if err != nil {
+				return tenant, err
+			}
+			if !allowed {
+				klog.Warningf("'%s/%s' Can't expand PVC %s, its StorageClass doesn't allow volume expansion", tenant.Namespace, tenant.Name, pvc.Name)
+				continue
+			}
+			size := current.DeepCopy()
+			size.Add(autoscaling.Step)
+			if size.Cmp(autoscaling.MaxSize) > 0 {
+				size = autoscaling.MaxSize.DeepCopy()
+			}
+			if err := c.expandPVC(ctx, tenant, pvc, size); err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %s", pvc.Name, err))
+				continue
+			}
+			expanded = true
+			c.recorder.Event(tenant, corev1.EventTypeNormal, PVCExpandedReason,
+				fmt.Sprintf("Expanded PVC %s from %s to %s, pool %s uses %d%% of its capacity", pvc.Name, current.String(), size.String(), pool.Name, poolStatus.Usage*100/total))
+		}
+		if len(failed) > 0 {
+			c.recorder.Event(tenant, corev1.EventTypeWarning, PVCExpansionFailedReason,
+				fmt.Sprintf("Unable to expand %d PVCs of pool %s: %s", len(failed), pool.Name, strings.Join(failed, ", ")))
+		}
+		resizing = nil
+		for j := range pvcList.Items {
+			if status := pvcResizeStatus(&pvcList.Items[j]); status != nil {
+				resizing = append(resizing, *status)
+			}
+		}
+		if !reflect.DeepEqual(resizing, poolStatus.ResizingPVCs) {
+			poolStatus.ResizingPVCs = resizing
+			statusChanged = true
+		}
+		if expanded {
+			now := metav1.Now()
+			poolStatus.LastStorageExpansion = &now
+			statusChanged = true
+		}
+	}
+
+	if !statusChanged {
+		return tenant, nil
+	}
+	return c.updatePoolStatus(ctx, tenant)
+}
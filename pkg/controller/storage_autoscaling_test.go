@@ -0,0 +1,333 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	miniofake "github.com/minio/operator/pkg/client/clientset/versioned/fake"
+)
+
+func Test_autoscalePoolStorage(t *testing.T) {
+	ctx := context.Background()
+	storageClass := "expandable"
+	allowExpansion := true
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+		},
+		Spec: miniov2.TenantSpec{
+			Pools: []miniov2.Pool{
+				{
+					Name:             "pool-0",
+					Servers:          2,
+					VolumesPerServer: 1,
+					StorageAutoscaling: &miniov2.StorageAutoscaling{
+						Step:    resource.MustParse("10Gi"),
+						MaxSize: resource.MustParse("15Gi"),
+					},
+				},
+			},
+		},
+		Status: miniov2.TenantStatus{
+			Pools: []miniov2.PoolStatus{
+				{SSName: "myminio-pool-0", State: miniov2.PoolInitialized, Capacity: 15, Usage: 85},
+			},
+		},
+	}
+
+	objects := []runtime.Object{
+		&storagev1.StorageClass{
+			ObjectMeta:           metav1.ObjectMeta{Name: storageClass},
+			AllowVolumeExpansion: &allowExpansion,
+		},
+	}
+	for i := 0; i < 2; i++ {
+		objects = append(objects, &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("data0-myminio-pool-0-%d", i),
+				Namespace: tenant.Namespace,
+				Labels: map[string]string{
+					miniov2.TenantLabel: tenant.Name,
+					miniov2.PoolLabel:   "pool-0",
+				},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &storageClass,
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+				},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		})
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	controller := Controller{
+		kubeClientSet:  fake.NewSimpleClientset(objects...),
+		minioClientSet: miniofake.NewSimpleClientset(tenant.DeepCopy()),
+		recorder:       recorder,
+	}
+
+	// the capacity is the free space, 45 bytes used with 55 bytes free is below the threshold
+	tenant.Status.Pools[0].Capacity, tenant.Status.Pools[0].Usage = 55, 45
+	if _, err := controller.autoscalePoolStorage(ctx, tenant.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("events = %d, want no expansion below the threshold", len(recorder.Events))
+	}
+	tenant.Status.Pools[0].Capacity, tenant.Status.Pools[0].Usage = 15, 85
+
+	spec := tenant.Spec
+	tenant, err := controller.autoscalePoolStorage(ctx, tenant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the status update doesn't carry the spec
+	tenant.Spec = spec
+	for i := 0; i < 2; i++ {
+		pvc, err := controller.kubeClientSet.CoreV1().PersistentVolumeClaims("ns").Get(ctx, fmt.Sprintf("data0-myminio-pool-0-%d", i), metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the step is capped by the max size
+		if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.Cmp(resource.MustParse("15Gi")) != 0 {
+			t.Errorf("PVC %s requests %s, want 15Gi", pvc.Name, size.String())
+		}
+	}
+	if len(recorder.Events) != 2 {
+		t.Errorf("events = %d, want 2", len(recorder.Events))
+	}
+	poolStatus := tenant.Status.Pools[0]
+	if poolStatus.LastStorageExpansion == nil {
+		t.Error("last storage expansion not recorded")
+	}
+	if len(poolStatus.ResizingPVCs) != 2 {
+		t.Errorf("resizing PVCs = %+v, want 2", poolStatus.ResizingPVCs)
+	}
+
+	// PVCs still resizing hold further expansions back
+	tenant.Spec.Pools[0].StorageAutoscaling.MaxSize = resource.MustParse("100Gi")
+	if _, err = controller.autoscalePoolStorage(ctx, tenant); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.Events) != 2 {
+		t.Errorf("events = %d, want 2", len(recorder.Events))
+	}
+}
+
+func Test_autoscalePoolStorageUpdateFailure(t *testing.T) {
+	ctx := context.Background()
+	storageClass := "expandable"
+	allowExpansion := true
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+		},
+		Spec: miniov2.TenantSpec{
+			Pools: []miniov2.Pool{
+				{
+					Name:             "pool-0",
+					Servers:          2,
+					VolumesPerServer: 1,
+					StorageAutoscaling: &miniov2.StorageAutoscaling{
+						Step:    resource.MustParse("10Gi"),
+						MaxSize: resource.MustParse("100Gi"),
+					},
+				},
+			},
+		},
+		Status: miniov2.TenantStatus{
+			Pools: []miniov2.PoolStatus{
+				{SSName: "myminio-pool-0", State: miniov2.PoolInitialized, Capacity: 15, Usage: 85},
+			},
+		},
+	}
+	objects := []runtime.Object{
+		&storagev1.StorageClass{
+			ObjectMeta:           metav1.ObjectMeta{Name: storageClass},
+			AllowVolumeExpansion: &allowExpansion,
+		},
+	}
+	for i := 0; i < 2; i++ {
+		objects = append(objects, &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("data0-myminio-pool-0-%d", i),
+				Namespace: tenant.Namespace,
+				Labels: map[string]string{
+					miniov2.TenantLabel: tenant.Name,
+					miniov2.PoolLabel:   "pool-0",
+				},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &storageClass,
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+				},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		})
+	}
+	kubeClient := fake.NewSimpleClientset(objects...)
+	// the first PVC is rejected, eg: by a storage quota
+	kubeClient.PrependReactor("update", "persistentvolumeclaims", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.(k8stesting.UpdateAction).GetObject().(*corev1.PersistentVolumeClaim).Name == "data0-myminio-pool-0-0" {
+			return true, nil, errors.New("exceeded quota")
+		}
+		return false, nil, nil
+	})
+	recorder := record.NewFakeRecorder(10)
+	controller := Controller{
+		kubeClientSet:  kubeClient,
+		minioClientSet: miniofake.NewSimpleClientset(tenant.DeepCopy()),
+		recorder:       recorder,
+	}
+
+	if _, err := controller.autoscalePoolStorage(ctx, tenant); err != nil {
+		t.Fatalf("autoscalePoolStorage() = %v, want the failure reported in an event", err)
+	}
+	pvc, err := kubeClient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, "data0-myminio-pool-0-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.Cmp(resource.MustParse("20Gi")) != 0 {
+		t.Errorf("PVC %s requests %s, want 20Gi", pvc.Name, size.String())
+	}
+	var failures int
+	for len(recorder.Events) > 0 {
+		if event := <-recorder.Events; strings.Contains(event, PVCExpansionFailedReason) {
+			failures++
+			if !strings.Contains(event, "pool-0") || !strings.Contains(event, "data0-myminio-pool-0-0") {
+				t.Errorf("event %q doesn't name the pool and the PVC", event)
+			}
+		}
+	}
+	if failures != 1 {
+		t.Errorf("%d %s events, want 1", failures, PVCExpansionFailedReason)
+	}
+}
+
+func Test_autoscalePoolStorageRecreatedPVC(t *testing.T) {
+	ctx := context.Background()
+	storageClass := "expandable"
+	allowExpansion := true
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myminio",
+			Namespace: "ns",
+		},
+		Spec: miniov2.TenantSpec{
+			Pools: []miniov2.Pool{
+				{
+					Name:             "pool-0",
+					Servers:          3,
+					VolumesPerServer: 1,
+					StorageAutoscaling: &miniov2.StorageAutoscaling{
+						Step:    resource.MustParse("10Gi"),
+						MaxSize: resource.MustParse("100Gi"),
+					},
+				},
+			},
+		},
+		Status: miniov2.TenantStatus{
+			Pools: []miniov2.PoolStatus{
+				{SSName: "myminio-pool-0", State: miniov2.PoolInitialized, Capacity: 90, Usage: 10},
+			},
+		},
+	}
+
+	objects := []runtime.Object{
+		&storagev1.StorageClass{
+			ObjectMeta:           metav1.ObjectMeta{Name: storageClass},
+			AllowVolumeExpansion: &allowExpansion,
+		},
+	}
+	// the last PVC was recreated by a drive replacement at the size of the volume claim template
+	for i, size := range []string{"20Gi", "20Gi", "10Gi"} {
+		objects = append(objects, &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("data0-myminio-pool-0-%d", i),
+				Namespace: tenant.Namespace,
+				Labels: map[string]string{
+					miniov2.TenantLabel: tenant.Name,
+					miniov2.PoolLabel:   "pool-0",
+				},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &storageClass,
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+				},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+			},
+		})
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	controller := Controller{
+		kubeClientSet:  fake.NewSimpleClientset(objects...),
+		minioClientSet: miniofake.NewSimpleClientset(tenant.DeepCopy()),
+		recorder:       recorder,
+	}
+
+	tenant, err := controller.autoscalePoolStorage(ctx, tenant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		pvc, err := controller.kubeClientSet.CoreV1().PersistentVolumeClaims("ns").Get(ctx, fmt.Sprintf("data0-myminio-pool-0-%d", i), metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.Cmp(resource.MustParse("20Gi")) != 0 {
+			t.Errorf("PVC %s requests %s, want 20Gi", pvc.Name, size.String())
+		}
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("events = %d, want 1", len(recorder.Events))
+	}
+	poolStatus := tenant.Status.Pools[0]
+	if poolStatus.LastStorageExpansion != nil {
+		t.Error("growing a recreated PVC recorded a storage expansion")
+	}
+	if len(poolStatus.ResizingPVCs) != 1 || poolStatus.ResizingPVCs[0].Name != "data0-myminio-pool-0-2" {
+		t.Errorf("resizing PVCs = %+v, want the recreated PVC", poolStatus.ResizingPVCs)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/minio/madmin-go/v3"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/common"
 	xhttp "github.com/minio/operator/pkg/internal"
 	authv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -270,6 +271,10 @@ func configureSTSServer(c *Controller) *http.Server {
 		Path(STSEndpoint + "/{tenantNamespace}").
 		HandlerFunc(c.AssumeRoleWithWebIdentityHandler)
 
+	router.Methods(http.MethodPost).
+		Path(common.WebhookAPISTSInjection).
+		HandlerFunc(c.STSInjectionHandler)
+
 	router.NotFoundHandler = http.NotFoundHandler()
 
 	s := &http.Server{
@@ -0,0 +1,145 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+//lint:file-ignore ST1005 Incorrectly formatted error string
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/minio/operator/pkg/apis/sts.min.io/v1beta1"
+	"github.com/minio/operator/pkg/certs"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+)
+
+const (
+	certificateIdentity = "AssumeRoleWithCertificate"
+
+	// STSClientCASecretName is the name of the secret in the operator namespace holding in `ca.crt` the CAs of the
+	// client certificates accepted by AssumeRoleWithCertificate
+	STSClientCASecretName = "sts-client-ca"
+)
+
+// AssumeRoleWithCertificateResponse contains the result of successful AssumeRoleWithCertificate request.
+type AssumeRoleWithCertificateResponse struct {
+	XMLName xml.Name `xml:"https://sts.amazonaws.com/doc/2011-06-15/ AssumeRoleWithCertificateResponse" json:"-"`
+	Result  struct {
+		Credentials Credentials `xml:"Credentials,omitempty"`
+	} `xml:"AssumeRoleWithCertificateResult"`
+	ResponseMetadata struct {
+		RequestID string `xml:"RequestId,omitempty"`
+	} `xml:"ResponseMetadata,omitempty"`
+}
+
+// stsClientCAs returns the CAs of the client certificates, nil when certificate authentication is not configured
+func (c *Controller) stsClientCAs(ctx context.Context) (*x509.CertPool, error) {
+	secret, err := c.kubeClientSet.CoreV1().Secrets(miniov2.GetNSFromFile()).Get(ctx, STSClientCASecretName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(secret.Data[certs.CAPublicCertFile]) {
+		return nil, fmt.Errorf("Secret '%s' has no valid certificate in '%s'", STSClientCASecretName, certs.CAPublicCertFile)
+	}
+	return pool, nil
+}
+
+// verifyClientCertificate returns the client certificate of the request once verified against the configured CAs
+func (c *Controller) verifyClientCertificate(ctx context.Context, r *http.Request) (*x509.Certificate, STSErrorCode, error) {
+	if r.TLS == nil {
+		return nil, ErrSTSInsecureConnection, fmt.Errorf("No TLS connection attempt")
+	}
+	if len(r.TLS.PeerCertificates) == 0 {
+		return nil, ErrSTSMissingParameter, fmt.Errorf("No client certificate provided")
+	}
+	roots, err := c.stsClientCAs(ctx)
+	if err != nil {
+		return nil, ErrSTSInternalError, fmt.Errorf("Error loading the client CAs: %s", err)
+	}
+	if roots == nil {
+		return nil, ErrSTSAccessDenied, fmt.Errorf("Certificate authentication is not configured, secret '%s' not found", STSClientCASecretName)
+	}
+	cert := r.TLS.PeerCertificates[0]
+	intermediates := x509.NewCertPool()
+	for _, intermediate := range r.TLS.PeerCertificates[1:] {
+		intermediates.AddCert(intermediate)
+	}
+	if _, err = cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return nil, ErrSTSInvalidClientCertificate, err
+	}
+	return cert, ErrSTSNone, nil
+}
+
+// certificateMatches returns true if the certificate holds the Common Name and the SAN of the identity, an identity
+// without any field matches no certificate
+func certificateMatches(identity *v1beta1.CertificateIdentity, cert *x509.Certificate) bool {
+	if identity == nil || (identity.CommonName == "" && identity.SAN == "") {
+		return false
+	}
+	if identity.CommonName != "" && identity.CommonName != cert.Subject.CommonName {
+		return false
+	}
+	if identity.SAN == "" {
+		return true
+	}
+	if slices.Contains(cert.DNSNames, identity.SAN) || slices.Contains(cert.EmailAddresses, identity.SAN) {
+		return true
+	}
+	for _, uri := range cert.URIs {
+		if uri.String() == identity.SAN {
+			return true
+		}
+	}
+	return false
+}
+
+// certificatePolicyBindings returns the PolicyBindings matching the verified client certificate of the request
+func (c *Controller) certificatePolicyBindings(ctx context.Context, r *http.Request, tenantNamespace string) ([]v1beta1.PolicyBinding, STSErrorCode, error) {
+	cert, errCode, err := c.verifyClientCertificate(ctx, r)
+	if err != nil {
+		return nil, errCode, err
+	}
+	pbs, err := c.minioClientSet.StsV1beta1().PolicyBindings(tenantNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, ErrSTSInternalError, fmt.Errorf("Error obtaining PolicyBindings: %s", err)
+	}
+	var policyBindings []v1beta1.PolicyBinding
+	for _, pb := range pbs.Items {
+		if certificateMatches(pb.Spec.Certificate, cert) {
+			policyBindings = append(policyBindings, pb)
+		}
+	}
+	if len(policyBindings) == 0 {
+		return nil, ErrSTSAccessDenied, fmt.Errorf("Certificate '%s' has no PolicyBindings in namespace '%s'", cert.Subject, tenantNamespace)
+	}
+	return policyBindings, ErrSTSNone, nil
+}
@@ -0,0 +1,123 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/apis/sts.min.io/v1beta1"
+	"github.com/minio/operator/pkg/certs"
+	miniofake "github.com/minio/operator/pkg/client/clientset/versioned/fake"
+)
+
+func Test_certificatePolicyBindings(t *testing.T) {
+	ctx := context.Background()
+	ca, caKey, err := newCACertificate("client-ca", nil, nil, time.Now(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	untrustedCA, _ := newTrustedCA(t, "untrusted-ca")
+	clientKey, err := newPrivateKey(miniov2.DefaultEllipticCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "edge-box-1"},
+		DNSNames:     []string{"edge-box-1.example.com"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, clientKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCert, err := x509.ParseCertificate(clientDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	binding := func(name string, identity *v1beta1.CertificateIdentity) *v1beta1.PolicyBinding {
+		return &v1beta1.PolicyBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec:       v1beta1.PolicyBindingSpec{Certificate: identity, Policies: []string{"readwrite"}},
+		}
+	}
+	controller := Controller{
+		kubeClientSet: fake.NewSimpleClientset(),
+		minioClientSet: miniofake.NewSimpleClientset(
+			binding("common-name", &v1beta1.CertificateIdentity{CommonName: "edge-box-1"}),
+			binding("san", &v1beta1.CertificateIdentity{SAN: "edge-box-1.example.com"}),
+			binding("other-san", &v1beta1.CertificateIdentity{CommonName: "edge-box-1", SAN: "edge-box-2.example.com"}),
+			binding("empty", &v1beta1.CertificateIdentity{}),
+			&v1beta1.PolicyBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "service-account", Namespace: "ns"},
+				Spec:       v1beta1.PolicyBindingSpec{Application: &v1beta1.Application{Namespace: "app", ServiceAccount: "sa"}},
+			},
+		),
+	}
+	request := func(peerCertificates ...*x509.Certificate) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/sts/ns", nil)
+		r.TLS = &tls.ConnectionState{PeerCertificates: peerCertificates}
+		return r
+	}
+
+	// certificate authentication is off until the client CAs are configured
+	if _, errCode, _ := controller.certificatePolicyBindings(ctx, request(clientCert), "ns"); errCode != ErrSTSAccessDenied {
+		t.Errorf("error code without client CAs = %d, want %d", errCode, ErrSTSAccessDenied)
+	}
+	if _, err = controller.kubeClientSet.CoreV1().Secrets(miniov2.GetNSFromFile()).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: STSClientCASecretName, Namespace: miniov2.GetNSFromFile()},
+		Data:       map[string][]byte{certs.CAPublicCertFile: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, errCode, _ := controller.certificatePolicyBindings(ctx, request(), "ns"); errCode != ErrSTSMissingParameter {
+		t.Errorf("error code without client certificate = %d, want %d", errCode, ErrSTSMissingParameter)
+	}
+	if _, errCode, _ := controller.certificatePolicyBindings(ctx, request(untrustedCA), "ns"); errCode != ErrSTSInvalidClientCertificate {
+		t.Errorf("error code of a certificate of another CA = %d, want %d", errCode, ErrSTSInvalidClientCertificate)
+	}
+	policyBindings, _, err := controller.certificatePolicyBindings(ctx, request(clientCert), "ns")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, pb := range policyBindings {
+		names = append(names, pb.Name)
+	}
+	if len(names) != 2 || names[0] != "common-name" || names[1] != "san" {
+		t.Errorf("PolicyBindings = %v, want the ones matching the Common Name or the SAN", names)
+	}
+}
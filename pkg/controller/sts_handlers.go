@@ -55,6 +55,11 @@ const contextLogKey = contextKeyType("operatorlog")
 // Evalues a PolicyBinding CRD as Mapping of the Minio Policies that the ServiceAccount can assume on a minio tenant
 // Eg:-
 // $ curl -k -X POST https://operator:9443/sts/{tenantNamespace} -d "Version=2011-06-15&Action=AssumeRoleWithWebIdentity&WebIdentityToken=<jwt>" -H "Content-Type: application/x-www-form-urlencoded"
+//
+// AssumeRoleWithCertificate authenticates the TLS client certificate of the request instead, the PolicyBindings
+// matching the certificate map the policies
+// Eg:-
+// $ curl --cert client.crt --key client.key -X POST https://operator:9443/sts/{tenantNamespace} -d "Version=2011-06-15&Action=AssumeRoleWithCertificate"
 func (c *Controller) AssumeRoleWithWebIdentityHandler(w http.ResponseWriter, r *http.Request) {
 	routerVars := mux.Vars(r)
 	tenantNamespace := ""
@@ -92,73 +97,22 @@ func (c *Controller) AssumeRoleWithWebIdentityHandler(w http.ResponseWriter, r *
 		return
 	}
 
+	// Authorized PolicyBindings for the identity of the request
+	var policyBindings []v1beta1.PolicyBinding
+	var errCode STSErrorCode
 	action := r.Form.Get(stsAction)
 	switch action {
-	// For now we only do WebIdentity, leaving it in case we want to implement certificate authentication
 	case webIdentity:
+		policyBindings, errCode, err = c.webIdentityPolicyBindings(ctx, r, tenantNamespace)
+	case certificateIdentity:
+		policyBindings, errCode, err = c.certificatePolicyBindings(ctx, r, tenantNamespace)
 	default:
 		writeSTSErrorResponse(w, true, ErrSTSInvalidParameterValue, fmt.Errorf("Unsupported action %s", action))
 		return
 	}
-
-	token := strings.TrimSpace(r.Form.Get(stsWebIdentityToken))
-
-	if token == "" {
-		writeSTSErrorResponse(w, true, ErrSTSMissingParameter, fmt.Errorf("Missing parameter '%s'", stsWebIdentityToken))
-		return
-	}
-
-	// VALIDATE JWT
-	accessToken := r.Form.Get(stsWebIdentityToken)
-	saAuthResult, err := c.ValidateServiceAccountJWT(&ctx, accessToken)
+	reqInfo.API = action
 	if err != nil {
-		writeSTSErrorResponse(w, true, ErrSTSInvalidIdentityToken, err)
-		return
-	}
-
-	isSSTSAudience := false
-	for _, audience := range saAuthResult.Status.Audiences {
-		if audience == TokenReviewAudience {
-			isSSTSAudience = true
-		}
-	}
-
-	if !isSSTSAudience {
-		writeSTSErrorResponse(w, true, ErrSTSAccessDenied, fmt.Errorf("Access denied: Invalid Token, audience '%s' not found", TokenReviewAudience))
-		return
-	}
-
-	if !saAuthResult.Status.Authenticated {
-		writeSTSErrorResponse(w, true, ErrSTSAccessDenied, fmt.Errorf("Access denied: Invalid Token"))
-		return
-	}
-
-	chunks := strings.Split(strings.Replace(saAuthResult.Status.User.Username, "system:serviceaccount:", "", -1), ":")
-
-	if len(chunks) < 2 {
-		writeSTSErrorResponse(w, true, ErrSTSInvalidIdentityToken, fmt.Errorf("Error parsing service account name and namespace"))
-		return
-	}
-	// saNamespace Service account Namespace
-	saNamespace := chunks[0]
-	// saName service account username
-	saName := chunks[1]
-
-	// Authorized PolicyBindings for the Service Account
-	policyBindings := []v1beta1.PolicyBinding{}
-	pbs, err := c.minioClientSet.StsV1beta1().PolicyBindings(tenantNamespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		writeSTSErrorResponse(w, true, ErrSTSInternalError, fmt.Errorf("Error obtaining PolicyBindings: %s", err))
-		return
-	}
-
-	for _, pb := range pbs.Items {
-		if pb.Spec.Application.Namespace == saNamespace && pb.Spec.Application.ServiceAccount == saName {
-			policyBindings = append(policyBindings, pb)
-		}
-	}
-	if len(policyBindings) == 0 {
-		writeSTSErrorResponse(w, true, ErrSTSAccessDenied, fmt.Errorf("Service account '%s' has no PolicyBindings in namespace '%s'", saAuthResult.Status.User.Username, tenantNamespace))
+		writeSTSErrorResponse(w, true, errCode, err)
 		return
 	}
 
@@ -278,17 +232,83 @@ func (c *Controller) AssumeRoleWithWebIdentityHandler(w http.ResponseWriter, r *
 		return
 	}
 
+	credentials := Credentials{
+		AccessKey:    stsCredentials.AccessKeyID,
+		SecretKey:    stsCredentials.SecretAccessKey,
+		SessionToken: stsCredentials.SessionToken,
+		Expiration:   stsCredentials.Expiration,
+	}
+	if action == certificateIdentity {
+		assumeRoleResponse := &AssumeRoleWithCertificateResponse{}
+		assumeRoleResponse.Result.Credentials = credentials
+		assumeRoleResponse.ResponseMetadata.RequestID = w.Header().Get(AmzRequestID)
+		writeSuccessResponseXML(w, xhttp.EncodeResponse(assumeRoleResponse))
+		return
+	}
 	assumeRoleResponse := &AssumeRoleWithWebIdentityResponse{
 		Result: WebIdentityResult{
-			Credentials: Credentials{
-				AccessKey:    stsCredentials.AccessKeyID,
-				SecretKey:    stsCredentials.SecretAccessKey,
-				SessionToken: stsCredentials.SessionToken,
-				Expiration:   stsCredentials.Expiration,
-			},
+			Credentials: credentials,
 		},
 	}
 
 	assumeRoleResponse.ResponseMetadata.RequestID = w.Header().Get(AmzRequestID)
 	writeSuccessResponseXML(w, xhttp.EncodeResponse(assumeRoleResponse))
 }
+
+// webIdentityPolicyBindings returns the PolicyBindings of the service account of the JWT of the request
+func (c *Controller) webIdentityPolicyBindings(ctx context.Context, r *http.Request, tenantNamespace string) ([]v1beta1.PolicyBinding, STSErrorCode, error) {
+	token := strings.TrimSpace(r.Form.Get(stsWebIdentityToken))
+
+	if token == "" {
+		return nil, ErrSTSMissingParameter, fmt.Errorf("Missing parameter '%s'", stsWebIdentityToken)
+	}
+
+	// VALIDATE JWT
+	accessToken := r.Form.Get(stsWebIdentityToken)
+	saAuthResult, err := c.ValidateServiceAccountJWT(&ctx, accessToken)
+	if err != nil {
+		return nil, ErrSTSInvalidIdentityToken, err
+	}
+
+	isSSTSAudience := false
+	for _, audience := range saAuthResult.Status.Audiences {
+		if audience == TokenReviewAudience {
+			isSSTSAudience = true
+		}
+	}
+
+	if !isSSTSAudience {
+		return nil, ErrSTSAccessDenied, fmt.Errorf("Access denied: Invalid Token, audience '%s' not found", TokenReviewAudience)
+	}
+
+	if !saAuthResult.Status.Authenticated {
+		return nil, ErrSTSAccessDenied, fmt.Errorf("Access denied: Invalid Token")
+	}
+
+	chunks := strings.Split(strings.Replace(saAuthResult.Status.User.Username, "system:serviceaccount:", "", -1), ":")
+
+	if len(chunks) < 2 {
+		return nil, ErrSTSInvalidIdentityToken, fmt.Errorf("Error parsing service account name and namespace")
+	}
+	// saNamespace Service account Namespace
+	saNamespace := chunks[0]
+	// saName service account username
+	saName := chunks[1]
+
+	// Authorized PolicyBindings for the Service Account
+	policyBindings := []v1beta1.PolicyBinding{}
+	pbs, err := c.minioClientSet.StsV1beta1().PolicyBindings(tenantNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, ErrSTSInternalError, fmt.Errorf("Error obtaining PolicyBindings: %s", err)
+	}
+
+	for _, pb := range pbs.Items {
+		if pb.Spec.Application != nil && pb.Spec.Application.Namespace == saNamespace && pb.Spec.Application.ServiceAccount == saName {
+			policyBindings = append(policyBindings, pb)
+		}
+	}
+	if len(policyBindings) == 0 {
+		return nil, ErrSTSAccessDenied, fmt.Errorf("Service account '%s' has no PolicyBindings in namespace '%s'", saAuthResult.Status.User.Username, tenantNamespace)
+	}
+	return policyBindings, ErrSTSNone, nil
+}
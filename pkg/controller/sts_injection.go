@@ -0,0 +1,399 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/certs"
+	"github.com/minio/operator/pkg/common"
+	"github.com/minio/operator/pkg/resources/statefulsets"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// STSInjectionEnabled Env variable name to turn on and off the STS credential injection webhook, disabled by default
+	STSInjectionEnabled = "OPERATOR_STS_INJECTION_ENABLED"
+
+	// STSInjectionWebhookName is the name of the MutatingWebhookConfiguration managed by the Operator
+	STSInjectionWebhookName = "minio-operator-sts-injection"
+
+	// STSInjectLabel opts a pod in the STS injection webhook, only pods labelled `sts.min.io/inject: "true"` are sent to
+	// the Operator when they are created
+	STSInjectLabel = "sts.min.io/inject"
+
+	// STSTenantAnnotation requests STS credentials injection for a pod, the value is the tenant as `namespace/name`
+	STSTenantAnnotation = "sts.min.io/tenant"
+
+	// STSCAConfigMapAnnotation optionally names a ConfigMap in the pod namespace with a `ca.crt` used to trust the
+	// STS and tenant endpoints, defaults to the cluster `kube-root-ca.crt` ConfigMap
+	STSCAConfigMapAnnotation = "sts.min.io/ca-configmap"
+
+	// STSInjectedAnnotation is set on pods mutated by the webhook
+	STSInjectedAnnotation = "sts.min.io/injected"
+)
+
+const (
+	stsInjectionContainerName    = "sts-credentials"
+	stsInjectionTokenVolume      = "sts-token"
+	stsInjectionCAVolume         = "sts-ca"
+	stsInjectionCredsVolume      = "sts-credentials"
+	stsInjectionTokenPath        = "/var/run/secrets/sts.min.io/serviceaccount"
+	stsInjectionCAPath           = "/var/run/secrets/sts.min.io/ca"
+	stsInjectionCredsPath        = "/var/run/secrets/sts.min.io/credentials"
+	stsInjectionTokenExpiration  = 3600
+	stsInjectionDefaultCAConfig  = "kube-root-ca.crt"
+	stsInjectionDefaultRegion    = "us-east-1"
+	stsInjectionSidecarEntryPath = "/minio-operator-sidecar"
+)
+
+// jsonPatchOperation is a single RFC 6902 operation returned to the API server
+type jsonPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// IsSTSInjectionEnabled Validates if the STS credential injection webhook is turned on, is disabled by default.
+func IsSTSInjectionEnabled() bool {
+	value, set := os.LookupEnv(STSInjectionEnabled)
+	if set {
+		return value == "on"
+	}
+	return false
+}
+
+// STSInjectionHandler - POST /webhook/v1/sts-injection
+// Mutating admission webhook that gives pods labelled `sts.min.io/inject: "true"` and annotated with
+// `sts.min.io/tenant: <namespace>/<name>` an auto-refreshing AWS shared credentials file obtained from the Operator STS
+func (c *Controller) STSInjectionHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 4<<20))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	review := admissionv1.AdmissionReview{}
+	if err = json.Unmarshal(body, &review); err != nil || review.Request == nil {
+		http.Error(w, "invalid admission review", http.StatusBadRequest)
+		return
+	}
+
+	response := &admissionv1.AdmissionResponse{
+		UID:     review.Request.UID,
+		Allowed: true,
+	}
+	patch, warnings, err := c.stsInjectionPatchForRequest(review.Request)
+	if err != nil {
+		response.Allowed = false
+		response.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+	} else if len(patch) > 0 {
+		patchBytes, err := json.Marshal(patch)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		response.Patch = patchBytes
+		response.PatchType = ptr.To(admissionv1.PatchTypeJSONPatch)
+	}
+	response.Warnings = warnings
+
+	review.Response = response
+	review.Request = nil
+	out, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(out)
+}
+
+// stsInjectionPatchForRequest resolves the tenant referenced by the pod and builds the patch, a
+// malformed annotation rejects the pod while a missing tenant only warns the user
+func (c *Controller) stsInjectionPatchForRequest(req *admissionv1.AdmissionRequest) ([]jsonPatchOperation, []string, error) {
+	if req.Kind.Kind != "Pod" || req.Operation != admissionv1.Create {
+		return nil, nil, nil
+	}
+	pod := corev1.Pod{}
+	if err := json.Unmarshal(req.Object.Raw, &pod); err != nil {
+		return nil, nil, err
+	}
+	tenantRef, ok := pod.Annotations[STSTenantAnnotation]
+	if !ok || pod.Annotations[STSInjectedAnnotation] == "true" {
+		return nil, nil, nil
+	}
+	tenantNamespace, tenantName, err := parseSTSTenantAnnotation(tenantRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	// served from the informer cache, a burst of pod creations must not hit the API server
+	tenant, err := c.tenantLister.Tenants(tenantNamespace).Get(tenantName)
+	if err != nil {
+		klog.Warningf("STS injection skipped for pod in namespace %s: %v", req.Namespace, err)
+		return nil, []string{fmt.Sprintf("STS credentials not injected, tenant %s not available: %v", tenantRef, err)}, nil
+	}
+	return stsInjectionPatch(&pod, tenant, stsInjectionEndpoint(tenantNamespace)), nil, nil
+}
+
+// parseSTSTenantAnnotation splits a `namespace/name` tenant reference
+func parseSTSTenantAnnotation(value string) (string, string, error) {
+	parts := strings.Split(strings.TrimSpace(value), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid %s annotation '%s', expected <namespace>/<tenant>", STSTenantAnnotation, value)
+	}
+	return parts[0], parts[1], nil
+}
+
+// stsInjectionEndpoint returns the in-cluster Operator STS URL for a tenant namespace
+func stsInjectionEndpoint(tenantNamespace string) string {
+	return fmt.Sprintf("https://sts.%s.svc.%s:%d%s/%s", miniov2.GetNSFromFile(), miniov2.GetClusterDomain(), STSDefaultPort, STSEndpoint, tenantNamespace)
+}
+
+// stsInjectionPatch builds the patch adding the STS token, CA bundle, credentials volume and the
+// refresh sidecar to the pod, application containers get the AWS SDK environment pointing to the tenant
+func stsInjectionPatch(pod *corev1.Pod, tenant *miniov2.Tenant, stsEndpoint string) []jsonPatchOperation {
+	for _, v := range pod.Spec.Volumes {
+		if v.Name == stsInjectionTokenVolume {
+			return nil
+		}
+	}
+
+	caConfigMap := stsInjectionDefaultCAConfig
+	if val, ok := pod.Annotations[STSCAConfigMapAnnotation]; ok && val != "" {
+		caConfigMap = val
+	}
+	caFile := stsInjectionCAPath + "/" + certs.CAPublicCertFile
+	credentialsFile := stsInjectionCredsPath + "/credentials"
+
+	volumes := append(pod.Spec.Volumes,
+		corev1.Volume{
+			Name: stsInjectionTokenVolume,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          TokenReviewAudience,
+							ExpirationSeconds: ptr.To(int64(stsInjectionTokenExpiration)),
+							Path:              "token",
+						},
+					}},
+				},
+			},
+		},
+		corev1.Volume{
+			Name: stsInjectionCAVolume,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: caConfigMap},
+					Items:                []corev1.KeyToPath{{Key: certs.CAPublicCertFile, Path: certs.CAPublicCertFile}},
+				},
+			},
+		},
+		corev1.Volume{
+			Name: stsInjectionCredsVolume,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+			},
+		},
+	)
+
+	credentialsCmd := []string{stsInjectionSidecarEntryPath, "sts-credentials", "--credentials-file", credentialsFile}
+	// native sidecar, starts before the application containers and does not block Job completion
+	sidecar := corev1.Container{
+		Name:          stsInjectionContainerName,
+		Image:         statefulsets.GetSidecarImage(),
+		RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways),
+		Args: []string{
+			"sts-credentials",
+			"--sts-endpoint", stsEndpoint,
+			"--token-file", stsInjectionTokenPath + "/token",
+			"--ca-file", caFile,
+			"--credentials-file", credentialsFile,
+		},
+		StartupProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				Exec: &corev1.ExecAction{Command: append(credentialsCmd, "--check")},
+			},
+			PeriodSeconds:    1,
+			FailureThreshold: 120,
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: stsInjectionTokenVolume, MountPath: stsInjectionTokenPath, ReadOnly: true},
+			{Name: stsInjectionCAVolume, MountPath: stsInjectionCAPath, ReadOnly: true},
+			{Name: stsInjectionCredsVolume, MountPath: stsInjectionCredsPath},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			ReadOnlyRootFilesystem:   ptr.To(true),
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		},
+	}
+	initContainers := append([]corev1.Container{sidecar}, pod.Spec.InitContainers...)
+
+	envs := []corev1.EnvVar{
+		{Name: "AWS_SHARED_CREDENTIALS_FILE", Value: credentialsFile},
+		{Name: "AWS_ENDPOINT_URL_S3", Value: tenant.MinIOServerEndpoint()},
+		{Name: "AWS_CA_BUNDLE", Value: caFile},
+		{Name: "AWS_REGION", Value: stsInjectionDefaultRegion},
+	}
+	containers := make([]corev1.Container, len(pod.Spec.Containers))
+	for i := range pod.Spec.Containers {
+		container := *pod.Spec.Containers[i].DeepCopy()
+		defined := miniov2.ToMap(container.Env)
+		for _, env := range envs {
+			// respect any value set by the user
+			if _, ok := defined[env.Name]; !ok {
+				container.Env = append(container.Env, env)
+			}
+		}
+		container.VolumeMounts = append(container.VolumeMounts,
+			corev1.VolumeMount{Name: stsInjectionCAVolume, MountPath: stsInjectionCAPath, ReadOnly: true},
+			corev1.VolumeMount{Name: stsInjectionCredsVolume, MountPath: stsInjectionCredsPath, ReadOnly: true},
+		)
+		containers[i] = container
+	}
+
+	patch := []jsonPatchOperation{
+		{Op: "add", Path: "/spec/volumes", Value: volumes},
+		{Op: "add", Path: "/spec/initContainers", Value: initContainers},
+		{Op: "add", Path: "/spec/containers", Value: containers},
+	}
+	if pod.Annotations == nil {
+		patch = append(patch, jsonPatchOperation{Op: "add", Path: "/metadata/annotations", Value: map[string]string{}})
+	}
+	patch = append(patch, jsonPatchOperation{
+		Op:    "add",
+		Path:  "/metadata/annotations/" + strings.ReplaceAll(STSInjectedAnnotation, "/", "~1"),
+		Value: "true",
+	})
+	return patch
+}
+
+// checkAndCreateSTSInjectionWebhook keeps the MutatingWebhookConfiguration for the STS injection in sync,
+// removing it when the feature is turned off
+func (c *Controller) checkAndCreateSTSInjectionWebhook(ctx context.Context) error {
+	client := c.kubeClientSet.AdmissionregistrationV1().MutatingWebhookConfigurations()
+	existing, err := client.Get(ctx, STSInjectionWebhookName, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	found := err == nil
+
+	if !IsSTSEnabled() || !IsSTSInjectionEnabled() {
+		if found {
+			klog.Infof("STS injection is disabled, removing MutatingWebhookConfiguration %s", STSInjectionWebhookName)
+			return client.Delete(ctx, STSInjectionWebhookName, metav1.DeleteOptions{})
+		}
+		return nil
+	}
+
+	caBundle, err := c.stsInjectionCABundle(ctx)
+	if err != nil {
+		return err
+	}
+	operatorNamespace := miniov2.GetNSFromFile()
+	webhook := admissionregistrationv1.MutatingWebhook{
+		Name: "sts-injection.sts.min.io",
+		ClientConfig: admissionregistrationv1.WebhookClientConfig{
+			Service: &admissionregistrationv1.ServiceReference{
+				Namespace: operatorNamespace,
+				Name:      "sts",
+				Path:      ptr.To(common.WebhookAPISTSInjection),
+				Port:      ptr.To(int32(STSDefaultPort)),
+			},
+			CABundle: caBundle,
+		},
+		Rules: []admissionregistrationv1.RuleWithOperations{{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{""},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"pods"},
+			},
+		}},
+		// never block pod creation if the operator is unavailable
+		FailurePolicy: ptr.To(admissionregistrationv1.Ignore),
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      corev1.LabelMetadataName,
+				Operator: metav1.LabelSelectorOpNotIn,
+				Values:   []string{operatorNamespace, metav1.NamespaceSystem},
+			}},
+		},
+		// the other pods of the cluster are not sent to the Operator
+		ObjectSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{STSInjectLabel: "true"},
+		},
+		SideEffects:             ptr.To(admissionregistrationv1.SideEffectClassNone),
+		AdmissionReviewVersions: []string{"v1"},
+		TimeoutSeconds:          ptr.To(int32(5)),
+		ReinvocationPolicy:      ptr.To(admissionregistrationv1.NeverReinvocationPolicy),
+	}
+
+	if !found {
+		klog.Infof("Creating MutatingWebhookConfiguration %s", STSInjectionWebhookName)
+		_, err = client.Create(ctx, &admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: STSInjectionWebhookName,
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by": "minio-operator",
+				},
+			},
+			Webhooks: []admissionregistrationv1.MutatingWebhook{webhook},
+		}, metav1.CreateOptions{})
+		return err
+	}
+	existing.Webhooks = []admissionregistrationv1.MutatingWebhook{webhook}
+	_, err = client.Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// stsInjectionCABundle returns the CA the API server uses to call the STS webhook, the `ca.crt` in the
+// STS TLS secret when present (eg: cert-manager) or the cluster CA that signs the Operator CSRs
+func (c *Controller) stsInjectionCABundle(ctx context.Context) ([]byte, error) {
+	secret, err := c.getCertificateSecret(ctx, miniov2.GetNSFromFile(), STSTLSSecretName)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		if ca, ok := secret.Data[certs.CAPublicCertFile]; ok && len(ca) > 0 {
+			return ca, nil
+		}
+	}
+	ca := miniov2.GetPodCAFromFile()
+	if len(ca) == 0 {
+		return nil, fmt.Errorf("unable to determine the CA bundle for the STS injection webhook")
+	}
+	return ca, nil
+}
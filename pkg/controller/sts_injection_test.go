@@ -0,0 +1,137 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/certs"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_parseSTSTenantAnnotation(t *testing.T) {
+	tests := []struct {
+		value     string
+		namespace string
+		name      string
+		wantErr   bool
+	}{
+		{value: "tenant-ns/myminio", namespace: "tenant-ns", name: "myminio"},
+		{value: " tenant-ns/myminio ", namespace: "tenant-ns", name: "myminio"},
+		{value: "myminio", wantErr: true},
+		{value: "tenant-ns/", wantErr: true},
+		{value: "a/b/c", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			namespace, name, err := parseSTSTenantAnnotation(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSTSTenantAnnotation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if namespace != tt.namespace || name != tt.name {
+				t.Errorf("parseSTSTenantAnnotation() = %s/%s, want %s/%s", namespace, name, tt.namespace, tt.name)
+			}
+		})
+	}
+}
+
+func Test_stsInjectionPatch(t *testing.T) {
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "myminio", Namespace: "tenant-ns"},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{STSTenantAnnotation: "tenant-ns/myminio"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "app",
+				Env:  []corev1.EnvVar{{Name: "AWS_REGION", Value: "eu-west-1"}},
+			}},
+		},
+	}
+
+	patch := stsInjectionPatch(pod, tenant, "https://sts.minio-operator.svc.cluster.local:4223/sts/tenant-ns")
+	if len(patch) != 4 {
+		t.Fatalf("expected 4 patch operations, got %d", len(patch))
+	}
+
+	volumes := patch[0].Value.([]corev1.Volume)
+	if len(volumes) != 3 || volumes[0].Projected.Sources[0].ServiceAccountToken.Audience != TokenReviewAudience {
+		t.Errorf("unexpected volumes %+v", volumes)
+	}
+
+	initContainers := patch[1].Value.([]corev1.Container)
+	if len(initContainers) != 1 || initContainers[0].Name != stsInjectionContainerName {
+		t.Fatalf("unexpected init containers %+v", initContainers)
+	}
+	if initContainers[0].RestartPolicy == nil || *initContainers[0].RestartPolicy != corev1.ContainerRestartPolicyAlways {
+		t.Errorf("credentials sidecar must be a native sidecar")
+	}
+
+	containers := patch[2].Value.([]corev1.Container)
+	envs := miniov2.ToMap(containers[0].Env)
+	if envs["AWS_REGION"] != "eu-west-1" {
+		t.Errorf("user defined AWS_REGION was overridden: %s", envs["AWS_REGION"])
+	}
+	if envs["AWS_ENDPOINT_URL_S3"] != tenant.MinIOServerEndpoint() {
+		t.Errorf("unexpected AWS_ENDPOINT_URL_S3 %s", envs["AWS_ENDPOINT_URL_S3"])
+	}
+	if _, ok := envs["AWS_SHARED_CREDENTIALS_FILE"]; !ok {
+		t.Errorf("AWS_SHARED_CREDENTIALS_FILE not injected")
+	}
+	if len(pod.Spec.Containers[0].Env) != 1 {
+		t.Errorf("original pod containers must not be modified")
+	}
+
+	if patch[3].Path != "/metadata/annotations/sts.min.io~1injected" {
+		t.Errorf("unexpected annotation path %s", patch[3].Path)
+	}
+
+	// already injected pods are left untouched
+	pod.Spec.Volumes = volumes
+	if patch := stsInjectionPatch(pod, tenant, ""); patch != nil {
+		t.Errorf("expected no patch for an injected pod, got %+v", patch)
+	}
+}
+
+func Test_checkAndCreateSTSInjectionWebhook(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv(STSInjectionEnabled, "on")
+	controller := Controller{
+		kubeClientSet: fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: STSTLSSecretName, Namespace: miniov2.GetNSFromFile()},
+			Data:       map[string][]byte{certs.CAPublicCertFile: []byte("ca")},
+		}),
+	}
+	if err := controller.checkAndCreateSTSInjectionWebhook(ctx); err != nil {
+		t.Fatal(err)
+	}
+	webhookConfiguration, err := controller.kubeClientSet.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, STSInjectionWebhookName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// only the pods opting in are sent to the Operator
+	selector := webhookConfiguration.Webhooks[0].ObjectSelector
+	if selector == nil || selector.MatchLabels[STSInjectLabel] != "true" {
+		t.Errorf("objectSelector = %v, want the %s label required", selector, STSInjectLabel)
+	}
+}
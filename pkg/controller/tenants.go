@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
@@ -45,8 +46,29 @@ func (c *Controller) getTenantConfiguration(ctx context.Context, tenant *miniov2
 	return tenantConfiguration, nil
 }
 
-// getTenantCredentials returns a combination of env and Configuration tenant credentials
+// getTenantCredentials returns a combination of env and Configuration tenant credentials, the root credentials are the
+// ones MinIO runs with, they lag behind the configured ones while a rotation is in progress
 func (c *Controller) getTenantCredentials(ctx context.Context, tenant *miniov2.Tenant) (map[string][]byte, error) {
+	tenantConfiguration, err := c.getConfiguredTenantCredentials(ctx, tenant)
+	if err != nil {
+		return tenantConfiguration, err
+	}
+	secret, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Get(ctx, tenant.ActiveRootCredentialsSecretName(), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return tenantConfiguration, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(secret.Data["accesskey"]) > 0 && len(secret.Data["secretkey"]) > 0 {
+		tenantConfiguration["accesskey"] = secret.Data["accesskey"]
+		tenantConfiguration["secretkey"] = secret.Data["secretkey"]
+	}
+	return tenantConfiguration, nil
+}
+
+// getConfiguredTenantCredentials returns a combination of env and Configuration tenant credentials as configured
+func (c *Controller) getConfiguredTenantCredentials(ctx context.Context, tenant *miniov2.Tenant) (map[string][]byte, error) {
 	// Configuration for tenant can be passed using 2 different sources, tenant.spec.env and config.env secret
 	// If the user provides duplicated configuration the override order will be:
 	// tenant.Spec.Env < config.env file (k8s secret)
@@ -134,6 +134,12 @@ func (c *Controller) generateTLSCertificateForService(serviceName, secretName, d
 					}
 				}
 			}
+		} else if c.operatorCertificateKeyChanged(tlsCertSecret, operatorDeployment) {
+			klog.Infof("Deleting the %s TLS secret to issue it again with the configured key algorithm", secretName)
+			if err = c.kubeClientSet.CoreV1().Secrets(namespace).Delete(ctx, secretName, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+				klog.Infof(err.Error())
+				time.Sleep(time.Second * 10)
+			}
 		} else {
 			publicCertPath, publicKeyPath = c.writeCertSecretToFile(tlsCertSecret, serviceName)
 			break
@@ -169,6 +175,25 @@ func (c *Controller) checkAndCreateCSR(ctx context.Context, deployment metav1.Ob
 	return nil
 }
 
+// operatorCertificateKeyChanged returns true if the key of a certificate generated by the operator doesn't have the
+// configured algorithm and size, certificates provided by the user are left untouched
+func (c *Controller) operatorCertificateKeyChanged(tlsCertSecret *corev1.Secret, operatorDeployment metav1.Object) bool {
+	if !metav1.IsControlledBy(tlsCertSecret, operatorDeployment) {
+		return false
+	}
+	publicCertKey, _ := c.getKeyNames(tlsCertSecret)
+	block, _ := pem.Decode(tlsCertSecret.Data[publicCertKey])
+	if block == nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false
+	}
+	keyAlgorithm, keySize := certificateKeyParams(operatorCertificateConfig(), miniov2.CertificateKeyAlgorithmECDSA)
+	return !certificateKeyMatches(cert.PublicKey, keyAlgorithm, keySize)
+}
+
 // getKeyNames Identify the K8s secret keys containing the public and private TLS certificate keys
 func (c *Controller) getKeyNames(tlsCertificateSecret *corev1.Secret) (string, string) {
 	// default secret keys for Opaque k8s secret
@@ -183,7 +208,7 @@ func (c *Controller) getKeyNames(tlsCertificateSecret *corev1.Secret) (string, s
 }
 
 // createCertificateSecret Stores the private and public keys in a Secret
-func (c *Controller) createCertificateSecret(ctx context.Context, deployment metav1.Object, labels map[string]string, secretName string, pkBytes, certBytes []byte) error {
+func (c *Controller) createCertificateSecret(ctx context.Context, deployment metav1.Object, labels map[string]string, secretName string, pkBytes, certBytes, caBytes []byte) error {
 	secret := &corev1.Secret{
 		Type: "Opaque",
 		ObjectMeta: metav1.ObjectMeta{
@@ -203,6 +228,10 @@ func (c *Controller) createCertificateSecret(ctx context.Context, deployment met
 			certs.PublicCertFile: certBytes,
 		},
 	}
+	// certificates signed by the internal CA come with its bundle
+	if len(caBytes) > 0 {
+		secret.Data[certs.CAPublicCertFile] = caBytes
+	}
 	_, err := c.kubeClientSet.CoreV1().Secrets(miniov2.GetNSFromFile()).Create(ctx, secret, metav1.CreateOptions{})
 	return err
 }
@@ -211,35 +240,54 @@ func (c *Controller) createCertificateSecret(ctx context.Context, deployment met
 // finally creating a secret storing private key and certificate for TLS
 // This Method Blocks till the CSR Request is approved via kubectl approve
 func (c *Controller) createAndStoreCSR(ctx context.Context, deployment metav1.Object, serviceName string, csrName string, secretName string) error {
-	privKeysBytes, csrBytes, err := generateServiceCSRCryptoData(serviceName)
+	encodedPrivateKey, certBytes, caBytes, err := c.issueServiceCertificate(ctx, serviceName, csrName)
 	if err != nil {
-		klog.Errorf("Private Key and CSR generation failed with error: %v", err)
 		return err
 	}
-	namespace := miniov2.GetNSFromFile()
-	err = c.createCertificateSigningRequest(ctx, map[string]string{}, csrName, namespace, csrBytes)
+
+	// Create secret
+	err = c.createCertificateSecret(ctx, deployment, map[string]string{}, secretName, encodedPrivateKey, certBytes, caBytes)
 	if err != nil {
-		klog.Errorf("Unexpected error during the creation of the csr/%s: %v", csrName, err)
+		klog.Errorf("Unexpected error during the creation of the %s/%s secret: %v", deployment.GetNamespace(), secretName, err)
 		return err
 	}
+	return nil
+}
 
-	// fetch certificate from CSR
-	certBytes, err := c.fetchCertificate(ctx, csrName)
+// issueServiceCertificate generates a key for the service and gets its certificate signed through the internal CA or
+// the Kubernetes CSR API, returning the PEM encoded private key, certificate and CA bundle
+func (c *Controller) issueServiceCertificate(ctx context.Context, serviceName string, csrName string) ([]byte, []byte, []byte, error) {
+	config := operatorCertificateConfig()
+	privKeysBytes, csrBytes, err := generateServiceCSRCryptoData(serviceName, config)
 	if err != nil {
-		klog.Errorf("Unexpected error during the creation of the csr/%s: %v", csrName, err)
-		return err
+		klog.Errorf("Private Key and CSR generation failed with error: %v", err)
+		return nil, nil, nil, err
 	}
+	var certBytes, caBytes []byte
+	if certificates.UseInternalCA() {
+		certBytes, caBytes, err = c.signCSRWithInternalCA(ctx, csrBytes, certificateDuration(config))
+		if err != nil {
+			klog.Errorf("Unexpected error signing the %s certificate with the internal CA: %v", serviceName, err)
+			return nil, nil, nil, err
+		}
+	} else {
+		namespace := miniov2.GetNSFromFile()
+		err = c.createCertificateSigningRequest(ctx, map[string]string{}, csrName, namespace, csrBytes, certificateDuration(config))
+		if err != nil {
+			klog.Errorf("Unexpected error during the creation of the csr/%s: %v", csrName, err)
+			return nil, nil, nil, err
+		}
 
-	// PEM encode private ECDSA key
-	encodedPrivateKey := pem.EncodeToMemory(&pem.Block{Type: privateKeyType, Bytes: privKeysBytes})
-
-	// Create secret
-	err = c.createCertificateSecret(ctx, deployment, map[string]string{}, secretName, encodedPrivateKey, certBytes)
-	if err != nil {
-		klog.Errorf("Unexpected error during the creation of the %s/%s secret: %v", deployment.GetNamespace(), secretName, err)
-		return err
+		// fetch certificate from CSR
+		certBytes, err = c.fetchCertificate(ctx, csrName)
+		if err != nil {
+			klog.Errorf("Unexpected error during the creation of the csr/%s: %v", csrName, err)
+			return nil, nil, nil, err
+		}
 	}
-	return nil
+
+	// PEM encode private key
+	return pem.EncodeToMemory(&pem.Block{Type: privateKeyType, Bytes: privKeysBytes}), certBytes, caBytes, nil
 }
 
 // createTLSConfig defines the tls.Config for the http servers.
@@ -299,17 +347,17 @@ func getCSRName(serviceName string) string {
 	return fmt.Sprintf("%s-%s-csr", serviceName, namespace)
 }
 
-// generateServiceCSRCryptoData Creates the private Key material
-func generateServiceCSRCryptoData(serviceName string) ([]byte, []byte, error) {
-	privateKey, err := newPrivateKey(miniov2.DefaultEllipticCurve)
+// generateServiceCSRCryptoData Creates the private Key material with the key algorithm of the config
+func generateServiceCSRCryptoData(serviceName string, config *miniov2.CertificateConfig) ([]byte, []byte, error) {
+	privateKey, err := newCertificateKey(certificateKeyParams(config, miniov2.CertificateKeyAlgorithmECDSA))
 	if err != nil {
-		klog.Errorf("Unexpected error during the ECDSA Key generation: %v", err)
+		klog.Errorf("Unexpected error during the Key generation: %v", err)
 		return nil, nil, err
 	}
 
 	privKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
 	if err != nil {
-		klog.Errorf("Unexpected error during encoding the ECDSA Private Key: %v", err)
+		klog.Errorf("Unexpected error during encoding the Private Key: %v", err)
 		return nil, nil, err
 	}
 
@@ -338,7 +386,7 @@ func generateServiceCSRCryptoData(serviceName string) ([]byte, []byte, error) {
 				Value:    []byte(opCommon),
 			},
 		},
-		SignatureAlgorithm: x509.ECDSAWithSHA512,
+		SignatureAlgorithm: csrSignatureAlgorithm(privateKey),
 		DNSNames:           []string{serviceName, opCommonNoDomain, opCommon},
 	}
 
@@ -0,0 +1,349 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/certs"
+)
+
+// trustRebuildInterval is how often the trust store is rebuilt, catching changes to the tenant secrets which are
+// not watched
+const trustRebuildInterval = 5 * time.Minute
+
+// Kinds of the sources of the operator trust store
+const (
+	trustSourcePodCA     = "PodCA"
+	trustSourceSecret    = "Secret"
+	trustSourceConfigMap = "ConfigMap"
+)
+
+// trustSourceKeys are the fields of secrets and config maps holding certificates to trust
+var trustSourceKeys = []string{certs.PublicCertFile, certs.TLSCertFile, certs.CAPublicCertFile}
+
+// trustedCertificate describes a certificate of the trust store
+type trustedCertificate struct {
+	Subject     string    `json:"subject"`
+	Fingerprint string    `json:"fingerprint"`
+	NotAfter    time.Time `json:"notAfter"`
+
+	cert *x509.Certificate
+}
+
+// trustSource is a declared source of trusted certificates
+type trustSource struct {
+	Kind         string               `json:"kind"`
+	Namespace    string               `json:"namespace,omitempty"`
+	Name         string               `json:"name,omitempty"`
+	Tenant       string               `json:"tenant,omitempty"`
+	Certificates []trustedCertificate `json:"certificates"`
+}
+
+func (s trustSource) String() string {
+	if s.Name == "" {
+		return s.Kind
+	}
+	return fmt.Sprintf("%s '%s/%s'", s.Kind, s.Namespace, s.Name)
+}
+
+// trustStore holds the transport trusting the certificates of the sources, the transport is replaced on every
+// rebuild so a removed source stops being trusted
+type trustStore struct {
+	// rebuild serializes the rebuilds
+	rebuild   sync.Mutex
+	mu        sync.RWMutex
+	transport *http.Transport
+	sources   []trustSource
+}
+
+// isTrustSource returns true if the object of the operator namespace holds certificates to trust, either secrets
+// with the "operator-ca-tls" prefix or secrets and config maps labelled with TrustedCALabel
+func isTrustSource(object metav1.Object, secret bool) bool {
+	if object.GetNamespace() != miniov2.GetNSFromFile() {
+		return false
+	}
+	if secret && strings.HasPrefix(object.GetName(), OperatorCATLSSecretName) {
+		return true
+	}
+	return object.GetLabels()[TrustedCALabel] == "true"
+}
+
+// parseTrustedCertificates returns the certificates of the PEM data, skipping anything that is not a certificate
+func parseTrustedCertificates(source string, pemData []byte) []trustedCertificate {
+	var trusted []trustedCertificate
+	for len(pemData) > 0 {
+		var block *pem.Block
+		if block, pemData = pem.Decode(pemData); block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			klog.Warningf("Ignoring invalid certificate of %s: %v", source, err)
+			continue
+		}
+		fingerprint := sha256.Sum256(cert.Raw)
+		trusted = append(trusted, trustedCertificate{
+			Subject:     cert.Subject.String(),
+			Fingerprint: hex.EncodeToString(fingerprint[:]),
+			NotAfter:    cert.NotAfter,
+			cert:        cert,
+		})
+	}
+	return trusted
+}
+
+// newTrustSource returns the source with the certificates of the fields, nil if it doesn't hold any
+func newTrustSource(source trustSource, data map[string][]byte) *trustSource {
+	seen := map[string]bool{}
+	for _, key := range trustSourceKeys {
+		for _, cert := range parseTrustedCertificates(source.String(), data[key]) {
+			if !seen[cert.Fingerprint] {
+				seen[cert.Fingerprint] = true
+				source.Certificates = append(source.Certificates, cert)
+			}
+		}
+	}
+	if len(source.Certificates) == 0 {
+		return nil
+	}
+	return &source
+}
+
+// collectTrustSources returns the declared sources of trusted certificates: the pod CA, the trust secrets and config
+// maps of the operator namespace and the TLS secrets of the tenants
+func (c *Controller) collectTrustSources(ctx context.Context) ([]trustSource, error) {
+	var sources []trustSource
+	add := func(source *trustSource) {
+		if source != nil {
+			sources = append(sources, *source)
+		}
+	}
+	add(newTrustSource(trustSource{Kind: trustSourcePodCA}, map[string][]byte{certs.CAPublicCertFile: miniov2.GetPodCAFromFile()}))
+
+	namespace := miniov2.GetNSFromFile()
+	secrets, err := c.kubeClientSet.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return sources, err
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if isTrustSource(secret, true) {
+			add(newTrustSource(trustSource{Kind: trustSourceSecret, Namespace: secret.Namespace, Name: secret.Name}, secret.Data))
+		}
+	}
+	configMaps, err := c.kubeClientSet.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: TrustedCALabel + "=true",
+	})
+	if err != nil {
+		return sources, err
+	}
+	for _, configMap := range configMaps.Items {
+		data := map[string][]byte{}
+		for _, key := range trustSourceKeys {
+			if value, ok := configMap.Data[key]; ok {
+				data[key] = []byte(value)
+			} else if value, ok := configMap.BinaryData[key]; ok {
+				data[key] = value
+			}
+		}
+		add(newTrustSource(trustSource{Kind: trustSourceConfigMap, Namespace: configMap.Namespace, Name: configMap.Name}, data))
+	}
+
+	if c.tenantLister == nil {
+		return sources, nil
+	}
+	tenants, err := c.tenantLister.List(labels.Everything())
+	if err != nil {
+		return sources, err
+	}
+	for _, tenant := range tenants {
+		var names []string
+		for _, secret := range append(tenant.Spec.ExternalCertSecret, tenant.Spec.ExternalCaCertSecret...) {
+			if secret != nil {
+				names = append(names, secret.Name)
+			}
+		}
+		if tenant.AutoCert() {
+			names = append(names, tenant.MinIOTLSSecretName())
+		}
+		for _, name := range names {
+			secret, err := c.kubeClientSet.CoreV1().Secrets(tenant.Namespace).Get(ctx, name, metav1.GetOptions{})
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return sources, err
+			}
+			add(newTrustSource(trustSource{Kind: trustSourceSecret, Namespace: secret.Namespace, Name: secret.Name, Tenant: tenant.Name}, secret.Data))
+		}
+	}
+	return sources, nil
+}
+
+// rebuildTrust replaces the transport with one trusting the system CAs and the certificates of the declared sources,
+// a source that can't be read keeps the current transport so an API error doesn't drop trust
+func (c *Controller) rebuildTrust(ctx context.Context) error {
+	c.trust.rebuild.Lock()
+	defer c.trust.rebuild.Unlock()
+
+	sources, err := c.collectTrustSources(ctx)
+	c.trust.mu.RLock()
+	previous, oldSources := c.trust.transport, c.trust.sources
+	c.trust.mu.RUnlock()
+	if err != nil && previous != nil {
+		return fmt.Errorf("unable to rebuild the trust store, keeping the trusted certificates: %v", err)
+	}
+
+	rootCAs := miniov2.MustGetSystemCertPool()
+	for _, source := range sources {
+		for _, cert := range source.Certificates {
+			rootCAs.AddCert(cert.cert)
+		}
+	}
+	transport := c.createTransport(rootCAs)
+
+	c.trust.mu.Lock()
+	c.trust.transport, c.trust.sources = transport, sources
+	c.trust.mu.Unlock()
+	if previous != nil {
+		// pooled connections were verified against the previous roots
+		previous.CloseIdleConnections()
+	}
+
+	logTrustChanges(oldSources, sources, previous == nil)
+	recordTrustSourcesExpiry(oldSources, sources)
+	return err
+}
+
+// logTrustChanges logs the certificates added to and removed from the trust store, all of them on the first build
+func logTrustChanges(oldSources, sources []trustSource, first bool) {
+	fingerprints := func(sources []trustSource) map[string]string {
+		set := map[string]string{}
+		for _, source := range sources {
+			for _, cert := range source.Certificates {
+				set[cert.Fingerprint] = fmt.Sprintf("'%s' (sha256 %s) from %s", cert.Subject, cert.Fingerprint, source)
+			}
+		}
+		return set
+	}
+	previous, current := fingerprints(oldSources), fingerprints(sources)
+	for fingerprint, description := range current {
+		if _, ok := previous[fingerprint]; !ok || first {
+			klog.Infof("Trusting certificate %s", description)
+		}
+	}
+	for fingerprint, description := range previous {
+		if _, ok := current[fingerprint]; !ok {
+			klog.Infof("No longer trusting certificate %s", description)
+		}
+	}
+}
+
+// recordTrustSourcesExpiry records the expiry of the trusted certificates, removing the series of dropped sources
+func recordTrustSourcesExpiry(oldSources, sources []trustSource) {
+	current := map[string]bool{}
+	for _, source := range sources {
+		if source.Kind == trustSourcePodCA {
+			continue
+		}
+		current[source.Namespace+"/"+source.Name] = true
+		var notAfter time.Time
+		for _, cert := range source.Certificates {
+			if notAfter.IsZero() || cert.NotAfter.Before(notAfter) {
+				notAfter = cert.NotAfter
+			}
+		}
+		certificateExpiry.WithLabelValues(source.Namespace, source.Name, CertificateTypeTrusted).Set(float64(notAfter.Unix()))
+	}
+	for _, source := range oldSources {
+		if source.Kind != trustSourcePodCA && !current[source.Namespace+"/"+source.Name] {
+			certificateExpiry.DeleteLabelValues(source.Namespace, source.Name, CertificateTypeTrusted)
+		}
+	}
+}
+
+// recurrentTrustRebuild rebuilds the trust store on schedule, so renewed or removed tenant certificates are picked up
+func (c *Controller) recurrentTrustRebuild(ctx context.Context) {
+	ticker := time.NewTicker(trustRebuildInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.rebuildTrust(ctx); err != nil {
+				klog.Errorf("Unable to rebuild the trust store: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// handleTrustConfigMap rebuilds the trust store when a labelled config map of the operator namespace changes
+func (c *Controller) handleTrustConfigMap(obj interface{}, oldObj interface{}) {
+	configMap, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return
+	}
+	oldConfigMap, _ := oldObj.(*corev1.ConfigMap)
+	if !isTrustSource(configMap, false) && (oldConfigMap == nil || !isTrustSource(oldConfigMap, false)) {
+		return
+	}
+	klog.Infof("ConfigMap '%s/%s' changed, rebuilding the trust store", configMap.Namespace, configMap.Name)
+	if err := c.rebuildTrust(context.Background()); err != nil {
+		klog.Errorf("Unable to rebuild the trust store: %v", err)
+	}
+}
+
+// serveTrust lists the sources and fingerprints of the trusted certificates, the system CAs are not listed
+func (c *Controller) serveTrust(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	c.trust.mu.RLock()
+	sources := c.trust.sources
+	c.trust.mu.RUnlock()
+	if sources == nil {
+		sources = []trustSource{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sources); err != nil {
+		klog.Errorf("Unable to write the trust store: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/certs"
+	listers "github.com/minio/operator/pkg/client/listers/minio.min.io/v2"
+)
+
+func newTrustedCA(t *testing.T, name string) (*x509.Certificate, []byte) {
+	t.Helper()
+	cert, _, err := newCACertificate(name, nil, nil, time.Now(), 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+func Test_rebuildTrust(t *testing.T) {
+	ctx := context.Background()
+	namespace := miniov2.GetNSFromFile()
+	secretCA, secretPEM := newTrustedCA(t, "secret-ca")
+	configMapCA, configMapPEM := newTrustedCA(t, "config-map-ca")
+	tenantCA, tenantPEM := newTrustedCA(t, "tenant-ca")
+	ignoredCA, ignoredPEM := newTrustedCA(t, "ignored-ca")
+
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "myminio", Namespace: "ns"},
+		Spec: miniov2.TenantSpec{
+			ExternalCaCertSecret: []*miniov2.LocalCertificateReference{{Name: "tenant-ca"}},
+		},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := indexer.Add(tenant); err != nil {
+		t.Fatal(err)
+	}
+	controller := Controller{
+		kubeClientSet: fake.NewSimpleClientset(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: OperatorCATLSSecretName + "-custom", Namespace: namespace},
+				Data:       map[string][]byte{certs.CAPublicCertFile: secretPEM},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: namespace},
+				Data:       map[string][]byte{certs.CAPublicCertFile: ignoredPEM},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "bundle", Namespace: namespace, Labels: map[string]string{TrustedCALabel: "true"}},
+				Data:       map[string]string{certs.CAPublicCertFile: string(configMapPEM)},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant-ca", Namespace: "ns"},
+				Data:       map[string][]byte{certs.CAPublicCertFile: tenantPEM},
+			},
+		),
+		tenantLister: listers.NewTenantLister(indexer),
+	}
+
+	trusts := func(cert *x509.Certificate) bool {
+		_, err := cert.Verify(x509.VerifyOptions{Roots: controller.getTransport().TLSClientConfig.RootCAs})
+		return err == nil
+	}
+	if !trusts(secretCA) || !trusts(configMapCA) || !trusts(tenantCA) {
+		t.Fatalf("the certificates of the sources are not trusted")
+	}
+	if trusts(ignoredCA) {
+		t.Errorf("the certificate of an unlabelled secret is trusted")
+	}
+
+	recorder := httptest.NewRecorder()
+	controller.serveTrust(recorder, httptest.NewRequest(http.MethodGet, "/debug/trust", nil))
+	var sources []trustSource
+	if err := json.NewDecoder(recorder.Body).Decode(&sources); err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) != 3 || sources[2].Tenant != tenant.Name || len(sources[2].Certificates[0].Fingerprint) != 64 {
+		t.Errorf("sources = %+v, want the secret, config map and tenant sources", sources)
+	}
+	recorder = httptest.NewRecorder()
+	controller.serveTrust(recorder, httptest.NewRequest(http.MethodPost, "/debug/trust", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST returned %d, want %d", recorder.Code, http.StatusMethodNotAllowed)
+	}
+
+	// removing a source drops its certificates
+	if err := controller.kubeClientSet.CoreV1().ConfigMaps(namespace).Delete(ctx, "bundle", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := controller.rebuildTrust(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if trusts(configMapCA) {
+		t.Errorf("the certificate of the removed config map is still trusted")
+	}
+	if !trusts(secretCA) || !trusts(tenantCA) {
+		t.Errorf("the certificates of the remaining sources are no longer trusted")
+	}
+}
@@ -85,11 +85,7 @@ func GetPrometheusConfig(t *miniov2.Tenant, accessKey, secretKey string) *Promet
 		ScrapeConfigs: []ScrapeConfig{},
 	}
 
-	if len(t.Spec.PrometheusOperatorScrapeMetricsPaths) == 0 {
-		t.Spec.PrometheusOperatorScrapeMetricsPaths = []string{"/minio/v2/metrics/cluster"}
-	}
-
-	for index, scrape := range t.Spec.PrometheusOperatorScrapeMetricsPaths {
+	for index, scrape := range t.GetPrometheusOperatorScrapeMetricsPaths() {
 		promConfig.ScrapeConfigs = append(promConfig.ScrapeConfigs, ScrapeConfig{
 			JobName:     fmt.Sprintf("%s-%d", t.PrometheusOperatorAddlConfigJobName(), index),
 			BearerToken: bearerToken,
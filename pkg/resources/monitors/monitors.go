@@ -0,0 +1,190 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package monitors
+
+import (
+	"fmt"
+	"strings"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/certs"
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// clusterCAConfigMap is published by Kubernetes in every namespace and holds the CA that signs the
+// certificates issued through the CSR API
+const clusterCAConfigMap = "kube-root-ca.crt"
+
+func monitorLabels(t *miniov2.Tenant) map[string]string {
+	labels := make(map[string]string, len(t.Spec.PrometheusOperatorMonitorLabels)+1)
+	for k, v := range t.Spec.PrometheusOperatorMonitorLabels {
+		labels[k] = v
+	}
+	labels[miniov2.TenantLabel] = t.Name
+	return labels
+}
+
+func scheme(t *miniov2.Tenant) string {
+	if t.TLS() {
+		return "https"
+	}
+	return "http"
+}
+
+func authorization(t *miniov2.Tenant) *promv1.SafeAuthorization {
+	return &promv1.SafeAuthorization{
+		Type: "Bearer",
+		Credentials: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: t.PrometheusBearerTokenSecretName()},
+			Key:                  miniov2.PrometheusBearerTokenSecretKey,
+		},
+	}
+}
+
+func safeTLSConfig(t *miniov2.Tenant) *promv1.SafeTLSConfig {
+	if !t.TLS() {
+		return nil
+	}
+	serverName := t.MinIOFQDNServiceName()
+	tlsConfig := &promv1.SafeTLSConfig{
+		ServerName: &serverName,
+	}
+	if t.AutoCert() {
+		tlsConfig.CA = promv1.SecretOrConfigMap{
+			ConfigMap: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: clusterCAConfigMap},
+				Key:                  certs.CAPublicCertFile,
+			},
+		}
+	} else if len(t.Spec.ExternalCertSecret) > 0 {
+		// the CA of user provided certificates is expected in the first external certificate secret
+		tlsConfig.CA = promv1.SecretOrConfigMap{
+			Secret: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: t.Spec.ExternalCertSecret[0].Name},
+				Key:                  certs.CAPublicCertFile,
+			},
+		}
+	}
+	return tlsConfig
+}
+
+// clusterMetricsPath returns true for the metrics paths reporting the whole cluster, every server answers them with the
+// same series
+func clusterMetricsPath(path string) bool {
+	path = strings.TrimSuffix(path, "/")
+	return path == miniov2.PrometheusClusterMetricsPath || path == "/minio/v2/metrics/bucket" || strings.HasPrefix(path, "/minio/metrics/v3/cluster")
+}
+
+// clusterMetricsRelabelings points the target of every server to the MinIO service and drops the labels of the pods,
+// Prometheus merges the identical targets so the cluster series are scraped once through the service rather than once
+// per server
+func clusterMetricsRelabelings(t *miniov2.Tenant) []promv1.RelabelConfig {
+	port := miniov2.MinIOPortLoadBalancerSVC
+	if t.TLS() {
+		port = miniov2.MinIOTLSPortLoadBalancerSVC
+	}
+	address := fmt.Sprintf("%s:%d", t.MinIOFQDNServiceName(), port)
+	return []promv1.RelabelConfig{
+		{Action: "replace", TargetLabel: "__address__", Replacement: &address},
+		{Action: "labeldrop", Regex: "pod|container"},
+	}
+}
+
+// NewServiceMonitorForMinIO returns a ServiceMonitor scraping every configured metrics path through the
+// MinIO service of the tenant, the node metrics are scraped on every server and the cluster metrics once
+func NewServiceMonitorForMinIO(t *miniov2.Tenant) *promv1.ServiceMonitor {
+	portName := miniov2.MinIOServiceHTTPPortName
+	if t.TLS() {
+		portName = miniov2.MinIOServiceHTTPSPortName
+	}
+
+	var endpoints []promv1.Endpoint
+	for _, path := range t.GetPrometheusOperatorScrapeMetricsPaths() {
+		endpoint := promv1.Endpoint{
+			Port:          portName,
+			Path:          path,
+			Scheme:        scheme(t),
+			Interval:      promv1.Duration(miniov2.MinIOPrometheusScrapeInterval.String()),
+			Authorization: authorization(t),
+		}
+		if tlsConfig := safeTLSConfig(t); tlsConfig != nil {
+			endpoint.TLSConfig = &promv1.TLSConfig{SafeTLSConfig: *tlsConfig}
+		}
+		if clusterMetricsPath(path) {
+			endpoint.RelabelConfigs = clusterMetricsRelabelings(t)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return &promv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            t.PrometheusMonitorName(),
+			Namespace:       t.Namespace,
+			Labels:          monitorLabels(t),
+			OwnerReferences: t.OwnerRef(),
+		},
+		Spec: promv1.ServiceMonitorSpec{
+			Endpoints: endpoints,
+			Selector: metav1.LabelSelector{
+				MatchLabels: t.MinIOPodLabels(),
+			},
+			NamespaceSelector: promv1.NamespaceSelector{
+				MatchNames: []string{t.Namespace},
+			},
+		},
+	}
+}
+
+// NewPodMonitorForMinIO returns a PodMonitor scraping every configured metrics path on each MinIO pod of the tenant,
+// the cluster metrics are scraped once through the MinIO service
+func NewPodMonitorForMinIO(t *miniov2.Tenant) *promv1.PodMonitor {
+	var endpoints []promv1.PodMetricsEndpoint
+	for _, path := range t.GetPrometheusOperatorScrapeMetricsPaths() {
+		endpoint := promv1.PodMetricsEndpoint{
+			Port:          miniov2.MinIOPortName,
+			Path:          path,
+			Scheme:        scheme(t),
+			Interval:      promv1.Duration(miniov2.MinIOPrometheusScrapeInterval.String()),
+			Authorization: authorization(t),
+			TLSConfig:     safeTLSConfig(t),
+		}
+		if clusterMetricsPath(path) {
+			endpoint.RelabelConfigs = clusterMetricsRelabelings(t)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return &promv1.PodMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            t.PrometheusMonitorName(),
+			Namespace:       t.Namespace,
+			Labels:          monitorLabels(t),
+			OwnerReferences: t.OwnerRef(),
+		},
+		Spec: promv1.PodMonitorSpec{
+			PodMetricsEndpoints: endpoints,
+			Selector: metav1.LabelSelector{
+				MatchLabels: t.MinIOPodLabels(),
+			},
+			NamespaceSelector: promv1.NamespaceSelector{
+				MatchNames: []string{t.Namespace},
+			},
+		},
+	}
+}
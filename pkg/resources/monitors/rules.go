@@ -0,0 +1,219 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package monitors
+
+import (
+	"fmt"
+	"time"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	severityWarning  = "warning"
+	severityCritical = "critical"
+)
+
+// CertificateExpiry is the expiry of a certificate served by the tenant
+type CertificateExpiry struct {
+	Name     string
+	NotAfter time.Time
+}
+
+// seriesSelector returns the label matchers selecting the series Prometheus scraped from the tenant in the
+// configured prometheus-operator mode
+func seriesSelector(t *miniov2.Tenant) string {
+	switch t.GetPrometheusOperatorMode() {
+	case miniov2.PrometheusOperatorModeServiceMonitor:
+		return fmt.Sprintf(`{namespace="%s",service="%s"}`, t.Namespace, t.MinIOCIServiceName())
+	case miniov2.PrometheusOperatorModePodMonitor:
+		return fmt.Sprintf(`{job="%s/%s"}`, t.Namespace, t.PrometheusMonitorName())
+	default:
+		return fmt.Sprintf(`{job=~"%s-[0-9]+"}`, t.PrometheusOperatorAddlConfigJobName())
+	}
+}
+
+func int32OrDefault(v *int32, def int32) int32 {
+	if v == nil {
+		return def
+	}
+	return *v
+}
+
+func duration(d time.Duration) *promv1.Duration {
+	pd := promv1.Duration(d.String())
+	return &pd
+}
+
+// NewPrometheusRuleForMinIO returns the alerting rules of the tenant, certificate expiry alerts are generated for
+// each of the given certificates
+func NewPrometheusRuleForMinIO(t *miniov2.Tenant, certificates []CertificateExpiry) *promv1.PrometheusRule {
+	config := t.Spec.PrometheusRules
+	if config == nil {
+		config = &miniov2.PrometheusRulesConfig{}
+	}
+	sel := seriesSelector(t)
+	warningThreshold := int32OrDefault(config.CapacityWarningThreshold, miniov2.DefaultPrometheusRulesCapacityWarningThreshold)
+	criticalThreshold := int32OrDefault(config.CapacityCriticalThreshold, miniov2.DefaultPrometheusRulesCapacityCriticalThreshold)
+	healingMinutes := int32OrDefault(config.HealingThresholdMinutes, miniov2.DefaultPrometheusRulesHealingThresholdMinutes)
+	usedCapacity := fmt.Sprintf("100 * (1 - max(minio_cluster_capacity_usable_free_bytes%s) / max(minio_cluster_capacity_usable_total_bytes%s))", sel, sel)
+
+	rules := []promv1.Rule{
+		{
+			Alert: "MinIODrivesOffline",
+			Expr:  intstr.FromString(fmt.Sprintf("max(minio_cluster_drive_offline_total%s) > 0", sel)),
+			For:   duration(5 * time.Minute),
+			Labels: map[string]string{
+				"severity": severityWarning,
+			},
+			Annotations: map[string]string{
+				"summary":     "MinIO drives are offline",
+				"description": fmt.Sprintf("{{ $value }} drives of tenant %s/%s are offline.", t.Namespace, t.Name),
+			},
+		},
+		{
+			Alert: "MinIOWriteQuorumAtRisk",
+			Expr: intstr.FromString(fmt.Sprintf("min by (pool, set) (minio_cluster_health_erasure_set_online_drives%s) - on (pool, set) max by (pool, set) (minio_cluster_health_erasure_set_write_quorum%s) < 1",
+				sel, sel)),
+			For: duration(time.Minute),
+			Labels: map[string]string{
+				"severity": severityCritical,
+			},
+			Annotations: map[string]string{
+				"summary":     "MinIO erasure set write quorum at risk",
+				"description": fmt.Sprintf("Erasure set {{ $labels.set }} of pool {{ $labels.pool }} in tenant %s/%s loses write quorum if another drive goes offline.", t.Namespace, t.Name),
+			},
+		},
+		{
+			Alert: "MinIOHealingTooLong",
+			Expr:  intstr.FromString(fmt.Sprintf("max(minio_cluster_health_erasure_set_healing_drives%s) > 0", sel)),
+			For:   duration(time.Duration(healingMinutes) * time.Minute),
+			Labels: map[string]string{
+				"severity": severityWarning,
+			},
+			Annotations: map[string]string{
+				"summary":     "MinIO drives healing for too long",
+				"description": fmt.Sprintf("Drives of tenant %s/%s have been healing for more than %d minutes.", t.Namespace, t.Name, healingMinutes),
+			},
+		},
+		{
+			Alert: "MinIOCapacityWarning",
+			Expr:  intstr.FromString(fmt.Sprintf("%s > %d", usedCapacity, warningThreshold)),
+			For:   duration(15 * time.Minute),
+			Labels: map[string]string{
+				"severity": severityWarning,
+			},
+			Annotations: map[string]string{
+				"summary":     "MinIO usable capacity running low",
+				"description": fmt.Sprintf("Tenant %s/%s uses {{ $value | humanize }}%% of its usable capacity, above the %d%% threshold.", t.Namespace, t.Name, warningThreshold),
+			},
+		},
+		{
+			Alert: "MinIOCapacityCritical",
+			Expr:  intstr.FromString(fmt.Sprintf("%s > %d", usedCapacity, criticalThreshold)),
+			For:   duration(5 * time.Minute),
+			Labels: map[string]string{
+				"severity": severityCritical,
+			},
+			Annotations: map[string]string{
+				"summary":     "MinIO usable capacity almost exhausted",
+				"description": fmt.Sprintf("Tenant %s/%s uses {{ $value | humanize }}%% of its usable capacity, above the %d%% threshold.", t.Namespace, t.Name, criticalThreshold),
+			},
+		},
+		{
+			Alert: "MinIONodesOffline",
+			Expr:  intstr.FromString(fmt.Sprintf("max(minio_cluster_nodes_offline_total%s) > 0", sel)),
+			For:   duration(5 * time.Minute),
+			Labels: map[string]string{
+				"severity": severityCritical,
+			},
+			Annotations: map[string]string{
+				"summary":     "MinIO nodes are offline",
+				"description": fmt.Sprintf("{{ $value }} nodes of tenant %s/%s are offline.", t.Namespace, t.Name),
+			},
+		},
+	}
+
+	// the node metrics of a random server scraped through the service can't tell which drives report errors, the
+	// alert is only generated when the monitors scrape every server
+	if t.PrometheusScrapesEveryServer() {
+		rules = append(rules, promv1.Rule{
+			Alert: "MinIODriveErrors",
+			Expr: intstr.FromString(fmt.Sprintf("sum by (server, drive) (increase(minio_node_drive_errors_ioerror%s[10m]) + increase(minio_node_drive_errors_timeout%s[10m])) > 0",
+				sel, sel)),
+			Labels: map[string]string{
+				"severity": severityWarning,
+			},
+			Annotations: map[string]string{
+				"summary":     "MinIO drive errors",
+				"description": fmt.Sprintf("Drive {{ $labels.drive }} on {{ $labels.server }} of tenant %s/%s reported {{ $value }} I/O errors or timeouts in the last 10 minutes.", t.Namespace, t.Name),
+			},
+		})
+	}
+
+	// the operator knows the certificates it hands to MinIO, their expiry is embedded in the expression so no
+	// additional exporter is needed, the rule is updated whenever a certificate is renewed
+	expiryThreshold := t.GetCertExpiryAlertThreshold()
+	for _, cert := range certificates {
+		rules = append(rules, promv1.Rule{
+			Alert: "MinIOCertificateExpiring",
+			Expr:  intstr.FromString(fmt.Sprintf("vector(%d) - time() < %d", cert.NotAfter.Unix(), int64(expiryThreshold)*24*60*60)),
+			Labels: map[string]string{
+				"severity":    severityWarning,
+				"certificate": cert.Name,
+			},
+			Annotations: map[string]string{
+				"summary":     "MinIO certificate expiring",
+				"description": fmt.Sprintf("Certificate %s of tenant %s/%s expires on %s.", cert.Name, t.Namespace, t.Name, cert.NotAfter.UTC().Format(time.RFC3339)),
+			},
+		})
+	}
+
+	for i := range rules {
+		rules[i].Labels["tenant"] = t.Name
+		rules[i].Labels["namespace"] = t.Namespace
+		for k, v := range config.AlertLabels {
+			rules[i].Labels[k] = v
+		}
+	}
+
+	labels := make(map[string]string, len(config.Labels)+1)
+	for k, v := range config.Labels {
+		labels[k] = v
+	}
+	labels[miniov2.TenantLabel] = t.Name
+
+	return &promv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            t.PrometheusRuleName(),
+			Namespace:       t.Namespace,
+			Labels:          labels,
+			OwnerReferences: t.OwnerRef(),
+		},
+		Spec: promv1.PrometheusRuleSpec{
+			Groups: []promv1.RuleGroup{
+				{
+					Name:  fmt.Sprintf("minio-%s-%s", t.Namespace, t.Name),
+					Rules: rules,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,80 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package poddisruptionbudgets
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
+	"github.com/minio/operator/pkg/resources/statefulsets"
+)
+
+// maxUnavailable returns the override of the configuration if any, or the computed value
+func maxUnavailable(config *miniov2.PodDisruptionBudgetConfig, computed int32) *intstr.IntOrString {
+	if config != nil && config.MaxUnavailable != nil {
+		value := *config.MaxUnavailable
+		return &value
+	}
+	value := intstr.FromInt32(computed)
+	return &value
+}
+
+// Enabled returns whether the PodDisruptionBudget of a configuration should be created
+func Enabled(config *miniov2.PodDisruptionBudgetConfig) bool {
+	return config == nil || config.Enabled == nil || *config.Enabled
+}
+
+// NewForPool returns the PodDisruptionBudget of the pods of a pool, `computedMaxUnavailable` is used unless the pool
+// overrides it
+func NewForPool(t *miniov2.Tenant, pool *miniov2.Pool, computedMaxUnavailable int32) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.PoolStatefulsetName(pool),
+			Namespace: t.Namespace,
+			Labels: map[string]string{
+				miniov2.TenantLabel: t.Name,
+				miniov2.PoolLabel:   pool.Name,
+			},
+			OwnerReferences: t.OwnerRef(),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: maxUnavailable(pool.PodDisruptionBudget, computedMaxUnavailable),
+			Selector:       statefulsets.ContainerMatchLabels(t, pool),
+		},
+	}
+}
+
+// NewForKES returns the PodDisruptionBudget of the KES pods, a single pod can be evicted at a time unless KES
+// overrides it
+func NewForKES(t *miniov2.Tenant) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.KESStatefulSetName(),
+			Namespace: t.Namespace,
+			Labels: map[string]string{
+				miniov2.TenantLabel: t.Name,
+			},
+			OwnerReferences: t.OwnerRef(),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: maxUnavailable(t.Spec.KES.PodDisruptionBudget, 1),
+			Selector:       statefulsets.KESSelector(t),
+		},
+	}
+}
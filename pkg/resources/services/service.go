@@ -37,10 +37,23 @@ func NewClusterIPForMinIO(t *miniov2.Tenant) *corev1.Service {
 		TargetPort: intstr.FromInt(miniov2.MinIOPort),
 	}
 
+	// the tenant label allows the tenant ServiceMonitor to select this service
+	labels := map[string]string{}
+	var annotations map[string]string
+	if t.Spec.ServiceMetadata != nil {
+		for k, v := range t.Spec.ServiceMetadata.MinIOServiceLabels {
+			labels[k] = v
+		}
+		annotations = t.Spec.ServiceMetadata.MinIOServiceAnnotations
+	}
+	labels[miniov2.TenantLabel] = t.Name
+
 	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            t.MinIOCIServiceName(),
 			Namespace:       t.Namespace,
+			Labels:          labels,
+			Annotations:     annotations,
 			OwnerReferences: t.OwnerRef(),
 		},
 		Spec: corev1.ServiceSpec{
@@ -51,11 +64,6 @@ func NewClusterIPForMinIO(t *miniov2.Tenant) *corev1.Service {
 		},
 	}
 
-	if t.Spec.ServiceMetadata != nil {
-		svc.Labels = t.Spec.ServiceMetadata.MinIOServiceLabels
-		svc.Annotations = t.Spec.ServiceMetadata.MinIOServiceAnnotations
-	}
-
 	// check if the service is meant to be exposed
 	if t.Spec.ExposeServices != nil && t.Spec.ExposeServices.MinIO {
 		svc.Spec.Type = corev1.ServiceTypeLoadBalancer
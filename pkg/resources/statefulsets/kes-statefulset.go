@@ -23,6 +23,7 @@ import (
 	"golang.org/x/mod/semver"
 
 	"github.com/minio/operator/pkg/certs"
+	"github.com/minio/operator/pkg/utils"
 
 	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
 	appsv1 "k8s.io/api/apps/v1"
@@ -54,6 +55,9 @@ func KESMetadata(t *miniov2.Tenant) metav1.ObjectMeta {
 	meta := metav1.ObjectMeta{}
 	meta.Labels = t.Spec.KES.Labels
 	meta.Annotations = t.Spec.KES.Annotations
+	if secretsHash := t.KESSecretsHash(); secretsHash != "" {
+		meta.Annotations = utils.MergeMaps(meta.Annotations, map[string]string{miniov2.CertificatesHashAnnotation: secretsHash})
+	}
 
 	if meta.Labels == nil {
 		meta.Labels = make(map[string]string)
@@ -206,9 +210,10 @@ func NewForKES(t *miniov2.Tenant, serviceName string) *appsv1.StatefulSet {
 	// in mTLS with a KMS (eg: authentication with Vault)
 	var clientCertSecret string
 
+	autoCertFile, autoKeyFile := autoCertKeys(t)
 	serverCertPaths := []corev1.KeyToPath{
-		{Key: certs.PublicCertFile, Path: certPath},
-		{Key: certs.PrivateKeyFile, Path: keyPath},
+		{Key: autoCertFile, Path: certPath},
+		{Key: autoKeyFile, Path: keyPath},
 	}
 
 	configPath := []corev1.KeyToPath{
@@ -31,7 +31,8 @@ var (
 	sidecarOnce  sync.Once
 )
 
-func getSidecarImage() string {
+// GetSidecarImage returns the operator sidecar image, overridable with OPERATOR_SIDECAR_IMAGE
+func GetSidecarImage() string {
 	sidecarOnce.Do(func() {
 		sidecarImage = DefaultSidecarImage
 		if val := os.Getenv("OPERATOR_SIDECAR_IMAGE"); val != "" {
@@ -135,6 +135,15 @@ func volumeMounts(t *miniov2.Tenant, pool *miniov2.Pool, certVolumeSources []cor
 	return mounts
 }
 
+// autoCertKeys returns the keys of the certificate and the private key in the secrets generated by AutoCert, the
+// secrets written by cert-manager use the keys of `kubernetes.io/tls` secrets
+func autoCertKeys(t *miniov2.Tenant) (string, string) {
+	if t.CertManagerIssuer() {
+		return certs.TLSCertFile, certs.TLSKeyFile
+	}
+	return certs.PublicCertFile, certs.PrivateKeyFile
+}
+
 // Builds the MinIO container for a Tenant.
 func poolMinioServerContainer(t *miniov2.Tenant, skipEnvVars map[string][]byte, pool *miniov2.Pool, certVolumeSources []corev1.VolumeProjection) corev1.Container {
 	consolePort := miniov2.ConsolePort
@@ -222,10 +231,70 @@ func poolTolerations(z *miniov2.Pool) []corev1.Toleration {
 	return append(tolerations, z.Tolerations...)
 }
 
-// Builds the topology spread constraints for a Pool.
-func poolTopologySpreadConstraints(z *miniov2.Pool) []corev1.TopologySpreadConstraint {
+// Builds the affinity for a Pool, the placement keeps every server of the pool on a different node unless the pool
+// sets its own affinity.
+func poolAffinity(t *miniov2.Tenant, z *miniov2.Pool) *corev1.Affinity {
+	if z.Affinity != nil || z.Placement == nil {
+		return z.Affinity
+	}
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: ContainerMatchLabels(t, z),
+					TopologyKey:   corev1.LabelHostname,
+				},
+			},
+		},
+	}
+}
+
+// Builds the topology spread constraints for a Pool, the zone placements balance the servers of every erasure set of
+// the pool across zones unless the pool sets its own constraints. When the erasure sets are spread across groups of
+// consecutive servers, each group is balanced on its own by selecting its pods by their StatefulSet index.
+func poolTopologySpreadConstraints(t *miniov2.Tenant, z *miniov2.Pool) []corev1.TopologySpreadConstraint {
 	var constraints []corev1.TopologySpreadConstraint
-	return append(constraints, z.TopologySpreadConstraints...)
+	if len(z.TopologySpreadConstraints) > 0 || z.Placement == nil {
+		return append(constraints, z.TopologySpreadConstraints...)
+	}
+	constraint := corev1.TopologySpreadConstraint{
+		MaxSkew:       1,
+		TopologyKey:   corev1.LabelTopologyZone,
+		LabelSelector: ContainerMatchLabels(t, z),
+	}
+	switch z.Placement.Policy {
+	case miniov2.PoolPlacementSpreadZones:
+		constraint.WhenUnsatisfiable = corev1.ScheduleAnyway
+	case miniov2.PoolPlacementStrictSpreadZones:
+		// a server stays pending rather than running in a zone with more than its share of the servers, so the loss
+		// of a zone never costs more servers than validated
+		constraint.WhenUnsatisfiable = corev1.DoNotSchedule
+		if z.Placement.Zones > 0 {
+			minDomains := z.Placement.Zones
+			constraint.MinDomains = &minDomains
+		}
+	default:
+		return nil
+	}
+	setServers := int32(z.ErasureSetServers())
+	if setServers <= 0 || setServers >= z.Servers {
+		return append(constraints, constraint)
+	}
+	for first := int32(0); first < z.Servers; first += setServers {
+		var indexes []string
+		for i := first; i < first+setServers && i < z.Servers; i++ {
+			indexes = append(indexes, strconv.Itoa(int(i)))
+		}
+		groupConstraint := constraint
+		groupConstraint.LabelSelector = ContainerMatchLabels(t, z)
+		groupConstraint.LabelSelector.MatchExpressions = []metav1.LabelSelectorRequirement{{
+			Key:      appsv1.PodIndexLabel,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   indexes,
+		}}
+		constraints = append(constraints, groupConstraint)
+	}
+	return constraints
 }
 
 // Builds the security context for a Pool
@@ -319,14 +388,15 @@ func NewPool(args *NewPoolArgs) *appsv1.StatefulSet {
 	replicas := pool.Servers
 	var certVolumeSources []corev1.VolumeProjection
 
+	autoCertFile, autoKeyFile := autoCertKeys(t)
 	var clientCertSecret string
 	clientCertPaths := []corev1.KeyToPath{
-		{Key: certs.PublicCertFile, Path: "client.crt"},
-		{Key: certs.PrivateKeyFile, Path: "client.key"},
+		{Key: autoCertFile, Path: "client.crt"},
+		{Key: autoKeyFile, Path: "client.key"},
 	}
 	var kesCertSecret string
 	KESCertPath := []corev1.KeyToPath{
-		{Key: certs.PublicCertFile, Path: "CAs/kes.crt"},
+		{Key: autoCertFile, Path: "CAs/kes.crt"},
 	}
 
 	// Create an empty dir volume to share the configuration between the main container and side-car
@@ -422,12 +492,32 @@ func NewPool(args *NewPoolArgs) *appsv1.StatefulSet {
 					Name: t.MinIOTLSSecretName(),
 				},
 				Items: []corev1.KeyToPath{
-					{Key: certs.PublicCertFile, Path: crtMountPath},
-					{Key: certs.PrivateKeyFile, Path: keyMountPath},
-					{Key: certs.PublicCertFile, Path: caMountPath},
+					{Key: autoCertFile, Path: crtMountPath},
+					{Key: autoKeyFile, Path: keyMountPath},
+					{Key: autoCertFile, Path: caMountPath},
 				},
 			},
 		})
+		// Certificates issued for the domains of the tenant follow the AutoCert certificate, so MinIO picks them
+		// through SNI for the advertised hostnames
+		for i := range t.CertificateDomains() {
+			index := i
+			if len(t.Spec.ExternalCertSecret) > 0 {
+				index += len(t.Spec.ExternalCertSecret) + 1
+			}
+			certVolumeSources = append(certVolumeSources, corev1.VolumeProjection{
+				Secret: &corev1.SecretProjection{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: t.MinIODomainTLSSecretName(i),
+					},
+					Items: []corev1.KeyToPath{
+						{Key: autoCertFile, Path: fmt.Sprintf("hostname-%d/%s", index, certs.PublicCertFile)},
+						{Key: autoKeyFile, Path: fmt.Sprintf("hostname-%d/%s", index, certs.PrivateKeyFile)},
+						{Key: autoCertFile, Path: fmt.Sprintf("CAs/hostname-%d.crt", index)},
+					},
+				},
+			})
+		}
 	}
 	// Multiple client certificates will be mounted using the following folder structure:
 	//
@@ -651,11 +741,11 @@ func NewPool(args *NewPoolArgs) *appsv1.StatefulSet {
 					Containers:                    containers,
 					Volumes:                       podVolumes,
 					RestartPolicy:                 corev1.RestartPolicyAlways,
-					Affinity:                      pool.Affinity,
+					Affinity:                      poolAffinity(t, pool),
 					NodeSelector:                  pool.NodeSelector,
 					SchedulerName:                 t.Scheduler.Name,
 					Tolerations:                   poolTolerations(pool),
-					TopologySpreadConstraints:     poolTopologySpreadConstraints(pool),
+					TopologySpreadConstraints:     poolTopologySpreadConstraints(t, pool),
 					SecurityContext:               poolSecurityContext(pool, poolStatus),
 					ServiceAccountName:            t.Spec.ServiceAccountName,
 					PriorityClassName:             t.Spec.PriorityClassName,
@@ -707,7 +797,7 @@ func NewPool(args *NewPoolArgs) *appsv1.StatefulSet {
 func getInitContainer(t *miniov2.Tenant, pool *miniov2.Pool) corev1.Container {
 	initContainer := corev1.Container{
 		Name:  "validate-arguments",
-		Image: getSidecarImage(),
+		Image: GetSidecarImage(),
 		Args: []string{
 			"validate",
 			"--tenant",
@@ -756,7 +846,7 @@ func getSideCarContainer(t *miniov2.Tenant, pool *miniov2.Pool) corev1.Container
 
 	sidecarContainer := corev1.Container{
 		Name:  "sidecar",
-		Image: getSidecarImage(),
+		Image: GetSidecarImage(),
 		Args: []string{
 			"sidecar",
 			"--tenant",
@@ -18,8 +18,11 @@ package statefulsets
 
 import (
 	"reflect"
+	"strconv"
 	"testing"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	miniov2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
@@ -102,3 +105,91 @@ func TestGetContainerArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestPoolPlacement(t *testing.T) {
+	tenant := &miniov2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "myminio"}}
+	pool := &miniov2.Pool{
+		Name:      "pool-0",
+		Servers:   8,
+		Placement: &miniov2.PoolPlacement{Policy: miniov2.PoolPlacementStrictSpreadZones, Zones: 4},
+	}
+	affinity := poolAffinity(tenant, pool)
+	if affinity == nil || affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].TopologyKey != corev1.LabelHostname {
+		t.Errorf("affinity = %+v, want an anti-affinity across nodes", affinity)
+	}
+	constraints := poolTopologySpreadConstraints(tenant, pool)
+	if len(constraints) != 1 || constraints[0].TopologyKey != corev1.LabelTopologyZone ||
+		constraints[0].WhenUnsatisfiable != corev1.DoNotSchedule || *constraints[0].MinDomains != 4 ||
+		constraints[0].LabelSelector.MatchLabels[miniov2.PoolLabel] != "pool-0" {
+		t.Errorf("constraints = %+v, want a strict spread across 4 zones", constraints)
+	}
+
+	// the erasure sets of 32 servers with a drive each span 16 servers, each half of the pool is spread on its own
+	wide := &miniov2.Pool{
+		Name:             "pool-1",
+		Servers:          32,
+		VolumesPerServer: 1,
+		Placement:        &miniov2.PoolPlacement{Policy: miniov2.PoolPlacementStrictSpreadZones, Zones: 4},
+	}
+	constraints = poolTopologySpreadConstraints(tenant, wide)
+	if len(constraints) != 2 {
+		t.Fatalf("constraints = %+v, want one per group of erasure sets", constraints)
+	}
+	for i, constraint := range constraints {
+		expressions := constraint.LabelSelector.MatchExpressions
+		if len(expressions) != 1 || expressions[0].Key != appsv1.PodIndexLabel || len(expressions[0].Values) != 16 ||
+			expressions[0].Values[0] != strconv.Itoa(i*16) || constraint.LabelSelector.MatchLabels[miniov2.PoolLabel] != "pool-1" {
+			t.Errorf("constraint %d selects %+v, want the servers %d to %d", i, constraint.LabelSelector, i*16, i*16+15)
+		}
+	}
+
+	// the affinity and constraints of the pool take precedence
+	pool.Affinity = &corev1.Affinity{}
+	pool.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{MaxSkew: 2}}
+	if poolAffinity(tenant, pool) != pool.Affinity {
+		t.Error("affinity of the pool was overridden")
+	}
+	if constraints = poolTopologySpreadConstraints(tenant, pool); len(constraints) != 1 || constraints[0].MaxSkew != 2 {
+		t.Errorf("constraints = %+v, want the constraints of the pool", constraints)
+	}
+}
+
+func TestNewPoolDomainCertificates(t *testing.T) {
+	autoCert := true
+	tenant := &miniov2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "myminio", Namespace: "ns"},
+		Spec: miniov2.TenantSpec{
+			RequestAutoCert: &autoCert,
+			CertConfig:      &miniov2.CertificateConfig{DomainCertificates: true},
+			Configuration:   &corev1.LocalObjectReference{Name: "myminio-env-configuration"},
+			Features: &miniov2.Features{
+				BucketDNS: true,
+				Domains: &miniov2.TenantDomains{
+					Minio:   []string{"s3.example.com"},
+					Console: "https://console.example.com:9443/",
+				},
+			},
+			Pools: []miniov2.Pool{{Name: "pool-0", Servers: 4, VolumesPerServer: 1}},
+		},
+	}
+	tenant.EnsureDefaults()
+	if domains := tenant.CertificateDomains(); !reflect.DeepEqual(domains, []string{"s3.example.com", "console.example.com"}) {
+		t.Fatalf("CertificateDomains() = %v", domains)
+	}
+	statefulSet := NewPool(&NewPoolArgs{Tenant: tenant, Pool: &tenant.Spec.Pools[0], PoolStatus: &miniov2.PoolStatus{}})
+	paths := map[string]string{}
+	for _, volume := range statefulSet.Spec.Template.Spec.Volumes {
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.Secret != nil && len(source.Secret.Items) > 0 {
+				paths[source.Secret.Name] = source.Secret.Items[0].Path
+			}
+		}
+	}
+	if paths[tenant.MinIOTLSSecretName()] != "public.crt" || paths[tenant.MinIODomainTLSSecretName(0)] != "hostname-0/public.crt" ||
+		paths[tenant.MinIODomainTLSSecretName(1)] != "hostname-1/public.crt" {
+		t.Errorf("certificate paths = %v, want the domain certificates after the AutoCert certificate", paths)
+	}
+}
@@ -23,4 +23,5 @@ import (
 var appCmds = []cli.Command{
 	sidecarCmd,
 	validateCmd,
+	stsCredentialsCmd,
 }
@@ -0,0 +1,91 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/minio/cli"
+	"github.com/minio/operator/sidecar/pkg/stscredentials"
+)
+
+// keeps an AWS credentials file up to date with Operator STS credentials
+var stsCredentialsCmd = cli.Command{
+	Name:   "sts-credentials",
+	Usage:  "Keep an AWS shared credentials file refreshed with Operator STS credentials",
+	Action: startSTSCredentials,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "sts-endpoint",
+			Value: "",
+			Usage: "Operator STS endpoint, including the tenant namespace",
+		},
+		cli.StringFlag{
+			Name:  "token-file",
+			Value: "/var/run/secrets/sts.min.io/serviceaccount/token",
+			Usage: "service account token with the sts.min.io audience",
+		},
+		cli.StringFlag{
+			Name:  "ca-file",
+			Value: "",
+			Usage: "CA bundle used to verify the STS endpoint",
+		},
+		cli.StringFlag{
+			Name:  "credentials-file",
+			Value: "/var/run/secrets/sts.min.io/credentials/credentials",
+			Usage: "AWS shared credentials file to write",
+		},
+		cli.BoolFlag{
+			Name:  "check",
+			Usage: "only check that the credentials file has been written",
+		},
+	},
+}
+
+func startSTSCredentials(ctx *cli.Context) {
+	credentialsFile := ctx.String("credentials-file")
+	if ctx.Bool("check") {
+		if err := stscredentials.Ready(credentialsFile); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+	stsEndpoint := ctx.String("sts-endpoint")
+	if stsEndpoint == "" {
+		log.Println("Must pass --sts-endpoint flag")
+		os.Exit(1)
+	}
+
+	sigCtx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	err := stscredentials.Run(sigCtx, stscredentials.Config{
+		STSEndpoint:     stsEndpoint,
+		TokenFile:       ctx.String("token-file"),
+		CAFile:          ctx.String("ca-file"),
+		CredentialsFile: credentialsFile,
+	})
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+}
@@ -7,6 +7,7 @@ toolchain go1.23.7
 require (
 	github.com/gorilla/mux v1.8.1
 	github.com/minio/cli v1.24.2
+	github.com/minio/minio-go/v7 v7.0.89
 	github.com/minio/operator v0.0.0-20250423195428-51a1c64002f8
 	github.com/minio/pkg v1.7.5
 	k8s.io/api v0.32.3
@@ -53,7 +54,6 @@ require (
 	github.com/minio/crc64nvme v1.0.1 // indirect
 	github.com/minio/madmin-go/v3 v3.0.100 // indirect
 	github.com/minio/md5-simd v1.1.2 // indirect
-	github.com/minio/minio-go/v7 v7.0.89 // indirect
 	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
 	github.com/modern-go/reflect2 v1.0.2 // indirect
 	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
@@ -19,9 +19,11 @@ package sidecar
 import (
 	"log"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/gorilla/mux"
+	v2 "github.com/minio/operator/pkg/apis/minio.min.io/v2"
 	"github.com/minio/operator/pkg/common"
 )
 
@@ -33,6 +35,11 @@ func configureSidecarServer(c *Controller) *http.Server {
 		HandlerFunc(c.CheckConfigHandler).
 		Queries(restQueries("c")...)
 
+	router.Methods(http.MethodPost).
+		Path(common.SidecarAPIRootCredentialsEndpoint).
+		HandlerFunc(c.CheckRootCredentialsHandler).
+		Queries(restQueries("c")...)
+
 	router.NotFoundHandler = http.NotFoundHandler()
 
 	s := &http.Server{
@@ -54,3 +61,23 @@ func (c *Controller) CheckConfigHandler(_ http.ResponseWriter, r *http.Request)
 
 	log.Println("Checking config hash: ", hash)
 }
+
+// CheckRootCredentialsHandler - POST /sidecar/v1/root-credentials?c={hash}
+// Answers 200 when the root credentials of config.env match the hash, 412 otherwise. The operator waits for every pod
+// to answer 200 before restarting MinIO to load new root credentials.
+func (c *Controller) CheckRootCredentialsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	contents, err := os.ReadFile(v2.CfgFile)
+	if err != nil {
+		log.Println("could not read the configuration", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	config := v2.ParseRawConfiguration(contents)
+	if v2.RootCredentialsHash(config["accesskey"], config["secretkey"]) != vars["c"] {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
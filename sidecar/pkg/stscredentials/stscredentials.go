@@ -0,0 +1,154 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stscredentials
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// refreshInterval is how often the credentials are checked for expiration, the
+// credentials themselves are renewed once 80% of their lifetime has elapsed
+var refreshInterval = 30 * time.Second
+
+// Config holds the settings for the credentials refresher
+type Config struct {
+	// STSEndpoint is the full Operator STS URL including the tenant namespace
+	STSEndpoint string
+	// TokenFile is the projected service account token with the sts.min.io audience
+	TokenFile string
+	// CAFile is a PEM bundle used to verify the STS endpoint, optional
+	CAFile string
+	// CredentialsFile is the AWS shared credentials file to keep up to date
+	CredentialsFile string
+}
+
+// Run fetches temporary credentials from the Operator STS and rewrites the
+// credentials file every time they are renewed, it only returns on context cancellation
+func Run(ctx context.Context, cfg Config) error {
+	creds, err := newCredentials(cfg)
+	if err != nil {
+		return err
+	}
+
+	var current credentials.Value
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		value, err := creds.GetWithContext(nil)
+		if err != nil {
+			log.Printf("unable to retrieve STS credentials: %v", err)
+		} else if value.AccessKeyID != current.AccessKeyID || value.SessionToken != current.SessionToken {
+			if err := WriteCredentialsFile(cfg.CredentialsFile, value); err != nil {
+				log.Printf("unable to write credentials file %s: %v", cfg.CredentialsFile, err)
+			} else {
+				current = value
+				log.Printf("STS credentials refreshed, expiring at %s", value.Expiration.Format(time.RFC3339))
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Ready returns nil once the credentials file has been written
+func Ready(credentialsFile string) error {
+	fi, err := os.Stat(credentialsFile)
+	if err != nil {
+		return err
+	}
+	if fi.Size() == 0 {
+		return fmt.Errorf("credentials file %s is empty", credentialsFile)
+	}
+	return nil
+}
+
+func newCredentials(cfg Config) (*credentials.Credentials, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if cfg.CAFile != "" {
+		caBytes, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caBytes) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return credentials.NewSTSWebIdentity(cfg.STSEndpoint, func() (*credentials.WebIdentityToken, error) {
+		// the kubelet rotates the projected token, always read the latest one
+		token, err := os.ReadFile(cfg.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		return &credentials.WebIdentityToken{
+			Token: strings.TrimSpace(string(token)),
+		}, nil
+	}, func(i *credentials.STSWebIdentity) {
+		i.Client = &http.Client{Transport: transport}
+	})
+}
+
+// WriteCredentialsFile atomically replaces the AWS shared credentials file with the given value
+func WriteCredentialsFile(path string, value credentials.Value) error {
+	var sb strings.Builder
+	sb.WriteString("[default]\n")
+	fmt.Fprintf(&sb, "aws_access_key_id = %s\n", value.AccessKeyID)
+	fmt.Fprintf(&sb, "aws_secret_access_key = %s\n", value.SecretAccessKey)
+	fmt.Fprintf(&sb, "aws_session_token = %s\n", value.SessionToken)
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".credentials-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.WriteString(sb.String()); err != nil {
+		tmp.Close()
+		return err
+	}
+	// the application container may run as a different user than the sidecar
+	if err = tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
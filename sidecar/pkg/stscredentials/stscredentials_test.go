@@ -0,0 +1,200 @@
+// This file is part of MinIO Operator
+// Copyright (c) 2025 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stscredentials
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// newSTSServer serves AssumeRoleWithWebIdentity with a new access key on every call, the first credentials are already
+// expired so they are renewed on the next refresh
+func newSTSServer(t *testing.T, status int) (*atomic.Int32, Config) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("WebIdentityToken") != "token" {
+			http.Error(w, "invalid token", http.StatusBadRequest)
+			return
+		}
+		call := calls.Add(1)
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		expiration := time.Now().Add(time.Hour)
+		if call == 1 {
+			expiration = time.Now()
+		}
+		fmt.Fprintf(w, `<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+<AssumeRoleWithWebIdentityResult><Credentials><AccessKeyId>AK%d</AccessKeyId><SecretAccessKey>SK%d</SecretAccessKey>
+<SessionToken>TOKEN%d</SessionToken><Expiration>%s</Expiration></Credentials></AssumeRoleWithWebIdentityResult>
+</AssumeRoleWithWebIdentityResponse>`, call, call, call, expiration.UTC().Format(time.RFC3339))
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	cfg := Config{
+		STSEndpoint:     server.URL,
+		TokenFile:       filepath.Join(dir, "token"),
+		CAFile:          filepath.Join(dir, "ca.crt"),
+		CredentialsFile: filepath.Join(dir, "credentials"),
+	}
+	if err := os.WriteFile(cfg.TokenFile, []byte("token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(cfg.CAFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return &calls, cfg
+}
+
+func TestRunRefreshesCredentials(t *testing.T) {
+	defer func(interval time.Duration) { refreshInterval = interval }(refreshInterval)
+	refreshInterval = 10 * time.Millisecond
+	_, cfg := newSTSServer(t, http.StatusOK)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- Run(ctx, cfg) }()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Run returned %v on cancellation", err)
+		}
+	}()
+
+	// the expired credentials are renewed and the file rewritten with the new ones
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		content, _ := os.ReadFile(cfg.CredentialsFile)
+		if strings.Contains(string(content), "aws_access_key_id = AK2\n") {
+			if !strings.Contains(string(content), "aws_session_token = TOKEN2\n") {
+				t.Fatalf("credentials file holds a mismatched session token:\n%s", content)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("credentials were not refreshed, credentials file:\n%s", content)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := Ready(cfg.CredentialsFile); err != nil {
+		t.Errorf("Ready() = %v once the credentials were written", err)
+	}
+}
+
+func TestRunKeepsRetryingOnSTSErrors(t *testing.T) {
+	defer func(interval time.Duration) { refreshInterval = interval }(refreshInterval)
+	refreshInterval = 10 * time.Millisecond
+	calls, cfg := newSTSServer(t, http.StatusForbidden)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- Run(ctx, cfg) }()
+	deadline := time.Now().Add(10 * time.Second)
+	for calls.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run returned %v on cancellation", err)
+	}
+	if calls.Load() < 2 {
+		t.Errorf("STS was called %d times, want the failed request retried", calls.Load())
+	}
+	if err := Ready(cfg.CredentialsFile); err == nil {
+		t.Error("Ready() = nil without credentials")
+	}
+}
+
+func TestRunInvalidCAFile(t *testing.T) {
+	_, cfg := newSTSServer(t, http.StatusOK)
+	if err := os.WriteFile(cfg.CAFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Run(context.Background(), cfg); err == nil {
+		t.Error("Run() = nil with a CA file holding no certificate")
+	}
+	cfg.CAFile = filepath.Join(t.TempDir(), "missing.crt")
+	if err := Run(context.Background(), cfg); err == nil {
+		t.Error("Run() = nil with a missing CA file")
+	}
+}
+
+func TestWriteCredentialsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte("stale"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteCredentialsFile(path, credentials.Value{AccessKeyID: "AK", SecretAccessKey: "SK", SessionToken: "TOKEN"}); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[default]\naws_access_key_id = AK\naws_secret_access_key = SK\naws_session_token = TOKEN\n"
+	if string(content) != want {
+		t.Errorf("credentials file =\n%s\nwant\n%s", content, want)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0o644 {
+		t.Errorf("credentials file mode = %o, want it readable by the application container", fi.Mode().Perm())
+	}
+	// no temporary file is left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("%d files in the credentials directory, want 1", len(entries))
+	}
+
+	if err = WriteCredentialsFile(filepath.Join(t.TempDir(), "missing", "credentials"), credentials.Value{}); err == nil {
+		t.Error("WriteCredentialsFile() = nil in a missing directory")
+	}
+}
+
+func TestReady(t *testing.T) {
+	dir := t.TempDir()
+	if err := Ready(filepath.Join(dir, "credentials")); err == nil {
+		t.Error("Ready() = nil without a credentials file")
+	}
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(empty, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Ready(empty); err == nil {
+		t.Error("Ready() = nil with an empty credentials file")
+	}
+}